@@ -0,0 +1,239 @@
+package ast
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/rmaidveo/go-calculator/containers"
+	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/rmaidveo/go-calculator/translator"
+)
+
+type NodeKind int
+
+const (
+	NumberNode NodeKind = iota
+	VariableNode
+	CallNode
+	AssignmentNode
+	LetNode
+	SequenceNode
+)
+
+type Node struct {
+	Kind      NodeKind
+	Value     string
+	Position  int
+	Arguments []*Node
+}
+
+func Number(number float64, position int) *Node {
+	return &Node{
+		Kind:     NumberNode,
+		Value:    strconv.FormatFloat(number, 'f', -1, 64),
+		Position: position,
+	}
+}
+
+func Variable(name string, position int) *Node {
+	return &Node{
+		Kind:     VariableNode,
+		Value:    name,
+		Position: position,
+	}
+}
+
+func Call(name string, position int, arguments ...*Node) *Node {
+	return &Node{
+		Kind:      CallNode,
+		Value:     name,
+		Position:  position,
+		Arguments: arguments,
+	}
+}
+
+func (node *Node) Number() (float64, bool) {
+	if node.Kind != NumberNode {
+		return 0, false
+	}
+
+	number, err := strconv.ParseFloat(node.Value, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return number, true
+}
+
+func (node *Node) IsNumber(number float64) bool {
+	nodeNumber, ok := node.Number()
+	return ok && nodeNumber == number
+}
+
+func Build(commands []translator.Command, functions map[string]evaluator.Function) (*Node, error) {
+	var statements []*Node
+	var nodeStack containers.Stack[*Node]
+	var letStack containers.Stack[*Node]
+	for _, command := range commands {
+		switch command.Kind {
+		case translator.PushNumberCommand:
+			nodeStack.Push(&Node{
+				Kind:     NumberNode,
+				Value:    command.Operand,
+				Position: command.Position,
+			})
+		case translator.PushVariableCommand:
+			nodeStack.Push(Variable(command.Operand, command.Position))
+		case translator.CallFunctionCommand:
+			function, ok := functions[command.Operand]
+			if !ok {
+				return nil, fmt.Errorf("unknown function %q at position %d", command.Operand, command.Position)
+			}
+
+			arguments := make([]*Node, function.Arity)
+			for argumentIndex := function.Arity - 1; argumentIndex >= 0; argumentIndex-- {
+				argument, ok := nodeStack.Pop()
+				if !ok {
+					return nil, fmt.Errorf("node stack is empty for argument #%d at position %d", argumentIndex, command.Position)
+				}
+
+				arguments[argumentIndex] = argument
+			}
+
+			nodeStack.Push(Call(command.Operand, command.Position, arguments...))
+		case translator.AssignVariableCommand:
+			value, ok := nodeStack.Pop()
+			if !ok {
+				return nil, fmt.Errorf("node stack is empty for the assignment to %q at position %d", command.Operand, command.Position)
+			}
+
+			nodeStack.Push(&Node{
+				Kind:      AssignmentNode,
+				Value:     command.Operand,
+				Position:  command.Position,
+				Arguments: []*Node{value},
+			})
+		case translator.BindVariableCommand:
+			value, ok := nodeStack.Pop()
+			if !ok {
+				return nil, fmt.Errorf("node stack is empty for the binding of %q at position %d", command.Operand, command.Position)
+			}
+
+			letStack.Push(&Node{
+				Kind:      LetNode,
+				Value:     command.Operand,
+				Position:  command.Position,
+				Arguments: []*Node{value},
+			})
+		case translator.UnbindVariableCommand:
+			body, ok := nodeStack.Pop()
+			if !ok {
+				return nil, fmt.Errorf("node stack is empty for the binding of %q at position %d", command.Operand, command.Position)
+			}
+
+			letNode, ok := letStack.Pop()
+			if !ok || letNode.Value != command.Operand {
+				return nil, fmt.Errorf("no binding of %q is found at position %d", command.Operand, command.Position)
+			}
+
+			letNode.Arguments = append(letNode.Arguments, body)
+			nodeStack.Push(letNode)
+		case translator.PopCommand:
+			statement, ok := nodeStack.Pop()
+			if !ok || !nodeStack.IsEmpty() {
+				return nil, fmt.Errorf("unexpected end of the statement at position %d", command.Position)
+			}
+
+			statements = append(statements, statement)
+		}
+	}
+
+	statement, ok := nodeStack.Pop()
+	if !ok {
+		return nil, errors.New("node stack is empty")
+	}
+	if !nodeStack.IsEmpty() || !letStack.IsEmpty() {
+		return nil, errors.New("node stack has extra nodes")
+	}
+	if len(statements) == 0 {
+		return statement, nil
+	}
+
+	statements = append(statements, statement)
+	sequence := &Node{
+		Kind:      SequenceNode,
+		Position:  statements[0].Position,
+		Arguments: statements,
+	}
+	return sequence, nil
+}
+
+func (node *Node) Commands() []translator.Command {
+	var commands []translator.Command
+	node.appendCommands(&commands)
+
+	return commands
+}
+
+func (node *Node) appendCommands(commands *[]translator.Command) {
+	switch node.Kind {
+	case NumberNode:
+		*commands = append(*commands, translator.Command{
+			Kind:     translator.PushNumberCommand,
+			Operand:  node.Value,
+			Position: node.Position,
+		})
+	case VariableNode:
+		*commands = append(*commands, translator.Command{
+			Kind:     translator.PushVariableCommand,
+			Operand:  node.Value,
+			Position: node.Position,
+		})
+	case CallNode:
+		for _, argument := range node.Arguments {
+			argument.appendCommands(commands)
+		}
+
+		*commands = append(*commands, translator.Command{
+			Kind:     translator.CallFunctionCommand,
+			Operand:  node.Value,
+			Position: node.Position,
+		})
+	case AssignmentNode:
+		node.Arguments[0].appendCommands(commands)
+
+		*commands = append(*commands, translator.Command{
+			Kind:     translator.AssignVariableCommand,
+			Operand:  node.Value,
+			Position: node.Position,
+		})
+	case LetNode:
+		node.Arguments[0].appendCommands(commands)
+
+		*commands = append(*commands, translator.Command{
+			Kind:     translator.BindVariableCommand,
+			Operand:  node.Value,
+			Position: node.Position,
+		})
+
+		node.Arguments[1].appendCommands(commands)
+
+		*commands = append(*commands, translator.Command{
+			Kind:     translator.UnbindVariableCommand,
+			Operand:  node.Value,
+			Position: node.Position,
+		})
+	case SequenceNode:
+		for statementIndex, statement := range node.Arguments {
+			if statementIndex > 0 {
+				*commands = append(*commands, translator.Command{
+					Kind:     translator.PopCommand,
+					Position: statement.Position,
+				})
+			}
+
+			statement.appendCommands(commands)
+		}
+	}
+}
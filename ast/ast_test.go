@@ -0,0 +1,211 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/rmaidveo/go-calculator/translator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuild(t *testing.T) {
+	type args struct {
+		commands  []translator.Command
+		functions map[string]evaluator.Function
+	}
+
+	functions := map[string]evaluator.Function{
+		"+":   {Arity: 2},
+		"neg": {Arity: 1},
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    *Node
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success/number",
+			args: args{
+				commands: []translator.Command{
+					{Kind: translator.PushNumberCommand, Operand: "23", Position: 42},
+				},
+				functions: functions,
+			},
+			want:    &Node{Kind: NumberNode, Value: "23", Position: 42},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/function call",
+			args: args{
+				commands: []translator.Command{
+					{Kind: translator.PushNumberCommand, Operand: "23", Position: 123},
+					{Kind: translator.PushVariableCommand, Operand: "x", Position: 142},
+					{Kind: translator.CallFunctionCommand, Operand: "neg", Position: 140},
+					{Kind: translator.CallFunctionCommand, Operand: "+", Position: 130},
+				},
+				functions: functions,
+			},
+			want: &Node{
+				Kind:     CallNode,
+				Value:    "+",
+				Position: 130,
+				Arguments: []*Node{
+					{Kind: NumberNode, Value: "23", Position: 123},
+					{
+						Kind:      CallNode,
+						Value:     "neg",
+						Position:  140,
+						Arguments: []*Node{{Kind: VariableNode, Value: "x", Position: 142}},
+					},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/statements",
+			args: args{
+				commands: []translator.Command{
+					{Kind: translator.PushNumberCommand, Operand: "23", Position: 104},
+					{Kind: translator.AssignVariableCommand, Operand: "x", Position: 100},
+					{Kind: translator.PopCommand, Position: 106},
+					{Kind: translator.PushVariableCommand, Operand: "x", Position: 120},
+					{Kind: translator.BindVariableCommand, Operand: "y", Position: 114},
+					{Kind: translator.PushVariableCommand, Operand: "y", Position: 125},
+					{Kind: translator.UnbindVariableCommand, Operand: "y", Position: 114},
+				},
+				functions: functions,
+			},
+			want: &Node{
+				Kind:     SequenceNode,
+				Position: 100,
+				Arguments: []*Node{
+					{
+						Kind:      AssignmentNode,
+						Value:     "x",
+						Position:  100,
+						Arguments: []*Node{{Kind: NumberNode, Value: "23", Position: 104}},
+					},
+					{
+						Kind:     LetNode,
+						Value:    "y",
+						Position: 114,
+						Arguments: []*Node{
+							{Kind: VariableNode, Value: "x", Position: 120},
+							{Kind: VariableNode, Value: "y", Position: 125},
+						},
+					},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error/unknown function",
+			args: args{
+				commands: []translator.Command{
+					{Kind: translator.PushNumberCommand, Operand: "23", Position: 123},
+					{Kind: translator.CallFunctionCommand, Operand: "unknown", Position: 130},
+				},
+				functions: functions,
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/node stack is empty for an argument",
+			args: args{
+				commands: []translator.Command{
+					{Kind: translator.PushNumberCommand, Operand: "23", Position: 123},
+					{Kind: translator.CallFunctionCommand, Operand: "+", Position: 130},
+				},
+				functions: functions,
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/no binding",
+			args: args{
+				commands: []translator.Command{
+					{Kind: translator.PushNumberCommand, Operand: "23", Position: 123},
+					{Kind: translator.UnbindVariableCommand, Operand: "x", Position: 130},
+				},
+				functions: functions,
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/extra nodes",
+			args: args{
+				commands: []translator.Command{
+					{Kind: translator.PushNumberCommand, Operand: "23", Position: 123},
+					{Kind: translator.PushNumberCommand, Operand: "42", Position: 142},
+				},
+				functions: functions,
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/no commands",
+			args: args{
+				commands:  []translator.Command{},
+				functions: functions,
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Build(tt.args.commands, tt.args.functions)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestNode_Commands(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands []translator.Command
+	}{
+		{
+			name: "function call",
+			commands: []translator.Command{
+				{Kind: translator.PushNumberCommand, Operand: "23", Position: 123},
+				{Kind: translator.PushVariableCommand, Operand: "x", Position: 142},
+				{Kind: translator.CallFunctionCommand, Operand: "neg", Position: 140},
+				{Kind: translator.CallFunctionCommand, Operand: "+", Position: 130},
+			},
+		},
+		{
+			name: "statements",
+			commands: []translator.Command{
+				{Kind: translator.PushNumberCommand, Operand: "23", Position: 104},
+				{Kind: translator.AssignVariableCommand, Operand: "x", Position: 100},
+				{Kind: translator.PopCommand, Position: 114},
+				{Kind: translator.PushVariableCommand, Operand: "x", Position: 120},
+				{Kind: translator.BindVariableCommand, Operand: "y", Position: 114},
+				{Kind: translator.PushVariableCommand, Operand: "y", Position: 125},
+				{Kind: translator.UnbindVariableCommand, Operand: "y", Position: 114},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := Build(tt.commands, map[string]evaluator.Function{
+				"+":   {Arity: 2},
+				"neg": {Arity: 1},
+			})
+			assert.NoError(t, err)
+
+			got := node.Commands()
+
+			assert.Equal(t, tt.commands, got)
+		})
+	}
+}
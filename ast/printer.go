@@ -0,0 +1,149 @@
+package ast
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/rmaidveo/go-calculator/tokenizer"
+)
+
+const (
+	lowestPrecedence  = 0
+	highestPrecedence = 10
+)
+
+func (node *Node) String() string {
+	var builder strings.Builder
+	node.write(&builder)
+
+	return builder.String()
+}
+
+func (node *Node) Precedence() int {
+	switch node.Kind {
+	case NumberNode:
+		if strings.HasPrefix(node.Value, "-") {
+			return tokenizer.NegationToken.Precedence()
+		}
+
+		return highestPrecedence
+	case VariableNode:
+		return highestPrecedence
+	case CallNode:
+		if kind, ok := node.Operator(); ok {
+			return kind.Precedence()
+		}
+
+		return highestPrecedence
+	default:
+		return lowestPrecedence
+	}
+}
+
+func (node *Node) Operator() (tokenizer.TokenKind, bool) {
+	if node.Kind != CallNode {
+		return 0, false
+	}
+
+	kind, ok := tokenizer.ParseOperator(node.Value)
+	if !ok {
+		return 0, false
+	}
+
+	isUnary := kind == tokenizer.NegationToken || kind.IsPostfix()
+	if isUnary && len(node.Arguments) != 1 || !isUnary && len(node.Arguments) != 2 {
+		return 0, false
+	}
+
+	return kind, true
+}
+
+func (node *Node) NeedsParentheses(parent *Node, argumentIndex int) bool {
+	parentKind, ok := parent.Operator()
+	if !ok {
+		return parent.Kind == CallNode && node.Kind == SequenceNode
+	}
+
+	precedence, parentPrecedence := node.Precedence(), parentKind.Precedence()
+	if parentKind == tokenizer.NegationToken || parentKind.IsPostfix() {
+		return precedence < parentPrecedence
+	}
+
+	isAssociativeSide := argumentIndex == 0 && !parentKind.IsRightAssociative() ||
+		argumentIndex == 1 && parentKind.IsRightAssociative()
+	if isAssociativeSide {
+		return precedence < parentPrecedence
+	}
+
+	return precedence <= parentPrecedence
+}
+
+func (node *Node) write(builder *strings.Builder) {
+	switch node.Kind {
+	case NumberNode:
+		if number, ok := node.Number(); ok && strings.ContainsAny(node.Value, "eE") {
+			builder.WriteString(strconv.FormatFloat(number, 'f', -1, 64))
+		} else {
+			builder.WriteString(node.Value)
+		}
+	case VariableNode:
+		builder.WriteString(node.Value)
+	case CallNode:
+		kind, ok := node.Operator()
+		switch {
+		case ok && kind == tokenizer.NegationToken:
+			builder.WriteString(tokenizer.MinusToken.String())
+			node.writeArgument(builder, 0)
+		case ok && kind.IsPostfix():
+			node.writeArgument(builder, 0)
+			builder.WriteString(kind.String())
+		case ok:
+			node.writeArgument(builder, 0)
+			if kind == tokenizer.PlusToken || kind == tokenizer.MinusToken {
+				builder.WriteString(" " + kind.String() + " ")
+			} else {
+				builder.WriteString(kind.String())
+			}
+			node.writeArgument(builder, 1)
+		default:
+			builder.WriteString(node.Value)
+			builder.WriteString(tokenizer.LeftParenthesisToken.String())
+			for argumentIndex := range node.Arguments {
+				if argumentIndex > 0 {
+					builder.WriteString(tokenizer.CommaToken.String() + " ")
+				}
+
+				node.writeArgument(builder, argumentIndex)
+			}
+			builder.WriteString(tokenizer.RightParenthesisToken.String())
+		}
+	case AssignmentNode:
+		builder.WriteString(node.Value + " " + tokenizer.EqualsToken.String() + " ")
+		node.writeArgument(builder, 0)
+	case LetNode:
+		builder.WriteString(tokenizer.LetToken.String() + " " + node.Value + " " + tokenizer.EqualsToken.String() + " ")
+		node.writeArgument(builder, 0)
+		builder.WriteString(" " + tokenizer.InToken.String() + " ")
+		node.writeArgument(builder, 1)
+	case SequenceNode:
+		for argumentIndex := range node.Arguments {
+			if argumentIndex > 0 {
+				builder.WriteString(tokenizer.SemicolonToken.String() + " ")
+			}
+
+			node.writeArgument(builder, argumentIndex)
+		}
+	}
+}
+
+func (node *Node) writeArgument(builder *strings.Builder, argumentIndex int) {
+	argument := node.Arguments[argumentIndex]
+	if !argument.NeedsParentheses(node, argumentIndex) {
+		argument.write(builder)
+		return
+	}
+
+	builder.WriteString(tokenizer.LeftParenthesisToken.String())
+	argument.write(builder)
+	builder.WriteString(tokenizer.RightParenthesisToken.String())
+}
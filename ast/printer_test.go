@@ -0,0 +1,113 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/rmaidveo/go-calculator/tokenizer"
+	"github.com/rmaidveo/go-calculator/translator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNode_String(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "number", text: "23.50", want: "23.50"},
+		{name: "variable", text: "x", want: "x"},
+		{name: "additive operators", text: "x+y-z", want: "x + y - z"},
+		{name: "multiplicative operators", text: "x * y / z % w", want: "x*y/z%w"},
+		{name: "precedence", text: "2 * x ^ 2 + 1", want: "2*x^2 + 1"},
+		{name: "left associativity", text: "x - (y - z) - (w + v)", want: "x - (y - z) - (w + v)"},
+		{name: "right associativity", text: "(x ^ y) ^ z ^ w", want: "(x^y)^z^w"},
+		{name: "redundant parentheses", text: "((x * y)) + (z)", want: "x*y + z"},
+		{name: "negation", text: "-x ^ 2 * -(y + 1) - (-z) ^ 2", want: "-x^2*-(y + 1) - (-z)^2"},
+		{name: "function call", text: "max(sin(x), 2 * y)", want: "max(sin(x), 2*y)"},
+		{name: "postfix operators", text: "(x + 1)! * x!! ^ 2 - (-x)! + sin(90 °) - (x ^ 2)!", want: "(x + 1)!*x!!^2 - (-x)! + sin(90°) - (x^2)!"},
+		{name: "assignment", text: "x = y = 2", want: "x = y = 2"},
+		{name: "let binding", text: "2 * (let x = 3 in x + 1)", want: "2*(let x = 3 in x + 1)"},
+		{name: "statements", text: "x = 2\n\nx * 3", want: "x = 2; x*3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := build(t, tt.text)
+
+			got := node.String()
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, withoutPositions(node.Commands()), withoutPositions(build(t, got).Commands()))
+		})
+	}
+}
+
+func TestNode_String_negativeNumbers(t *testing.T) {
+	tests := []struct {
+		name string
+		node *Node
+		want string
+	}{
+		{
+			name: "as an operand",
+			node: Call("*", 0, Variable("x", 0), Number(-2, 0)),
+			want: "x*-2",
+		},
+		{
+			name: "as a factorial operand",
+			node: Call("!", 0, Number(-2, 0)),
+			want: "(-2)!",
+		},
+		{
+			name: "as a base",
+			node: Call("^", 0, Number(-2, 0), Variable("x", 0)),
+			want: "(-2)^x",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.node.String()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func build(t *testing.T, text string) *Node {
+	functions := map[string]evaluator.Function{
+		"+":   {Arity: 2},
+		"-":   {Arity: 2},
+		"*":   {Arity: 2},
+		"/":   {Arity: 2},
+		"%":   {Arity: 2},
+		"^":   {Arity: 2},
+		"neg": {Arity: 1},
+		"!":   {Arity: 1},
+		"°":   {Arity: 1},
+		"sin": {Arity: 1},
+		"max": {Arity: 2},
+	}
+	functionNames := map[string]struct{}{}
+	for functionName := range functions {
+		functionNames[functionName] = struct{}{}
+	}
+
+	tokens, err := tokenizer.Tokenize(text)
+	assert.NoError(t, err)
+
+	commands, err := translator.Translate(tokens, functionNames)
+	assert.NoError(t, err)
+
+	node, err := Build(commands, functions)
+	assert.NoError(t, err)
+
+	return node
+}
+
+func withoutPositions(commands []translator.Command) []translator.Command {
+	for index := range commands {
+		commands[index].Position = 0
+	}
+
+	return commands
+}
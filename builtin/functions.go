@@ -0,0 +1,199 @@
+package builtin
+
+import (
+	"errors"
+	"math"
+
+	"github.com/rmaidveo/go-calculator/evaluator"
+)
+
+var (
+	ErrDivisionByZero = errors.New("division by zero")
+
+	errNegativeFactorial = errors.New("factorial of a negative integer")
+)
+
+func Functions() map[string]evaluator.Function {
+	return map[string]evaluator.Function{
+		"+": withIdentity(binary(
+			func(x float64, y float64) (float64, error) {
+				return x + y, nil
+			},
+			func(x float64, y float64) (float64, float64, error) {
+				return 1, 1, nil
+			},
+		), 0, true),
+		"-": withIdentity(binary(
+			func(x float64, y float64) (float64, error) {
+				return x - y, nil
+			},
+			func(x float64, y float64) (float64, float64, error) {
+				return 1, -1, nil
+			},
+		), 0, false),
+		"*": withIdentity(binary(
+			func(x float64, y float64) (float64, error) {
+				return x * y, nil
+			},
+			func(x float64, y float64) (float64, float64, error) {
+				return y, x, nil
+			},
+		), 1, true),
+		"/": withIdentity(binary(
+			func(x float64, y float64) (float64, error) {
+				if y == 0 {
+					return 0, ErrDivisionByZero
+				}
+
+				return x / y, nil
+			},
+			func(x float64, y float64) (float64, float64, error) {
+				if y == 0 {
+					return 0, 0, ErrDivisionByZero
+				}
+
+				return 1 / y, -x / (y * y), nil
+			},
+		), 1, false),
+		"%": binary(
+			func(x float64, y float64) (float64, error) {
+				if y == 0 {
+					return 0, ErrDivisionByZero
+				}
+
+				return math.Mod(x, y), nil
+			},
+			func(x float64, y float64) (float64, float64, error) {
+				if y == 0 {
+					return 0, 0, ErrDivisionByZero
+				}
+
+				return 1, -math.Trunc(x / y), nil
+			},
+		),
+		"^":   withIdentity(binary(wrapBinary(math.Pow), powerDerivative), 1, false),
+		"neg": selfInverse(unary(func(x float64) float64 { return -x }, func(x float64) float64 { return -1 })),
+		"!": {
+			Arity:   1,
+			Pure:    true,
+			Handler: factorial,
+		},
+		"°":       unary(func(x float64) float64 { return x * math.Pi / 180 }, func(x float64) float64 { return math.Pi / 180 }),
+		"percent": unary(func(x float64) float64 { return x / 100 }, func(x float64) float64 { return 0.01 }),
+
+		"abs":   unary(math.Abs, sign),
+		"sqrt":  unary(math.Sqrt, func(x float64) float64 { return 1 / (2 * math.Sqrt(x)) }),
+		"exp":   unary(math.Exp, math.Exp),
+		"log":   unary(math.Log, func(x float64) float64 { return 1 / x }),
+		"log10": unary(math.Log10, func(x float64) float64 { return 1 / (x * math.Ln10) }),
+		"sin":   unary(math.Sin, math.Cos),
+		"cos":   unary(math.Cos, func(x float64) float64 { return -math.Sin(x) }),
+		"tan": unary(math.Tan, func(x float64) float64 {
+			cos := math.Cos(x)
+			return 1 / (cos * cos)
+		}),
+		"asin":  unary(math.Asin, func(x float64) float64 { return 1 / math.Sqrt(1-x*x) }),
+		"acos":  unary(math.Acos, func(x float64) float64 { return -1 / math.Sqrt(1-x*x) }),
+		"atan":  unary(math.Atan, func(x float64) float64 { return 1 / (1 + x*x) }),
+		"floor": unary(math.Floor, zero),
+		"ceil":  unary(math.Ceil, zero),
+		"round": unary(math.Round, zero),
+		"atan2": binary(wrapBinary(math.Atan2), func(y float64, x float64) (float64, float64, error) {
+			squaredNorm := x*x + y*y
+			return x / squaredNorm, -y / squaredNorm, nil
+		}),
+		"pow": binary(wrapBinary(math.Pow), powerDerivative),
+		"min": binary(wrapBinary(math.Min), func(x float64, y float64) (float64, float64, error) {
+			if x <= y {
+				return 1, 0, nil
+			}
+
+			return 0, 1, nil
+		}),
+		"max": binary(wrapBinary(math.Max), func(x float64, y float64) (float64, float64, error) {
+			if x >= y {
+				return 1, 0, nil
+			}
+
+			return 0, 1, nil
+		}),
+	}
+}
+
+func withIdentity(function evaluator.Function, value float64, commutative bool) evaluator.Function {
+	function.Identity = &evaluator.Identity{Value: value, Commutative: commutative}
+	return function
+}
+
+func selfInverse(function evaluator.Function) evaluator.Function {
+	function.SelfInverse = true
+	return function
+}
+
+func unary(handler func(x float64) float64, derivative func(x float64) float64) evaluator.Function {
+	return evaluator.Function{
+		Arity: 1,
+		Pure:  true,
+		Handler: func(arguments []float64) (float64, error) {
+			return handler(arguments[0]), nil
+		},
+		Derivative: func(arguments []float64) ([]float64, error) {
+			return []float64{derivative(arguments[0])}, nil
+		},
+	}
+}
+
+func binary(
+	handler func(x float64, y float64) (float64, error),
+	derivative func(x float64, y float64) (float64, float64, error),
+) evaluator.Function {
+	return evaluator.Function{
+		Arity: 2,
+		Pure:  true,
+		Handler: func(arguments []float64) (float64, error) {
+			return handler(arguments[0], arguments[1])
+		},
+		Derivative: func(arguments []float64) ([]float64, error) {
+			derivativeX, derivativeY, err := derivative(arguments[0], arguments[1])
+			if err != nil {
+				return nil, err
+			}
+
+			return []float64{derivativeX, derivativeY}, nil
+		},
+	}
+}
+
+func wrapBinary(handler func(x float64, y float64) float64) func(x float64, y float64) (float64, error) {
+	return func(x float64, y float64) (float64, error) {
+		return handler(x, y), nil
+	}
+}
+
+func factorial(arguments []float64) (float64, error) {
+	x := arguments[0]
+	if x < 0 && x == math.Trunc(x) {
+		return 0, errNegativeFactorial
+	}
+
+	return math.Gamma(x + 1), nil
+}
+
+func powerDerivative(x float64, y float64) (float64, float64, error) {
+	return y * math.Pow(x, y-1), math.Pow(x, y) * math.Log(x), nil
+}
+
+func sign(x float64) float64 {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+func zero(x float64) float64 {
+	return 0
+}
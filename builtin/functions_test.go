@@ -0,0 +1,222 @@
+package builtin
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFunctions(t *testing.T) {
+	type args struct {
+		name      string
+		arguments []float64
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    float64
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success/+",
+			args:    args{name: "+", arguments: []float64{23, 42}},
+			want:    65,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/-",
+			args:    args{name: "-", arguments: []float64{23, 42}},
+			want:    -19,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/*",
+			args:    args{name: "*", arguments: []float64{2, 21}},
+			want:    42,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success//",
+			args:    args{name: "/", arguments: []float64{42, 4}},
+			want:    10.5,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/%",
+			args:    args{name: "%", arguments: []float64{42, 5}},
+			want:    2,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/^",
+			args:    args{name: "^", arguments: []float64{2, 10}},
+			want:    1024,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/neg",
+			args:    args{name: "neg", arguments: []float64{23}},
+			want:    -23,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/!",
+			args:    args{name: "!", arguments: []float64{5}},
+			want:    120,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/!/zero",
+			args:    args{name: "!", arguments: []float64{0}},
+			want:    1,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/!/gamma",
+			args:    args{name: "!", arguments: []float64{0.5}},
+			want:    math.Sqrt(math.Pi) / 2,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/°",
+			args:    args{name: "°", arguments: []float64{180}},
+			want:    math.Pi,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/percent",
+			args:    args{name: "percent", arguments: []float64{15}},
+			want:    0.15,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/sqrt",
+			args:    args{name: "sqrt", arguments: []float64{16}},
+			want:    4,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/cos",
+			args:    args{name: "cos", arguments: []float64{0}},
+			want:    1,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/atan2",
+			args:    args{name: "atan2", arguments: []float64{1, 1}},
+			want:    math.Pi / 4,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/max",
+			args:    args{name: "max", arguments: []float64{23, 42}},
+			want:    42,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error///division by zero",
+			args:    args{name: "/", arguments: []float64{23, 0}},
+			want:    0,
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/!/negative integer",
+			args:    args{name: "!", arguments: []float64{-2}},
+			want:    0,
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/%/division by zero",
+			args:    args{name: "%", arguments: []float64{23, 0}},
+			want:    0,
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			function, ok := Functions()[tt.args.name]
+			assert.True(t, ok)
+			assert.True(t, function.Pure)
+			assert.Len(t, tt.args.arguments, function.Arity)
+
+			got, err := function.Handler(tt.args.arguments)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestFunctions_derivatives(t *testing.T) {
+	const step = 1e-6
+
+	tests := []struct {
+		name      string
+		arguments []float64
+	}{
+		{name: "+", arguments: []float64{2, 3}},
+		{name: "-", arguments: []float64{2, 3}},
+		{name: "*", arguments: []float64{2, 3}},
+		{name: "/", arguments: []float64{2, 3}},
+		{name: "%", arguments: []float64{7.5, 2}},
+		{name: "^", arguments: []float64{2, 3}},
+		{name: "neg", arguments: []float64{2}},
+		{name: "abs", arguments: []float64{-2}},
+		{name: "sqrt", arguments: []float64{2}},
+		{name: "exp", arguments: []float64{2}},
+		{name: "log", arguments: []float64{2}},
+		{name: "log10", arguments: []float64{2}},
+		{name: "sin", arguments: []float64{2}},
+		{name: "cos", arguments: []float64{2}},
+		{name: "tan", arguments: []float64{0.5}},
+		{name: "asin", arguments: []float64{0.5}},
+		{name: "acos", arguments: []float64{0.5}},
+		{name: "atan", arguments: []float64{2}},
+		{name: "°", arguments: []float64{2}},
+		{name: "percent", arguments: []float64{2}},
+		{name: "floor", arguments: []float64{2.5}},
+		{name: "ceil", arguments: []float64{2.5}},
+		{name: "round", arguments: []float64{2.3}},
+		{name: "atan2", arguments: []float64{2, 3}},
+		{name: "pow", arguments: []float64{2, 3}},
+		{name: "min", arguments: []float64{2, 3}},
+		{name: "max", arguments: []float64{2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			function := Functions()[tt.name]
+
+			got, err := function.Derivative(tt.arguments)
+			assert.NoError(t, err)
+			assert.Len(t, got, function.Arity)
+
+			for argumentIndex := range tt.arguments {
+				forward := append([]float64(nil), tt.arguments...)
+				forward[argumentIndex] += step
+				backward := append([]float64(nil), tt.arguments...)
+				backward[argumentIndex] -= step
+
+				forwardValue, err := function.Handler(forward)
+				assert.NoError(t, err)
+				backwardValue, err := function.Handler(backward)
+				assert.NoError(t, err)
+
+				want := (forwardValue - backwardValue) / (2 * step)
+				assert.InDelta(t, want, got[argumentIndex], 1e-6)
+			}
+		})
+	}
+}
+
+func TestFunctions_derivativeErrors(t *testing.T) {
+	for _, name := range []string{"/", "%"} {
+		t.Run(name, func(t *testing.T) {
+			got, err := Functions()[name].Derivative([]float64{23, 0})
+
+			assert.Nil(t, got)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package builtin
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/rmaidveo/go-calculator/registry"
+)
+
+const (
+	OperatorCategory      = "operators"
+	ArithmeticCategory    = "arithmetic"
+	ExponentialCategory   = "exponential"
+	TrigonometricCategory = "trigonometric"
+	RoundingCategory      = "rounding"
+	ComparisonCategory    = "comparison"
+)
+
+func Registry() *registry.Registry {
+	functions := Functions()
+	functionRegistry := registry.New(registry.Options{})
+	for _, entry := range []registry.Entry{
+		{
+			Name:        "+",
+			Parameters:  []string{"x", "y"},
+			Description: "Adds y to x.",
+			Category:    OperatorCategory,
+			Examples:    []registry.Example{{Expression: "2 + 3", Result: 5}},
+		},
+		{
+			Name:        "-",
+			Parameters:  []string{"x", "y"},
+			Description: "Subtracts y from x.",
+			Category:    OperatorCategory,
+			Examples:    []registry.Example{{Expression: "2 - 3", Result: -1}},
+		},
+		{
+			Name:        "*",
+			Parameters:  []string{"x", "y"},
+			Description: "Multiplies x by y.",
+			Category:    OperatorCategory,
+			Examples:    []registry.Example{{Expression: "2 * 3", Result: 6}},
+		},
+		{
+			Name:        "/",
+			Parameters:  []string{"x", "y"},
+			Description: "Divides x by y; fails if y is zero.",
+			Category:    OperatorCategory,
+			Examples:    []registry.Example{{Expression: "3 / 2", Result: 1.5}},
+		},
+		{
+			Name:        "%",
+			Parameters:  []string{"x", "y"},
+			Description: "Returns the remainder of x divided by y; fails if y is zero.",
+			Category:    OperatorCategory,
+			Examples:    []registry.Example{{Expression: "7 % 3", Result: 1}},
+		},
+		{
+			Name:        "^",
+			Parameters:  []string{"x", "y"},
+			Description: "Raises x to the power y.",
+			Category:    OperatorCategory,
+			Examples:    []registry.Example{{Expression: "2 ^ 3 ^ 2", Result: 512}},
+		},
+		{
+			Name:        "neg",
+			Parameters:  []string{"x"},
+			Description: "Negates x; written as a unary minus.",
+			Category:    OperatorCategory,
+			Examples:    []registry.Example{{Expression: "-2 ^ 2", Result: -4}},
+		},
+		{
+			Name:        "!",
+			Parameters:  []string{"x"},
+			Description: "Returns the factorial of x; written after x, uses the gamma function for non-integers.",
+			Category:    OperatorCategory,
+			Examples:    []registry.Example{{Expression: "2 ^ 3!", Result: 64}},
+		},
+		{
+			Name:        "°",
+			Parameters:  []string{"x"},
+			Description: "Converts x degrees to radians; written after x.",
+			Category:    OperatorCategory,
+			Examples:    []registry.Example{{Expression: "180°", Result: math.Pi}},
+		},
+		{
+			Name:        "percent",
+			Parameters:  []string{"x"},
+			Description: "Divides x by 100; written as a postfix % when the percent mode is enabled.",
+			Category:    ArithmeticCategory,
+			Examples:    []registry.Example{{Expression: "percent(15)", Result: 0.15}},
+		},
+		{
+			Name:        "abs",
+			Parameters:  []string{"x"},
+			Description: "Returns the absolute value of x.",
+			Category:    ArithmeticCategory,
+			Examples:    []registry.Example{{Expression: "abs(-2.5)", Result: 2.5}},
+		},
+		{
+			Name:        "sqrt",
+			Parameters:  []string{"x"},
+			Description: "Returns the square root of x.",
+			Category:    ArithmeticCategory,
+			Examples:    []registry.Example{{Expression: "sqrt(16)", Result: 4}},
+		},
+		{
+			Name:        "pow",
+			Parameters:  []string{"x", "y"},
+			Description: "Raises x to the power y.",
+			Category:    ArithmeticCategory,
+			Examples:    []registry.Example{{Expression: "pow(2, 10)", Result: 1024}},
+		},
+		{
+			Name:        "exp",
+			Parameters:  []string{"x"},
+			Description: "Returns e raised to the power x.",
+			Category:    ExponentialCategory,
+			Examples:    []registry.Example{{Expression: "exp(0)", Result: 1}},
+		},
+		{
+			Name:        "log",
+			Parameters:  []string{"x"},
+			Description: "Returns the natural logarithm of x.",
+			Category:    ExponentialCategory,
+			Examples:    []registry.Example{{Expression: "log(1)", Result: 0}},
+		},
+		{
+			Name:        "log10",
+			Parameters:  []string{"x"},
+			Description: "Returns the decimal logarithm of x.",
+			Category:    ExponentialCategory,
+			Examples:    []registry.Example{{Expression: "log10(1000)", Result: 3}},
+		},
+		{
+			Name:        "sin",
+			Parameters:  []string{"x"},
+			Description: "Returns the sine of x radians.",
+			Category:    TrigonometricCategory,
+			Examples:    []registry.Example{{Expression: "sin(0)", Result: 0}},
+		},
+		{
+			Name:        "cos",
+			Parameters:  []string{"x"},
+			Description: "Returns the cosine of x radians.",
+			Category:    TrigonometricCategory,
+			Examples:    []registry.Example{{Expression: "cos(0)", Result: 1}},
+		},
+		{
+			Name:        "tan",
+			Parameters:  []string{"x"},
+			Description: "Returns the tangent of x radians.",
+			Category:    TrigonometricCategory,
+			Examples:    []registry.Example{{Expression: "tan(0)", Result: 0}},
+		},
+		{
+			Name:        "asin",
+			Parameters:  []string{"x"},
+			Description: "Returns the arcsine of x in radians.",
+			Category:    TrigonometricCategory,
+			Examples:    []registry.Example{{Expression: "asin(0)", Result: 0}},
+		},
+		{
+			Name:        "acos",
+			Parameters:  []string{"x"},
+			Description: "Returns the arccosine of x in radians.",
+			Category:    TrigonometricCategory,
+			Examples:    []registry.Example{{Expression: "acos(1)", Result: 0}},
+		},
+		{
+			Name:        "atan",
+			Parameters:  []string{"x"},
+			Description: "Returns the arctangent of x in radians.",
+			Category:    TrigonometricCategory,
+			Examples:    []registry.Example{{Expression: "atan(0)", Result: 0}},
+		},
+		{
+			Name:        "atan2",
+			Parameters:  []string{"y", "x"},
+			Description: "Returns the arctangent of y/x using the signs of both to pick the quadrant.",
+			Category:    TrigonometricCategory,
+			Examples:    []registry.Example{{Expression: "atan2(0, 1)", Result: 0}},
+		},
+		{
+			Name:        "floor",
+			Parameters:  []string{"x"},
+			Description: "Rounds x down to an integer.",
+			Category:    RoundingCategory,
+			Examples:    []registry.Example{{Expression: "floor(-2.5)", Result: -3}},
+		},
+		{
+			Name:        "ceil",
+			Parameters:  []string{"x"},
+			Description: "Rounds x up to an integer.",
+			Category:    RoundingCategory,
+			Examples:    []registry.Example{{Expression: "ceil(-2.5)", Result: -2}},
+		},
+		{
+			Name:        "round",
+			Parameters:  []string{"x"},
+			Description: "Rounds x to the nearest integer, half away from zero.",
+			Category:    RoundingCategory,
+			Examples:    []registry.Example{{Expression: "round(2.5)", Result: 3}},
+		},
+		{
+			Name:        "min",
+			Parameters:  []string{"x", "y"},
+			Description: "Returns the smaller of x and y.",
+			Category:    ComparisonCategory,
+			Examples:    []registry.Example{{Expression: "min(2, 3)", Result: 2}},
+		},
+		{
+			Name:        "max",
+			Parameters:  []string{"x", "y"},
+			Description: "Returns the larger of x and y.",
+			Category:    ComparisonCategory,
+			Examples:    []registry.Example{{Expression: "max(2, 3)", Result: 3}},
+		},
+	} {
+		entry.Function = functions[entry.Name]
+		if err := functionRegistry.Register(entry); err != nil {
+			panic(fmt.Sprintf("unable to register the built-in function %q: %s", entry.Name, err))
+		}
+	}
+
+	return functionRegistry
+}
@@ -0,0 +1,58 @@
+package builtin
+
+import (
+	"testing"
+
+	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/rmaidveo/go-calculator/tokenizer"
+	"github.com/rmaidveo/go-calculator/translator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	functions := Functions()
+	functionRegistry := Registry()
+
+	assert.Len(t, functionRegistry.Entries(), len(functions))
+	for name, function := range functions {
+		entry, ok := functionRegistry.Lookup(name)
+		if !assert.True(t, ok, name) {
+			continue
+		}
+
+		assert.Len(t, entry.Parameters, function.Arity, name)
+		assert.NotEmpty(t, entry.Description, name)
+		assert.NotEmpty(t, entry.Category, name)
+		assert.NotEmpty(t, entry.Examples, name)
+	}
+}
+
+func TestRegistry_examples(t *testing.T) {
+	functions := Functions()
+	functionNames := make(map[string]struct{}, len(functions))
+	for functionName := range functions {
+		functionNames[functionName] = struct{}{}
+	}
+
+	for _, entry := range Registry().Entries() {
+		for _, example := range entry.Examples {
+			t.Run(example.Expression, func(t *testing.T) {
+				tokens, err := tokenizer.Tokenize(example.Expression)
+				assert.NoError(t, err)
+
+				commands, err := translator.Translate(tokens, functionNames)
+				assert.NoError(t, err)
+
+				got, err := evaluator.Evaluate(commands, map[string]float64{}, functions)
+				assert.NoError(t, err)
+				assert.Equal(t, example.Result, got)
+
+				isUsed := false
+				for _, command := range commands {
+					isUsed = isUsed || (command.Kind == translator.CallFunctionCommand && command.Operand == entry.Name)
+				}
+				assert.True(t, isUsed, "example %q does not use %q", example.Expression, entry.Name)
+			})
+		}
+	}
+}
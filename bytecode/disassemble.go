@@ -0,0 +1,51 @@
+package bytecode
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+func Disassemble(program Program) string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "version %d\n", Version)
+
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "constants: %d\n", len(program.Constants))
+	for constantIndex, constant := range program.Constants {
+		fmt.Fprintf(writer, "  #%d\t%s\n", constantIndex, strconv.FormatFloat(constant, 'g', -1, 64))
+	}
+
+	fmt.Fprintf(writer, "symbols: %d\n", len(program.Symbols))
+	for symbolIndex, symbol := range program.Symbols {
+		fmt.Fprintf(writer, "  #%d\t%s\t%s\n", symbolIndex, symbol.Kind, symbol.Name)
+	}
+
+	fmt.Fprintf(writer, "instructions: %d\n", len(program.Instructions))
+	for instructionIndex, instruction := range program.Instructions {
+		var operand, comment string
+		switch {
+		case instruction.Opcode == PopOpcode:
+		case instruction.Opcode == PushNumberOpcode && instruction.Operand < len(program.Constants):
+			operand = "#" + strconv.Itoa(instruction.Operand)
+			comment = "; " + strconv.FormatFloat(program.Constants[instruction.Operand], 'g', -1, 64)
+		case instruction.Opcode != PushNumberOpcode && instruction.Operand < len(program.Symbols):
+			operand = "#" + strconv.Itoa(instruction.Operand)
+			comment = "; " + program.Symbols[instruction.Operand].Name
+		default:
+			operand = "#" + strconv.Itoa(instruction.Operand)
+			comment = "; <invalid>"
+		}
+
+		line := fmt.Sprintf("  %04d\t%s\t%s\t@%d", instructionIndex, instruction.Opcode, operand, instruction.Position)
+		if comment != "" {
+			line += "\t" + comment
+		}
+
+		fmt.Fprintln(writer, line)
+	}
+	writer.Flush()
+
+	return builder.String()
+}
@@ -0,0 +1,59 @@
+package bytecode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisassemble(t *testing.T) {
+	tests := []struct {
+		name    string
+		program Program
+		want    string
+	}{
+		{
+			name:    "empty",
+			program: Program{},
+			want:    "version 1\nconstants: 0\nsymbols: 0\ninstructions: 0\n",
+		},
+		{
+			name: "program",
+			program: Program{
+				Constants:    []float64{12.5},
+				Symbols:      []Symbol{{Kind: VariableSymbol, Name: "total"}, {Kind: FunctionSymbol, Name: "*"}},
+				Instructions: []Instruction{{Opcode: PushNumberOpcode, Operand: 0, Position: 8}, {Opcode: PushVariableOpcode, Operand: 0, Position: 15}, {Opcode: CallFunctionOpcode, Operand: 1, Position: 13}, {Opcode: AssignVariableOpcode, Operand: 0, Position: 0}, {Opcode: PopOpcode, Position: 20}},
+			},
+			want: "version 1\n" +
+				"constants: 1\n" +
+				"  #0  12.5\n" +
+				"symbols: 2\n" +
+				"  #0  variable  total\n" +
+				"  #1  function  *\n" +
+				"instructions: 5\n" +
+				"  0000  push    #0  @8   ; 12.5\n" +
+				"  0001  load    #0  @15  ; total\n" +
+				"  0002  call    #1  @13  ; *\n" +
+				"  0003  assign  #0  @0   ; total\n" +
+				"  0004  pop         @20\n",
+		},
+		{
+			name: "invalid operand",
+			program: Program{
+				Instructions: []Instruction{{Opcode: PushVariableOpcode, Operand: 3, Position: 0}},
+			},
+			want: "version 1\n" +
+				"constants: 0\n" +
+				"symbols: 0\n" +
+				"instructions: 1\n" +
+				"  0000  load  #3  @0  ; <invalid>\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Disassemble(tt.program)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
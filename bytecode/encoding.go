@@ -0,0 +1,161 @@
+package bytecode
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	magic        = "CALC"
+	constantSize = 8
+)
+
+var (
+	errUnexpectedEnd = errors.New("unexpected end of data")
+)
+
+func Encode(program Program) []byte {
+	data := []byte(magic)
+	data = append(data, Version)
+
+	data = binary.AppendUvarint(data, uint64(len(program.Constants)))
+	for _, constant := range program.Constants {
+		data = binary.LittleEndian.AppendUint64(data, math.Float64bits(constant))
+	}
+
+	data = binary.AppendUvarint(data, uint64(len(program.Symbols)))
+	for _, symbol := range program.Symbols {
+		data = append(data, byte(symbol.Kind))
+		data = binary.AppendUvarint(data, uint64(len(symbol.Name)))
+		data = append(data, symbol.Name...)
+	}
+
+	data = binary.AppendUvarint(data, uint64(len(program.Instructions)))
+	for _, instruction := range program.Instructions {
+		data = append(data, byte(instruction.Opcode))
+		data = binary.AppendUvarint(data, uint64(instruction.Operand))
+		data = binary.AppendUvarint(data, uint64(instruction.Position))
+	}
+
+	return data
+}
+
+func Decode(data []byte) (Program, error) {
+	decoder := decoder{data: data}
+	if string(decoder.bytes(len(magic))) != magic {
+		return Program{}, fmt.Errorf("%w: missing magic number", errInvalidProgram)
+	}
+
+	version := decoder.byte()
+	if decoder.err == nil && version != Version {
+		return Program{}, fmt.Errorf("%w: unsupported version %d", errInvalidProgram, version)
+	}
+
+	var program Program
+	constantCount := decoder.count(constantSize)
+	for constantIndex := 0; constantIndex < constantCount; constantIndex++ {
+		bits := decoder.bytes(constantSize)
+		if decoder.err != nil {
+			break
+		}
+
+		program.Constants = append(program.Constants, math.Float64frombits(binary.LittleEndian.Uint64(bits)))
+	}
+
+	symbolCount := decoder.count(2)
+	for symbolIndex := 0; symbolIndex < symbolCount; symbolIndex++ {
+		kind := SymbolKind(decoder.byte())
+		name := string(decoder.bytes(decoder.count(1)))
+		if decoder.err != nil {
+			break
+		}
+
+		program.Symbols = append(program.Symbols, Symbol{Kind: kind, Name: name})
+	}
+
+	instructionCount := decoder.count(3)
+	for instructionIndex := 0; instructionIndex < instructionCount; instructionIndex++ {
+		opcode := Opcode(decoder.byte())
+		operand := decoder.integer()
+		position := decoder.integer()
+		if decoder.err != nil {
+			break
+		}
+
+		program.Instructions = append(program.Instructions, Instruction{
+			Opcode:   opcode,
+			Operand:  operand,
+			Position: position,
+		})
+	}
+
+	if decoder.err != nil {
+		return Program{}, fmt.Errorf("%w: %w", errInvalidProgram, decoder.err)
+	}
+	if len(decoder.data) != decoder.offset {
+		return Program{}, fmt.Errorf("%w: unexpected data at offset %d", errInvalidProgram, decoder.offset)
+	}
+	if err := program.Validate(); err != nil {
+		return Program{}, err
+	}
+
+	return program, nil
+}
+
+type decoder struct {
+	data   []byte
+	offset int
+	err    error
+}
+
+func (decoder *decoder) bytes(length int) []byte {
+	if decoder.err != nil {
+		return nil
+	}
+	if length > len(decoder.data)-decoder.offset {
+		decoder.err = fmt.Errorf("%w at offset %d", errUnexpectedEnd, decoder.offset)
+		return nil
+	}
+
+	bytes := decoder.data[decoder.offset : decoder.offset+length]
+	decoder.offset += length
+
+	return bytes
+}
+
+func (decoder *decoder) byte() byte {
+	bytes := decoder.bytes(1)
+	if bytes == nil {
+		return 0
+	}
+
+	return bytes[0]
+}
+
+func (decoder *decoder) integer() int {
+	if decoder.err != nil {
+		return 0
+	}
+
+	value, length := binary.Uvarint(decoder.data[decoder.offset:])
+	if length <= 0 || value > math.MaxInt32 {
+		decoder.err = fmt.Errorf("invalid integer at offset %d", decoder.offset)
+		return 0
+	}
+
+	decoder.offset += length
+	return int(value)
+}
+
+func (decoder *decoder) count(minimalElementSize int) int {
+	offset := decoder.offset
+	count := decoder.integer()
+	if decoder.err == nil && count > (len(decoder.data)-decoder.offset)/minimalElementSize {
+		decoder.err = fmt.Errorf("too large count %d at offset %d", count, offset)
+		return 0
+	}
+
+	return count
+}
@@ -0,0 +1,203 @@
+package bytecode
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/rmaidveo/go-calculator/tokenizer"
+	"github.com/rmaidveo/go-calculator/translator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	program := Program{
+		Constants:    []float64{2},
+		Symbols:      []Symbol{{Kind: VariableSymbol, Name: "x"}, {Kind: FunctionSymbol, Name: "*"}},
+		Instructions: []Instruction{{Opcode: PushVariableOpcode, Operand: 0, Position: 0}, {Opcode: PushNumberOpcode, Operand: 0, Position: 200}, {Opcode: CallFunctionOpcode, Operand: 1, Position: 2}},
+	}
+
+	got := Encode(program)
+
+	assert.Equal(t, []byte{
+		'C', 'A', 'L', 'C', Version,
+		1, 0, 0, 0, 0, 0, 0, 0, 0x40,
+		2, byte(VariableSymbol), 1, 'x', byte(FunctionSymbol), 1, '*',
+		3, byte(PushVariableOpcode), 0, 0, byte(PushNumberOpcode), 0, 0xc8, 0x01, byte(CallFunctionOpcode), 1, 2,
+	}, got)
+}
+
+func TestDecode(t *testing.T) {
+	valid := Encode(Program{
+		Constants:    []float64{2},
+		Symbols:      []Symbol{{Kind: VariableSymbol, Name: "x"}},
+		Instructions: []Instruction{{Opcode: PushVariableOpcode, Operand: 0, Position: 3}},
+	})
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    Program
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			data: valid,
+			want: Program{
+				Constants:    []float64{2},
+				Symbols:      []Symbol{{Kind: VariableSymbol, Name: "x"}},
+				Instructions: []Instruction{{Opcode: PushVariableOpcode, Operand: 0, Position: 3}},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/empty program",
+			data:    []byte{'C', 'A', 'L', 'C', Version, 0, 0, 0},
+			want:    Program{},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error/empty data",
+			data:    nil,
+			want:    Program{},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/wrong magic number",
+			data:    append([]byte("CALX"), valid[4:]...),
+			want:    Program{},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/unsupported version",
+			data:    []byte{'C', 'A', 'L', 'C', Version + 1, 0, 0, 0},
+			want:    Program{},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/truncated",
+			data:    valid[:len(valid)-1],
+			want:    Program{},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/trailing data",
+			data:    append(append([]byte(nil), valid...), 0),
+			want:    Program{},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/too large count",
+			data:    []byte{'C', 'A', 'L', 'C', Version, 0xff, 0xff, 0xff, 0x01},
+			want:    Program{},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/invalid integer",
+			data:    []byte{'C', 'A', 'L', 'C', Version, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01},
+			want:    Program{},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/invalid program",
+			data:    []byte{'C', 'A', 'L', 'C', Version, 0, 0, 1, byte(PushNumberOpcode), 0, 0},
+			want:    Program{},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decode(tt.data)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func FuzzEncode(f *testing.F) {
+	for _, text := range []string{
+		"2 + 3",
+		"x = 2.5 * y; let z = x in z - -1",
+		"max(sin(x), 2) ^ 3 % 4",
+		"a\nb\nc",
+	} {
+		f.Add(text)
+	}
+
+	functionNames := map[string]struct{}{}
+	for functionName := range builtin.Functions() {
+		functionNames[functionName] = struct{}{}
+	}
+
+	f.Fuzz(func(t *testing.T, text string) {
+		tokens, err := tokenizer.Tokenize(text)
+		if err != nil {
+			return
+		}
+
+		commands, err := translator.Translate(tokens, functionNames)
+		if err != nil {
+			return
+		}
+
+		program, err := FromCommands(commands)
+		if err != nil {
+			return
+		}
+		if err := program.Validate(); err != nil {
+			t.Fatalf("compiled program is invalid: %v", err)
+		}
+
+		decodedProgram, err := Decode(Encode(program))
+		if err != nil {
+			t.Fatalf("unable to decode the encoded program: %v", err)
+		}
+		assert.Equal(t, Encode(program), Encode(decodedProgram))
+
+		data, err := EncodeJSON(program)
+		if err != nil {
+			t.Fatalf("unable to encode the program to JSON: %v", err)
+		}
+
+		decodedProgram, err = DecodeJSON(data)
+		if err != nil {
+			t.Fatalf("unable to decode the program from JSON: %v", err)
+		}
+
+		decodedData, err := EncodeJSON(decodedProgram)
+		if err != nil {
+			t.Fatalf("unable to encode the decoded program to JSON: %v", err)
+		}
+		assert.Equal(t, string(data), string(decodedData))
+	})
+}
+
+func FuzzDecode(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{'C', 'A', 'L', 'C', Version, 0, 0, 0})
+	f.Add(Encode(Program{
+		Constants:    []float64{2, math.Pi},
+		Symbols:      []Symbol{{Kind: VariableSymbol, Name: "x"}, {Kind: FunctionSymbol, Name: "+"}},
+		Instructions: []Instruction{{Opcode: PushVariableOpcode}, {Opcode: PushNumberOpcode, Operand: 1, Position: 4}, {Opcode: CallFunctionOpcode, Operand: 1, Position: 2}},
+	}))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		program, err := Decode(data)
+		if err != nil {
+			return
+		}
+
+		if err := program.Validate(); err != nil {
+			t.Fatalf("decoded program is invalid: %v", err)
+		}
+		_ = Disassemble(program)
+		_ = program.Commands()
+
+		decodedProgram, err := Decode(Encode(program))
+		if err != nil {
+			t.Fatalf("unable to decode the encoded program: %v", err)
+		}
+		assert.Equal(t, Encode(program), Encode(decodedProgram))
+	})
+}
@@ -0,0 +1,146 @@
+package bytecode
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+type jsonProgram struct {
+	Version      int               `json:"version"`
+	Constants    []jsonConstant    `json:"constants"`
+	Symbols      []jsonSymbol      `json:"symbols"`
+	Instructions []jsonInstruction `json:"instructions"`
+}
+
+type jsonConstant float64
+
+func (constant jsonConstant) MarshalJSON() ([]byte, error) {
+	number := float64(constant)
+	switch {
+	case math.IsNaN(number):
+		return []byte(`"NaN"`), nil
+	case math.IsInf(number, 1):
+		return []byte(`"+Inf"`), nil
+	case math.IsInf(number, -1):
+		return []byte(`"-Inf"`), nil
+	default:
+		return json.Marshal(number)
+	}
+}
+
+func (constant *jsonConstant) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		var number float64
+		if err := json.Unmarshal(data, &number); err != nil {
+			return err
+		}
+
+		*constant = jsonConstant(number)
+		return nil
+	}
+
+	switch text {
+	case "NaN":
+		*constant = jsonConstant(math.NaN())
+	case "+Inf":
+		*constant = jsonConstant(math.Inf(1))
+	case "-Inf":
+		*constant = jsonConstant(math.Inf(-1))
+	default:
+		return fmt.Errorf("unknown constant %q", text)
+	}
+
+	return nil
+}
+
+type jsonSymbol struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+type jsonInstruction struct {
+	Opcode   string `json:"opcode"`
+	Operand  int    `json:"operand"`
+	Position int    `json:"position"`
+}
+
+func EncodeJSON(program Program) ([]byte, error) {
+	encodedProgram := jsonProgram{
+		Version:      Version,
+		Constants:    make([]jsonConstant, 0, len(program.Constants)),
+		Symbols:      make([]jsonSymbol, 0, len(program.Symbols)),
+		Instructions: make([]jsonInstruction, 0, len(program.Instructions)),
+	}
+	for _, constant := range program.Constants {
+		encodedProgram.Constants = append(encodedProgram.Constants, jsonConstant(constant))
+	}
+	for _, symbol := range program.Symbols {
+		encodedProgram.Symbols = append(encodedProgram.Symbols, jsonSymbol{
+			Kind: symbol.Kind.String(),
+			Name: symbol.Name,
+		})
+	}
+	for _, instruction := range program.Instructions {
+		encodedProgram.Instructions = append(encodedProgram.Instructions, jsonInstruction{
+			Opcode:   instruction.Opcode.String(),
+			Operand:  instruction.Operand,
+			Position: instruction.Position,
+		})
+	}
+
+	data, err := json.Marshal(encodedProgram)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal the program: %w", err)
+	}
+
+	return data, nil
+}
+
+func DecodeJSON(data []byte) (Program, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var encodedProgram jsonProgram
+	if err := decoder.Decode(&encodedProgram); err != nil {
+		return Program{}, fmt.Errorf("%w: %w", errInvalidProgram, err)
+	}
+	if decoder.More() {
+		return Program{}, fmt.Errorf("%w: unexpected data after the program", errInvalidProgram)
+	}
+	if encodedProgram.Version != Version {
+		return Program{}, fmt.Errorf("%w: unsupported version %d", errInvalidProgram, encodedProgram.Version)
+	}
+
+	var program Program
+	for _, constant := range encodedProgram.Constants {
+		program.Constants = append(program.Constants, float64(constant))
+	}
+	for symbolIndex, encodedSymbol := range encodedProgram.Symbols {
+		kind, ok := parseSymbolKind(encodedSymbol.Kind)
+		if !ok {
+			return Program{}, fmt.Errorf("%w: unknown kind %q of symbol #%d", errInvalidProgram, encodedSymbol.Kind, symbolIndex)
+		}
+
+		program.Symbols = append(program.Symbols, Symbol{Kind: kind, Name: encodedSymbol.Name})
+	}
+	for instructionIndex, encodedInstruction := range encodedProgram.Instructions {
+		opcode, ok := parseOpcode(encodedInstruction.Opcode)
+		if !ok {
+			return Program{}, fmt.Errorf("%w: unknown opcode %q of instruction #%d", errInvalidProgram, encodedInstruction.Opcode, instructionIndex)
+		}
+
+		program.Instructions = append(program.Instructions, Instruction{
+			Opcode:   opcode,
+			Operand:  encodedInstruction.Operand,
+			Position: encodedInstruction.Position,
+		})
+	}
+	if err := program.Validate(); err != nil {
+		return Program{}, err
+	}
+
+	return program, nil
+}
@@ -0,0 +1,177 @@
+package bytecode
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		program Program
+		want    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success/empty",
+			program: Program{},
+			want:    `{"version":1,"constants":[],"symbols":[],"instructions":[]}`,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success",
+			program: Program{
+				Constants:    []float64{2.5},
+				Symbols:      []Symbol{{Kind: VariableSymbol, Name: "x"}, {Kind: FunctionSymbol, Name: "*"}},
+				Instructions: []Instruction{{Opcode: PushVariableOpcode, Operand: 0, Position: 0}, {Opcode: PushNumberOpcode, Operand: 0, Position: 4}, {Opcode: CallFunctionOpcode, Operand: 1, Position: 2}, {Opcode: PopOpcode, Position: 7}},
+			},
+			want: `{"version":1,"constants":[2.5],` +
+				`"symbols":[{"kind":"variable","name":"x"},{"kind":"function","name":"*"}],` +
+				`"instructions":[{"opcode":"load","operand":0,"position":0},{"opcode":"push","operand":0,"position":4},` +
+				`{"opcode":"call","operand":1,"position":2},{"opcode":"pop","operand":0,"position":7}]}`,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/not finite constants",
+			program: Program{Constants: []float64{math.Inf(1), math.Inf(-1), math.NaN()}},
+			want:    `{"version":1,"constants":["+Inf","-Inf","NaN"],"symbols":[],"instructions":[]}`,
+			wantErr: assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EncodeJSON(tt.program)
+
+			assert.Equal(t, tt.want, string(got))
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestDecodeJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    Program
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			data: `{"version":1,"constants":[2.5],"symbols":[{"kind":"function","name":"neg"}],` +
+				`"instructions":[{"opcode":"push","operand":0,"position":1},{"opcode":"call","operand":0,"position":0}]}`,
+			want: Program{
+				Constants:    []float64{2.5},
+				Symbols:      []Symbol{{Kind: FunctionSymbol, Name: "neg"}},
+				Instructions: []Instruction{{Opcode: PushNumberOpcode, Operand: 0, Position: 1}, {Opcode: CallFunctionOpcode, Operand: 0, Position: 0}},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/infinite constants",
+			data:    `{"version":1,"constants":["+Inf","-Inf"],"symbols":[],"instructions":[]}`,
+			want:    Program{Constants: []float64{math.Inf(1), math.Inf(-1)}},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/empty",
+			data:    `{"version":1,"constants":[],"symbols":[],"instructions":[]}`,
+			want:    Program{},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error/invalid JSON",
+			data:    `{"version":1`,
+			want:    Program{},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/unknown field",
+			data:    `{"version":1,"extra":true}`,
+			want:    Program{},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/unknown constant",
+			data:    `{"version":1,"constants":["Infinity"]}`,
+			want:    Program{},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/trailing data",
+			data:    `{"version":1} {}`,
+			want:    Program{},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/unsupported version",
+			data:    `{"version":2}`,
+			want:    Program{},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/unknown symbol kind",
+			data:    `{"version":1,"symbols":[{"kind":"constant","name":"x"}]}`,
+			want:    Program{},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/unknown opcode",
+			data:    `{"version":1,"instructions":[{"opcode":"jump","operand":0,"position":0}]}`,
+			want:    Program{},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/invalid program",
+			data:    `{"version":1,"instructions":[{"opcode":"push","operand":0,"position":0}]}`,
+			want:    Program{},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeJSON([]byte(tt.data))
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func FuzzDecodeJSON(f *testing.F) {
+	f.Add(`{"version":1,"constants":[],"symbols":[],"instructions":[]}`)
+	f.Add(`{"version":1,"constants":[2.5],"symbols":[{"kind":"function","name":"neg"}],` +
+		`"instructions":[{"opcode":"push","operand":0,"position":1},{"opcode":"call","operand":0,"position":0}]}`)
+
+	f.Fuzz(func(t *testing.T, data string) {
+		program, err := DecodeJSON([]byte(data))
+		if err != nil {
+			return
+		}
+
+		encodedData, err := EncodeJSON(program)
+		if err != nil {
+			t.Fatalf("unable to encode the decoded program: %v", err)
+		}
+
+		decodedProgram, err := DecodeJSON(encodedData)
+		if err != nil {
+			t.Fatalf("unable to decode the encoded program: %v", err)
+		}
+
+		decodedData, err := EncodeJSON(decodedProgram)
+		if err != nil {
+			t.Fatalf("unable to encode the decoded program: %v", err)
+		}
+		assert.Equal(t, string(encodedData), string(decodedData))
+	})
+}
+
+func TestDecodeJSON_notANumber(t *testing.T) {
+	got, err := DecodeJSON([]byte(`{"version":1,"constants":["NaN"],"symbols":[],"instructions":[]}`))
+
+	if assert.NoError(t, err) && assert.Len(t, got.Constants, 1) {
+		assert.True(t, math.IsNaN(got.Constants[0]))
+	}
+}
@@ -0,0 +1,248 @@
+package bytecode
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/rmaidveo/go-calculator/translator"
+)
+
+const (
+	Version = 1
+)
+
+var (
+	errInvalidProgram = errors.New("invalid program")
+)
+
+type Opcode uint8
+
+const (
+	PushNumberOpcode     Opcode = 1
+	PushVariableOpcode   Opcode = 2
+	CallFunctionOpcode   Opcode = 3
+	AssignVariableOpcode Opcode = 4
+	BindVariableOpcode   Opcode = 5
+	UnbindVariableOpcode Opcode = 6
+	PopOpcode            Opcode = 7
+)
+
+var opcodes = []Opcode{
+	PushNumberOpcode,
+	PushVariableOpcode,
+	CallFunctionOpcode,
+	AssignVariableOpcode,
+	BindVariableOpcode,
+	UnbindVariableOpcode,
+	PopOpcode,
+}
+
+type SymbolKind uint8
+
+const (
+	VariableSymbol SymbolKind = 1
+	FunctionSymbol SymbolKind = 2
+)
+
+type Symbol struct {
+	Kind SymbolKind
+	Name string
+}
+
+type Instruction struct {
+	Opcode   Opcode
+	Operand  int
+	Position int
+}
+
+type Program struct {
+	Constants    []float64
+	Symbols      []Symbol
+	Instructions []Instruction
+}
+
+func FromCommands(commands []translator.Command) (Program, error) {
+	var program Program
+	constantIndexes := map[uint64]int{}
+	symbolIndexes := map[Symbol]int{}
+	for _, command := range commands {
+		opcode, ok := opcodeForCommandKind(command.Kind)
+		if !ok {
+			return Program{}, fmt.Errorf("unknown command kind %d at position %d", command.Kind, command.Position)
+		}
+
+		instruction := Instruction{Opcode: opcode, Position: command.Position}
+		switch opcode {
+		case PushNumberOpcode:
+			number, err := strconv.ParseFloat(command.Operand, 64)
+			if err != nil {
+				return Program{}, fmt.Errorf("unable to parse the number at position %d: %w", command.Position, err)
+			}
+
+			bits := math.Float64bits(number)
+			constantIndex, ok := constantIndexes[bits]
+			if !ok {
+				constantIndex = len(program.Constants)
+				constantIndexes[bits] = constantIndex
+				program.Constants = append(program.Constants, number)
+			}
+
+			instruction.Operand = constantIndex
+		case PopOpcode:
+		default:
+			symbol := Symbol{Kind: opcode.symbolKind(), Name: command.Operand}
+			symbolIndex, ok := symbolIndexes[symbol]
+			if !ok {
+				symbolIndex = len(program.Symbols)
+				symbolIndexes[symbol] = symbolIndex
+				program.Symbols = append(program.Symbols, symbol)
+			}
+
+			instruction.Operand = symbolIndex
+		}
+
+		program.Instructions = append(program.Instructions, instruction)
+	}
+	if err := program.Validate(); err != nil {
+		return Program{}, err
+	}
+
+	return program, nil
+}
+
+func (program Program) Commands() []translator.Command {
+	commands := make([]translator.Command, 0, len(program.Instructions))
+	for _, instruction := range program.Instructions {
+		command := translator.Command{
+			Kind:     instruction.Opcode.commandKind(),
+			Position: instruction.Position,
+		}
+		switch instruction.Opcode {
+		case PushNumberOpcode:
+			command.Operand = strconv.FormatFloat(program.Constants[instruction.Operand], 'g', -1, 64)
+		case PopOpcode:
+		default:
+			command.Operand = program.Symbols[instruction.Operand].Name
+		}
+
+		commands = append(commands, command)
+	}
+
+	return commands
+}
+
+func (program Program) Validate() error {
+	for symbolIndex, symbol := range program.Symbols {
+		if symbol.Kind != VariableSymbol && symbol.Kind != FunctionSymbol {
+			return fmt.Errorf("%w: unknown kind %d of symbol #%d", errInvalidProgram, symbol.Kind, symbolIndex)
+		}
+		if symbol.Name == "" || !utf8.ValidString(symbol.Name) {
+			return fmt.Errorf("%w: invalid name %q of symbol #%d", errInvalidProgram, symbol.Name, symbolIndex)
+		}
+	}
+
+	for instructionIndex, instruction := range program.Instructions {
+		if instruction.Position < 0 {
+			return fmt.Errorf("%w: negative position of instruction #%d", errInvalidProgram, instructionIndex)
+		}
+
+		switch instruction.Opcode {
+		case PushNumberOpcode:
+			if instruction.Operand < 0 || instruction.Operand >= len(program.Constants) {
+				return fmt.Errorf("%w: constant #%d of instruction #%d is out of range", errInvalidProgram, instruction.Operand, instructionIndex)
+			}
+		case PopOpcode:
+			if instruction.Operand != 0 {
+				return fmt.Errorf("%w: unexpected operand of instruction #%d", errInvalidProgram, instructionIndex)
+			}
+		case PushVariableOpcode, CallFunctionOpcode, AssignVariableOpcode, BindVariableOpcode, UnbindVariableOpcode:
+			if instruction.Operand < 0 || instruction.Operand >= len(program.Symbols) {
+				return fmt.Errorf("%w: symbol #%d of instruction #%d is out of range", errInvalidProgram, instruction.Operand, instructionIndex)
+			}
+			if program.Symbols[instruction.Operand].Kind != instruction.Opcode.symbolKind() {
+				return fmt.Errorf("%w: symbol #%d of instruction #%d has a wrong kind", errInvalidProgram, instruction.Operand, instructionIndex)
+			}
+		default:
+			return fmt.Errorf("%w: unknown opcode %d of instruction #%d", errInvalidProgram, instruction.Opcode, instructionIndex)
+		}
+	}
+
+	return nil
+}
+
+func (opcode Opcode) String() string {
+	return opcode.commandKind().String()
+}
+
+func (opcode Opcode) commandKind() translator.CommandKind {
+	switch opcode {
+	case PushNumberOpcode:
+		return translator.PushNumberCommand
+	case PushVariableOpcode:
+		return translator.PushVariableCommand
+	case CallFunctionOpcode:
+		return translator.CallFunctionCommand
+	case AssignVariableOpcode:
+		return translator.AssignVariableCommand
+	case BindVariableOpcode:
+		return translator.BindVariableCommand
+	case UnbindVariableOpcode:
+		return translator.UnbindVariableCommand
+	case PopOpcode:
+		return translator.PopCommand
+	default:
+		return -1
+	}
+}
+
+func (opcode Opcode) symbolKind() SymbolKind {
+	if opcode == CallFunctionOpcode {
+		return FunctionSymbol
+	}
+
+	return VariableSymbol
+}
+
+func (kind SymbolKind) String() string {
+	switch kind {
+	case VariableSymbol:
+		return "variable"
+	case FunctionSymbol:
+		return "function"
+	default:
+		return ""
+	}
+}
+
+func opcodeForCommandKind(kind translator.CommandKind) (Opcode, bool) {
+	for _, opcode := range opcodes {
+		if opcode.commandKind() == kind {
+			return opcode, true
+		}
+	}
+
+	return 0, false
+}
+
+func parseOpcode(name string) (Opcode, bool) {
+	for _, opcode := range opcodes {
+		if opcode.String() == name {
+			return opcode, true
+		}
+	}
+
+	return 0, false
+}
+
+func parseSymbolKind(name string) (SymbolKind, bool) {
+	for _, kind := range []SymbolKind{VariableSymbol, FunctionSymbol} {
+		if kind.String() == name {
+			return kind, true
+		}
+	}
+
+	return 0, false
+}
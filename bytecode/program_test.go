@@ -0,0 +1,198 @@
+package bytecode
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/rmaidveo/go-calculator/tokenizer"
+	"github.com/rmaidveo/go-calculator/translator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromCommands(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands []translator.Command
+		want     Program
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name:     "success/empty",
+			commands: nil,
+			want:     Program{},
+			wantErr:  assert.NoError,
+		},
+		{
+			name: "success/constants and symbols are shared",
+			commands: []translator.Command{
+				{Kind: translator.PushVariableCommand, Operand: "x", Position: 4},
+				{Kind: translator.PushNumberCommand, Operand: "2.50", Position: 8},
+				{Kind: translator.CallFunctionCommand, Operand: "*", Position: 6},
+				{Kind: translator.AssignVariableCommand, Operand: "x", Position: 0},
+				{Kind: translator.PopCommand, Position: 12},
+				{Kind: translator.PushNumberCommand, Operand: "2.5", Position: 14},
+				{Kind: translator.BindVariableCommand, Operand: "y", Position: 18},
+				{Kind: translator.PushVariableCommand, Operand: "y", Position: 23},
+				{Kind: translator.UnbindVariableCommand, Operand: "y", Position: 18},
+			},
+			want: Program{
+				Constants: []float64{2.5},
+				Symbols: []Symbol{
+					{Kind: VariableSymbol, Name: "x"},
+					{Kind: FunctionSymbol, Name: "*"},
+					{Kind: VariableSymbol, Name: "y"},
+				},
+				Instructions: []Instruction{
+					{Opcode: PushVariableOpcode, Operand: 0, Position: 4},
+					{Opcode: PushNumberOpcode, Operand: 0, Position: 8},
+					{Opcode: CallFunctionOpcode, Operand: 1, Position: 6},
+					{Opcode: AssignVariableOpcode, Operand: 0, Position: 0},
+					{Opcode: PopOpcode, Operand: 0, Position: 12},
+					{Opcode: PushNumberOpcode, Operand: 0, Position: 14},
+					{Opcode: BindVariableOpcode, Operand: 2, Position: 18},
+					{Opcode: PushVariableOpcode, Operand: 2, Position: 23},
+					{Opcode: UnbindVariableOpcode, Operand: 2, Position: 18},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error/invalid number",
+			commands: []translator.Command{
+				{Kind: translator.PushNumberCommand, Operand: "2..5", Position: 0},
+			},
+			want:    Program{},
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/unknown command kind",
+			commands: []translator.Command{
+				{Kind: translator.CommandKind(100), Position: 0},
+			},
+			want:    Program{},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromCommands(tt.commands)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestProgram_Commands(t *testing.T) {
+	commands := compile(t, "x = 2.50 * y; let z = x in z - -1")
+
+	program, err := FromCommands(commands)
+	assert.NoError(t, err)
+
+	for index := range commands {
+		if commands[index].Operand == "2.50" {
+			commands[index].Operand = "2.5"
+		}
+	}
+	assert.Equal(t, commands, program.Commands())
+}
+
+func TestProgram_Validate(t *testing.T) {
+	symbols := []Symbol{{Kind: VariableSymbol, Name: "x"}, {Kind: FunctionSymbol, Name: "neg"}}
+
+	tests := []struct {
+		name    string
+		program Program
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success",
+			program: Program{Constants: []float64{1}, Symbols: symbols, Instructions: []Instruction{{Opcode: PushNumberOpcode}}},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/not finite constants",
+			program: Program{Constants: []float64{math.Inf(1), math.Inf(-1), math.NaN()}},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error/unknown symbol kind",
+			program: Program{Symbols: []Symbol{{Kind: 100, Name: "x"}}},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/empty symbol name",
+			program: Program{Symbols: []Symbol{{Kind: VariableSymbol, Name: ""}}},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/invalid symbol name",
+			program: Program{Symbols: []Symbol{{Kind: VariableSymbol, Name: "\xff"}}},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/negative position",
+			program: Program{Symbols: symbols, Instructions: []Instruction{{Opcode: PopOpcode, Position: -1}}},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/constant out of range",
+			program: Program{Instructions: []Instruction{{Opcode: PushNumberOpcode, Operand: 0}}},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/operand of pop",
+			program: Program{Instructions: []Instruction{{Opcode: PopOpcode, Operand: 1}}},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/symbol out of range",
+			program: Program{Symbols: symbols, Instructions: []Instruction{{Opcode: PushVariableOpcode, Operand: 2}}},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/symbol of wrong kind",
+			program: Program{Symbols: symbols, Instructions: []Instruction{{Opcode: CallFunctionOpcode, Operand: 0}}},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/unknown opcode",
+			program: Program{Instructions: []Instruction{{Opcode: 100}}},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.program.Validate()
+
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestOpcode_String(t *testing.T) {
+	for _, opcode := range opcodes {
+		got, ok := parseOpcode(opcode.String())
+
+		assert.True(t, ok)
+		assert.Equal(t, opcode, got)
+	}
+
+	assert.Equal(t, "", Opcode(100).String())
+}
+
+func compile(t *testing.T, text string) []translator.Command {
+	functionNames := map[string]struct{}{}
+	for functionName := range builtin.Functions() {
+		functionNames[functionName] = struct{}{}
+	}
+
+	tokens, err := tokenizer.Tokenize(text)
+	assert.NoError(t, err)
+
+	commands, err := translator.Translate(tokens, functionNames)
+	assert.NoError(t, err)
+
+	return commands
+}
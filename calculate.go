@@ -2,20 +2,120 @@ package calculator
 
 import (
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
+	"github.com/rmaidveo/go-calculator/constants"
 	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/rmaidveo/go-calculator/explain"
+	"github.com/rmaidveo/go-calculator/locale"
 	"github.com/rmaidveo/go-calculator/tokenizer"
 	"github.com/rmaidveo/go-calculator/translator"
 )
 
+type Options struct {
+	Constants              map[string]float64
+	Locale                 locale.Locale
+	ImplicitMultiplication translator.ImplicitMultiplication
+	PostfixPercent         bool
+	ResolveFunction        func(name string) (string, bool)
+}
+
 func Calculate(
 	text string,
 	variables map[string]float64,
 	functions map[string]evaluator.Function,
 ) (float64, error) {
-	tokens, err := tokenizer.Tokenize(text)
+	result, _, err := CalculateWithVariables(text, variables, functions)
+	return result, err
+}
+
+func CalculateWithOptions(
+	text string,
+	variables map[string]float64,
+	functions map[string]evaluator.Function,
+	options Options,
+) (float64, error) {
+	if err := constants.CheckVariables(options.Constants, variables); err != nil {
+		return 0, err
+	}
+
+	commands, err := compile(text, functions, options, variables)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := evaluator.Evaluate(commands, variables, functions)
+	if err != nil {
+		return 0, fmt.Errorf("unable to evaluate: %w", err)
+	}
+
+	return result, nil
+}
+
+func CalculateWithVariables(
+	text string,
+	variables map[string]float64,
+	functions map[string]evaluator.Function,
+) (float64, map[string]float64, error) {
+	commands, err := compile(text, functions, Options{}, variables)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	result, finalVariables, err := evaluator.EvaluateWithVariables(commands, variables, functions)
+	if err != nil {
+		return 0, nil, fmt.Errorf("unable to evaluate: %w", err)
+	}
+
+	return result, finalVariables, nil
+}
+
+func CalculateGradient(
+	text string,
+	variables map[string]float64,
+	functions map[string]evaluator.Function,
+	gradientVariables []string,
+) (float64, map[string]float64, error) {
+	commands, err := compile(text, functions, Options{}, variables)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	result, gradient, err := evaluator.EvaluateGradient(commands, variables, functions, gradientVariables)
 	if err != nil {
-		return 0, fmt.Errorf("unable to tokenize: %w", err)
+		return 0, nil, fmt.Errorf("unable to evaluate the gradient: %w", err)
+	}
+
+	return result, gradient, nil
+}
+
+func Compile(text string, functions map[string]evaluator.Function) ([]translator.Command, error) {
+	return CompileWithOptions(text, functions, Options{})
+}
+
+func CompileWithOptions(
+	text string,
+	functions map[string]evaluator.Function,
+	options Options,
+) ([]translator.Command, error) {
+	return compile(text, functions, options, nil)
+}
+
+// the variables only decide whether an alias such as π may fall back to a variable of the same name
+func compile(
+	text string,
+	functions map[string]evaluator.Function,
+	options Options,
+	variables map[string]float64,
+) ([]translator.Command, error) {
+	tokens, err := tokenizer.TokenizeWithOptions(text, tokenizer.Options{Locale: options.Locale})
+	if err != nil {
+		return nil, fmt.Errorf("unable to tokenize: %w", err)
+	}
+
+	if err := checkConstantAliases(text, tokens, options.Constants, variables); err != nil {
+		return nil, err
 	}
 
 	functionNames := make(map[string]struct{}, len(functions))
@@ -23,15 +123,48 @@ func Calculate(
 		functionNames[functionName] = struct{}{}
 	}
 
-	commands, err := translator.Translate(tokens, functionNames)
+	commands, err := translator.TranslateWithOptions(tokens, functionNames, translator.Options{
+		Constants:              options.Constants,
+		ImplicitMultiplication: options.ImplicitMultiplication,
+		PostfixPercent:         options.PostfixPercent,
+		ResolveFunction:        options.ResolveFunction,
+	})
 	if err != nil {
-		return 0, fmt.Errorf("unable to translate: %w", err)
+		return nil, fmt.Errorf("unable to translate: %w", err)
 	}
 
-	result, err := evaluator.Evaluate(commands, variables, functions)
+	return commands, nil
+}
+
+func checkConstantAliases(
+	text string,
+	tokens []tokenizer.Token,
+	constantSet map[string]float64,
+	variables map[string]float64,
+) error {
+	for _, token := range tokens {
+		if token.Kind != tokenizer.IdentifierToken || strings.HasPrefix(text[token.Position:], token.Value) {
+			continue
+		}
+		if _, ok := constantSet[token.Value]; ok {
+			continue
+		}
+		if _, ok := variables[token.Value]; ok {
+			continue
+		}
+
+		alias, _ := utf8.DecodeRuneInString(text[token.Position:])
+		return fmt.Errorf("unable to use %q at position %d: the constant %q is not enabled", alias, token.Position, token.Value)
+	}
+
+	return nil
+}
+
+func Explain(text string, variables map[string]float64, functions map[string]evaluator.Function) (string, error) {
+	commands, err := compile(text, functions, Options{}, variables)
 	if err != nil {
-		return 0, fmt.Errorf("unable to evaluate: %w", err)
+		return "", err
 	}
 
-	return result, nil
+	return explain.Explain(commands, variables, functions)
 }
@@ -1,9 +1,16 @@
 package calculator
 
 import (
+	"math"
 	"testing"
 
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/rmaidveo/go-calculator/constants"
+	"github.com/rmaidveo/go-calculator/constants/physical"
 	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/rmaidveo/go-calculator/locale"
+	"github.com/rmaidveo/go-calculator/registry"
+	"github.com/rmaidveo/go-calculator/translator"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -37,6 +44,23 @@ func TestCalculate(t *testing.T) {
 			want:    65,
 			wantErr: assert.NoError,
 		},
+		{
+			name: "success/with comments",
+			args: args{
+				text:      "/* base */ 23 + 42 # offset",
+				variables: map[string]float64{},
+				functions: map[string]evaluator.Function{
+					"+": {
+						Arity: 2,
+						Handler: func(arguments []float64) (float64, error) {
+							return arguments[0] + arguments[1], nil
+						},
+					},
+				},
+			},
+			want:    65,
+			wantErr: assert.NoError,
+		},
 		{
 			name: "error/unable to tokenize",
 			args: args{
@@ -88,6 +112,56 @@ func TestCalculate(t *testing.T) {
 			want:    0,
 			wantErr: assert.Error,
 		},
+		{
+			name: "success/left-associative subtraction",
+			args: args{
+				text:      "1 - 2 - 3",
+				variables: map[string]float64{},
+				functions: builtin.Functions(),
+			},
+			want:    -4,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/left-associative division",
+			args: args{
+				text:      "8 / 2 / 2",
+				variables: map[string]float64{},
+				functions: builtin.Functions(),
+			},
+			want:    2,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/right-associative exponentiation",
+			args: args{
+				text:      "2 ^ 3 ^ 2",
+				variables: map[string]float64{},
+				functions: builtin.Functions(),
+			},
+			want:    512,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/brackets",
+			args: args{
+				text:      "|2 - 7| * [⌊2.5⌋ + {⌈0.5⌉}]",
+				variables: map[string]float64{},
+				functions: builtin.Functions(),
+			},
+			want:    15,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error/absolute value between numbers",
+			args: args{
+				text:      "2 |x| 3",
+				variables: map[string]float64{"x": -1},
+				functions: builtin.Functions(),
+			},
+			want:    0,
+			wantErr: assert.Error,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -98,3 +172,568 @@ func TestCalculate(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculateWithVariables(t *testing.T) {
+	type args struct {
+		text      string
+		variables map[string]float64
+		functions map[string]evaluator.Function
+	}
+
+	functions := map[string]evaluator.Function{
+		"+": {
+			Arity: 2,
+			Handler: func(arguments []float64) (float64, error) {
+				return arguments[0] + arguments[1], nil
+			},
+		},
+		"*": {
+			Arity: 2,
+			Handler: func(arguments []float64) (float64, error) {
+				return arguments[0] * arguments[1], nil
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		args          args
+		want          float64
+		wantVariables map[string]float64
+		wantErr       assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success/assignments",
+			args: args{
+				text:      "rate = 0.5; years = 2\nprincipal * (1 + rate) * years",
+				variables: map[string]float64{"principal": 100},
+				functions: functions,
+			},
+			want:          300,
+			wantVariables: map[string]float64{"principal": 100, "rate": 0.5, "years": 2},
+			wantErr:       assert.NoError,
+		},
+		{
+			name: "success/line starting with an operator",
+			args: args{
+				text:      "x\n+1 # comment\n* 2",
+				variables: map[string]float64{"x": 2},
+				functions: functions,
+			},
+			want:          4,
+			wantVariables: map[string]float64{"x": 2},
+			wantErr:       assert.NoError,
+		},
+		{
+			name: "success/unicode alias for a variable",
+			args: args{
+				text:      "2 * π",
+				variables: map[string]float64{"pi": 3.14159},
+				functions: functions,
+			},
+			want:          6.28318,
+			wantVariables: map[string]float64{"pi": 3.14159},
+			wantErr:       assert.NoError,
+		},
+		{
+			name: "success/let binding",
+			args: args{
+				text:      "total = 2 * (let x = 3 in x * x) + x",
+				variables: map[string]float64{"x": 10},
+				functions: functions,
+			},
+			want:          28,
+			wantVariables: map[string]float64{"x": 10, "total": 28},
+			wantErr:       assert.NoError,
+		},
+		{
+			name: "error/unable to translate",
+			args: args{
+				text:      "let x = 3",
+				variables: map[string]float64{},
+				functions: functions,
+			},
+			want:          0,
+			wantVariables: nil,
+			wantErr:       assert.Error,
+		},
+		{
+			name: "error/unable to evaluate",
+			args: args{
+				text:      "x = 3; * 2",
+				variables: map[string]float64{},
+				functions: functions,
+			},
+			want:          0,
+			wantVariables: nil,
+			wantErr:       assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotVariables, err := CalculateWithVariables(tt.args.text, tt.args.variables, tt.args.functions)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantVariables, gotVariables)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestCalculateWithOptions(t *testing.T) {
+	type args struct {
+		text      string
+		variables map[string]float64
+		functions map[string]evaluator.Function
+		options   Options
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    float64
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success/mathematical constants",
+			args: args{
+				text:      "r = 2; pi * r ^ 2 + tau - 2 * pi",
+				variables: map[string]float64{},
+				functions: builtin.Functions(),
+				options:   Options{Constants: constants.Mathematical()},
+			},
+			want:    4 * math.Pi,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/unicode aliases",
+			args: args{
+				text:      "r = 2; π × r² − √16 ÷ 2",
+				variables: map[string]float64{},
+				functions: builtin.Functions(),
+				options:   Options{Constants: constants.Mathematical()},
+			},
+			want:    4*math.Pi - 2,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error/unicode alias after a number",
+			args: args{
+				text:      "2π",
+				variables: map[string]float64{},
+				functions: builtin.Functions(),
+				options:   Options{Constants: constants.Mathematical()},
+			},
+			want:    0,
+			wantErr: assert.Error,
+		},
+		{
+			name: "success/unicode alias for a variable",
+			args: args{
+				text:      "2 * π",
+				variables: map[string]float64{"pi": 3},
+				functions: builtin.Functions(),
+				options:   Options{},
+			},
+			want:    6,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error/unicode alias without constants",
+			args: args{
+				text:      "2 * π",
+				variables: map[string]float64{},
+				functions: builtin.Functions(),
+				options:   Options{},
+			},
+			want:    0,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/variable named as a constant",
+			args: args{
+				text:      "2 * g",
+				variables: map[string]float64{"g": 10},
+				functions: builtin.Functions(),
+				options:   Options{Constants: physical.Constants()},
+			},
+			want:    0,
+			wantErr: assert.Error,
+		},
+		{
+			name: "success/no constants",
+			args: args{
+				text:      "pi = 3; pi * 2",
+				variables: map[string]float64{},
+				functions: builtin.Functions(),
+				options:   Options{},
+			},
+			want:    6,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error/assignment to a constant",
+			args: args{
+				text:      "pi = 3; pi * 2",
+				variables: map[string]float64{},
+				functions: builtin.Functions(),
+				options:   Options{Constants: constants.Mathematical()},
+			},
+			want:    0,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/unable to evaluate",
+			args: args{
+				text:      "pi * x",
+				variables: map[string]float64{},
+				functions: builtin.Functions(),
+				options:   Options{Constants: constants.Mathematical()},
+			},
+			want:    0,
+			wantErr: assert.Error,
+		},
+		{
+			name: "success/german",
+			args: args{
+				text:      "x = 1.234,5; max(x; 2,5) * 2",
+				variables: map[string]float64{},
+				functions: builtin.Functions(),
+				options:   Options{Locale: locale.German},
+			},
+			want:    2469,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/french",
+			args: args{
+				text:      "1\u202f000,25 + min(0,5; 1)",
+				variables: map[string]float64{},
+				functions: builtin.Functions(),
+				options:   Options{Locale: locale.French},
+			},
+			want:    1000.75,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/default locale",
+			args: args{
+				text:      "max(1.5, 2)",
+				variables: map[string]float64{},
+				functions: builtin.Functions(),
+				options:   Options{Locale: locale.Locale{}},
+			},
+			want:    2,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error/short digit group in the german locale",
+			args: args{
+				text:      "1.23",
+				variables: map[string]float64{},
+				functions: builtin.Functions(),
+				options:   Options{Locale: locale.German},
+			},
+			want:    0,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/plain space as the french grouping separator",
+			args: args{
+				text:      "1 234,5",
+				variables: map[string]float64{},
+				functions: builtin.Functions(),
+				options:   Options{Locale: locale.French},
+			},
+			want:    0,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/argument separator inside brackets",
+			args: args{
+				text:      "max([1; 2])",
+				variables: map[string]float64{},
+				functions: builtin.Functions(),
+				options:   Options{Locale: locale.German},
+			},
+			want:    0,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/decimal point in the german locale",
+			args: args{
+				text:      "1.5",
+				variables: map[string]float64{},
+				functions: builtin.Functions(),
+				options:   Options{Locale: locale.Locale{DecimalSeparator: ',', ArgumentSeparator: ';'}},
+			},
+			want:    0,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/invalid locale",
+			args: args{
+				text:      "1",
+				variables: map[string]float64{},
+				functions: builtin.Functions(),
+				options:   Options{Locale: locale.Locale{DecimalSeparator: ',', ArgumentSeparator: ','}},
+			},
+			want:    0,
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CalculateWithOptions(tt.args.text, tt.args.variables, tt.args.functions, tt.args.options)
+
+			assert.InDelta(t, tt.want, got, 1e-12)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestCalculateGradient(t *testing.T) {
+	type args struct {
+		text              string
+		variables         map[string]float64
+		gradientVariables []string
+	}
+
+	tests := []struct {
+		name         string
+		args         args
+		want         float64
+		wantGradient map[string]float64
+		wantErr      assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			args: args{
+				text:              "area = width * height; area + 2 * sin(angle)",
+				variables:         map[string]float64{"width": 3, "height": 4, "angle": 0},
+				gradientVariables: []string{"width", "height", "angle"},
+			},
+			want:         12,
+			wantGradient: map[string]float64{"width": 4, "height": 3, "angle": 2},
+			wantErr:      assert.NoError,
+		},
+		{
+			name: "error/unable to translate",
+			args: args{
+				text:              "let x = 3",
+				variables:         map[string]float64{},
+				gradientVariables: nil,
+			},
+			want:         0,
+			wantGradient: nil,
+			wantErr:      assert.Error,
+		},
+		{
+			name: "error/unable to evaluate the gradient",
+			args: args{
+				text:              "x / y",
+				variables:         map[string]float64{"x": 1, "y": 0},
+				gradientVariables: []string{"x"},
+			},
+			want:         0,
+			wantGradient: nil,
+			wantErr:      assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotGradient, err := CalculateGradient(
+				tt.args.text,
+				tt.args.variables,
+				builtin.Functions(),
+				tt.args.gradientVariables,
+			)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantGradient, gotGradient)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestExplain(t *testing.T) {
+	type args struct {
+		text      string
+		variables map[string]float64
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success",
+			args:    args{text: "price * qty", variables: map[string]float64{"price": 12.5, "qty": 33}},
+			want:    "price*qty = 12.5*33 = 412.5",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error/unable to compile",
+			args:    args{text: "(price", variables: map[string]float64{}},
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/unable to evaluate",
+			args:    args{text: "price * qty", variables: map[string]float64{}},
+			want:    "",
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Explain(tt.args.text, tt.args.variables, builtin.Functions())
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestCompile(t *testing.T) {
+	type args struct {
+		text      string
+		functions map[string]evaluator.Function
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    []translator.Command
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			args: args{
+				text:      "-x + 42",
+				functions: map[string]evaluator.Function{"+": {Arity: 2}, "neg": {Arity: 1}},
+			},
+			want: []translator.Command{
+				{Kind: translator.PushVariableCommand, Operand: "x", Position: 1},
+				{Kind: translator.CallFunctionCommand, Operand: "neg", Position: 0},
+				{Kind: translator.PushNumberCommand, Operand: "42", Position: 5},
+				{Kind: translator.CallFunctionCommand, Operand: "+", Position: 3},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error/unable to tokenize",
+			args: args{
+				text:      "23 @ 42",
+				functions: map[string]evaluator.Function{"+": {Arity: 2}},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/unable to translate",
+			args: args{
+				text:      "(23 + 42",
+				functions: map[string]evaluator.Function{"+": {Arity: 2}},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Compile(tt.args.text, tt.args.functions)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestCompileWithOptions(t *testing.T) {
+	type args struct {
+		text      string
+		variables map[string]float64
+		options   Options
+	}
+
+	caseInsensitive := registry.New(registry.Options{CaseInsensitive: true})
+	assert.NoError(t, caseInsensitive.Merge(builtin.Registry()))
+
+	tests := []struct {
+		name    string
+		args    args
+		want    float64
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success/implicit multiplication/as explicit",
+			args: args{
+				text:      "1/2x",
+				variables: map[string]float64{"x": 4},
+				options:   Options{ImplicitMultiplication: translator.ImplicitMultiplicationAsExplicit},
+			},
+			want:    2,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/implicit multiplication/binds tighter",
+			args: args{
+				text:      "1/2x",
+				variables: map[string]float64{"x": 4},
+				options:   Options{ImplicitMultiplication: translator.ImplicitMultiplicationBindsTighter},
+			},
+			want:    0.125,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/implicit multiplication with constants",
+			args: args{
+				text:      "(a + b)(a - b) / 2pi",
+				variables: map[string]float64{"a": 3, "b": 1},
+				options: Options{
+					Constants:              map[string]float64{"pi": 4},
+					ImplicitMultiplication: translator.ImplicitMultiplicationBindsTighter,
+				},
+			},
+			want:    1,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/case-insensitive function names",
+			args: args{
+				text:      "MAX(2, Sqrt(x))",
+				variables: map[string]float64{"x": 9},
+				options:   Options{ResolveFunction: caseInsensitive.Resolve},
+			},
+			want:    3,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error/unable to translate",
+			args: args{
+				text:      "2 3",
+				variables: map[string]float64{},
+				options:   Options{ImplicitMultiplication: translator.ImplicitMultiplicationAsExplicit},
+			},
+			want:    0,
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands, err := CompileWithOptions(tt.args.text, builtin.Functions(), tt.args.options)
+			if err != nil {
+				tt.wantErr(t, err)
+				return
+			}
+
+			got, err := evaluator.Evaluate(commands, tt.args.variables, builtin.Functions())
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rmaidveo/go-calculator/tokenizer"
+)
+
+type position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type textRange struct {
+	Start position `json:"start"`
+	End   position `json:"end"`
+}
+
+func offsetToPosition(text string, offset int) position {
+	if offset > len(text) {
+		offset = len(text)
+	}
+
+	lineStart := strings.LastIndexByte(text[:offset], '\n') + 1
+	character := 0
+	for _, textRune := range text[lineStart:offset] {
+		character += utf16Length(textRune)
+	}
+
+	return position{Line: strings.Count(text[:offset], "\n"), Character: character}
+}
+
+func positionToOffset(text string, textPosition position) int {
+	offset := 0
+	for line := 0; line < textPosition.Line; line++ {
+		newlineIndex := strings.IndexByte(text[offset:], '\n')
+		if newlineIndex == -1 {
+			return len(text)
+		}
+
+		offset += newlineIndex + 1
+	}
+
+	character := 0
+	for offset < len(text) && text[offset] != '\n' && character < textPosition.Character {
+		textRune, size := utf8.DecodeRuneInString(text[offset:])
+		character += utf16Length(textRune)
+		offset += size
+	}
+
+	return offset
+}
+
+func utf16Length(textRune rune) int {
+	if textRune > 0xffff {
+		return 2
+	}
+
+	return 1
+}
+
+func rangeForOffsets(text string, start int, end int) textRange {
+	return textRange{Start: offsetToPosition(text, start), End: offsetToPosition(text, end)}
+}
+
+func identifierAt(tokens []tokenizer.Token, offset int) (tokenizer.Token, bool) {
+	for _, token := range tokens {
+		if token.Kind != tokenizer.IdentifierToken {
+			continue
+		}
+		if token.Position <= offset && offset <= token.Position+len(token.Value) {
+			return token, true
+		}
+	}
+
+	return tokenizer.Token{}, false
+}
+
+func tokenEnd(tokens []tokenizer.Token, offset int) int {
+	for _, token := range tokens {
+		if token.Position != offset {
+			continue
+		}
+
+		switch {
+		case token.Value != "":
+			return offset + len(token.Value)
+		case token.Kind == tokenizer.LetToken:
+			return offset + len("let")
+		case token.Kind == tokenizer.InToken:
+			return offset + len("in")
+		}
+	}
+
+	return offset + 1
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/rmaidveo/go-calculator/tokenizer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOffsetToPosition(t *testing.T) {
+	text := "x = 1\nÿ = 𝑥 + y\n"
+
+	tests := []struct {
+		name   string
+		offset int
+		want   position
+	}{
+		{name: "start", offset: 0, want: position{Line: 0, Character: 0}},
+		{name: "first line", offset: 4, want: position{Line: 0, Character: 4}},
+		{name: "line start", offset: 6, want: position{Line: 1, Character: 0}},
+		{name: "after a two-byte rune", offset: 10, want: position{Line: 1, Character: 3}},
+		{name: "after a surrogate pair", offset: 15, want: position{Line: 1, Character: 6}},
+		{name: "end", offset: len(text), want: position{Line: 2, Character: 0}},
+		{name: "after the end", offset: len(text) + 10, want: position{Line: 2, Character: 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := offsetToPosition(text, tt.offset)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPositionToOffset(t *testing.T) {
+	text := "x = 1\nÿ = 𝑥 + y\n"
+
+	tests := []struct {
+		name         string
+		textPosition position
+		want         int
+	}{
+		{name: "start", textPosition: position{Line: 0, Character: 0}, want: 0},
+		{name: "after a two-byte rune", textPosition: position{Line: 1, Character: 3}, want: 10},
+		{name: "after a surrogate pair", textPosition: position{Line: 1, Character: 6}, want: 15},
+		{name: "after the line end", textPosition: position{Line: 0, Character: 100}, want: 5},
+		{name: "after the last line", textPosition: position{Line: 10, Character: 0}, want: len(text)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := positionToOffset(text, tt.textPosition)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIdentifierAt(t *testing.T) {
+	tokens, err := tokenizer.Tokenize("price * sqrt(qty)")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		offset int
+		want   tokenizer.Token
+		wantOk assert.BoolAssertionFunc
+	}{
+		{name: "start of an identifier", offset: 0, want: tokens[0], wantOk: assert.True},
+		{name: "end of an identifier", offset: 5, want: tokens[0], wantOk: assert.True},
+		{name: "function", offset: 10, want: tokens[2], wantOk: assert.True},
+		{name: "operator", offset: 6, want: tokenizer.Token{}, wantOk: assert.False},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := identifierAt(tokens, tt.offset)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantOk(t, ok)
+		})
+	}
+}
+
+func TestTokenEnd(t *testing.T) {
+	text := "let x = 12 in x + 1"
+	tokens, err := tokenizer.Tokenize(text)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		offset int
+		want   int
+	}{
+		{name: "keyword", offset: 0, want: 3},
+		{name: "number", offset: 8, want: 10},
+		{name: "operator", offset: 16, want: 17},
+		{name: "no token", offset: len(text), want: len(text) + 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tokenEnd(tokens, tt.offset)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
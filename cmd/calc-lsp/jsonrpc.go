@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	jsonrpcVersion      = "2.0"
+	contentLengthHeader = "Content-Length"
+
+	parseErrorCode     = -32700
+	methodNotFoundCode = -32601
+	invalidParamsCode  = -32602
+	internalErrorCode  = -32603
+)
+
+type message struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+	Result  json.RawMessage  `json:"result,omitempty"`
+	Error   *responseError   `json:"error,omitempty"`
+}
+
+type responseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (err *responseError) Error() string {
+	return fmt.Sprintf("%s (code %d)", err.Message, err.Code)
+}
+
+func readMessage(reader *bufio.Reader) (message, error) {
+	contentLength := -1
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return message{}, err
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return message{}, fmt.Errorf("invalid header %q", line)
+		}
+		if strings.EqualFold(strings.TrimSpace(name), contentLengthHeader) {
+			contentLength, err = strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || contentLength < 0 {
+				return message{}, fmt.Errorf("invalid content length %q", value)
+			}
+		}
+	}
+	if contentLength == -1 {
+		return message{}, errors.New("no content length is found")
+	}
+
+	content := make([]byte, contentLength)
+	if _, err := io.ReadFull(reader, content); err != nil {
+		return message{}, fmt.Errorf("unable to read the content: %w", err)
+	}
+
+	var incomingMessage message
+	if err := json.Unmarshal(content, &incomingMessage); err != nil {
+		return message{}, &responseError{Code: parseErrorCode, Message: err.Error()}
+	}
+
+	return incomingMessage, nil
+}
+
+func writeMessage(writer io.Writer, outgoingMessage message) error {
+	outgoingMessage.JSONRPC = jsonrpcVersion
+	content, err := json.Marshal(outgoingMessage)
+	if err != nil {
+		return fmt.Errorf("unable to marshal the message: %w", err)
+	}
+
+	if _, err := fmt.Fprintf(writer, "%s: %d\r\n\r\n%s", contentLengthHeader, len(content), content); err != nil {
+		return fmt.Errorf("unable to write the message: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadMessage(t *testing.T) {
+	id := json.RawMessage("1")
+
+	tests := []struct {
+		name    string
+		text    string
+		want    message
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			text: "Content-Length: 40\r\nContent-Type: application/vscode-jsonrpc\r\n\r\n" +
+				`{"jsonrpc":"2.0","id":1,"method":"exit"}`,
+			want:    message{JSONRPC: "2.0", ID: &id, Method: "exit"},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error/no content length",
+			text:    "Content-Type: application/json\r\n\r\n{}",
+			want:    message{},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/invalid content length",
+			text:    "Content-Length: many\r\n\r\n{}",
+			want:    message{},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/invalid header",
+			text:    "Content-Length\r\n\r\n{}",
+			want:    message{},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/short content",
+			text:    "Content-Length: 10\r\n\r\n{}",
+			want:    message{},
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/invalid JSON",
+			text: "Content-Length: 2\r\n\r\n{]",
+			want: message{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				var replyErr *responseError
+				return assert.ErrorAs(t, err, &replyErr, msgAndArgs...) &&
+					assert.Equal(t, parseErrorCode, replyErr.Code, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readMessage(bufio.NewReader(strings.NewReader(tt.text)))
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestWriteMessage(t *testing.T) {
+	var buffer bytes.Buffer
+
+	err := writeMessage(&buffer, message{Method: "initialized", Params: json.RawMessage("{}")})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Content-Length: 52\r\n\r\n"+`{"jsonrpc":"2.0","method":"initialized","params":{}}`, buffer.String())
+}
@@ -0,0 +1,529 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rmaidveo/go-calculator/ast"
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/rmaidveo/go-calculator/constants"
+	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/rmaidveo/go-calculator/format"
+	"github.com/rmaidveo/go-calculator/registry"
+	"github.com/rmaidveo/go-calculator/tokenizer"
+	"github.com/rmaidveo/go-calculator/translator"
+)
+
+const (
+	fullTextDocumentSync = 1
+	errorSeverity        = 1
+	functionCompletion   = 3
+	variableCompletion   = 6
+	keywordCompletion    = 14
+	constantCompletion   = 21
+	diagnosticSource     = "calc"
+)
+
+var (
+	errExit         = errors.New("exit")
+	positionPattern = regexp.MustCompile(`at position (\d+)`)
+)
+
+type server struct {
+	reader     *bufio.Reader
+	writer     io.Writer
+	functions  map[string]evaluator.Function
+	registry   *registry.Registry
+	constants  map[string]float64
+	documents  map[string]string
+	isShutdown bool
+}
+
+type textDocumentIdentifier struct {
+	URI string `json:"uri"`
+}
+
+type textDocumentItem struct {
+	URI  string `json:"uri"`
+	Text string `json:"text"`
+}
+
+type textDocumentPositionParams struct {
+	TextDocument textDocumentIdentifier `json:"textDocument"`
+	Position     position               `json:"position"`
+}
+
+type didOpenParams struct {
+	TextDocument textDocumentItem `json:"textDocument"`
+}
+
+type didChangeParams struct {
+	TextDocument   textDocumentIdentifier `json:"textDocument"`
+	ContentChanges []struct {
+		Text string `json:"text"`
+	} `json:"contentChanges"`
+}
+
+type didCloseParams struct {
+	TextDocument textDocumentIdentifier `json:"textDocument"`
+}
+
+type formattingParams struct {
+	TextDocument textDocumentIdentifier `json:"textDocument"`
+}
+
+type diagnostic struct {
+	Range    textRange `json:"range"`
+	Severity int       `json:"severity"`
+	Source   string    `json:"source"`
+	Message  string    `json:"message"`
+}
+
+type publishDiagnosticsParams struct {
+	URI         string       `json:"uri"`
+	Diagnostics []diagnostic `json:"diagnostics"`
+}
+
+type markupContent struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+type completionItem struct {
+	Label         string         `json:"label"`
+	Kind          int            `json:"kind"`
+	Detail        string         `json:"detail,omitempty"`
+	Documentation *markupContent `json:"documentation,omitempty"`
+}
+
+type hover struct {
+	Contents markupContent `json:"contents"`
+	Range    textRange     `json:"range"`
+}
+
+type location struct {
+	URI   string    `json:"uri"`
+	Range textRange `json:"range"`
+}
+
+type textEdit struct {
+	Range   textRange `json:"range"`
+	NewText string    `json:"newText"`
+}
+
+func newServer(reader io.Reader, writer io.Writer) *server {
+	functionRegistry := builtin.Registry()
+	return &server{
+		reader:    bufio.NewReader(reader),
+		writer:    writer,
+		functions: functionRegistry.Functions(),
+		registry:  functionRegistry,
+		constants: constants.Mathematical(),
+		documents: map[string]string{},
+	}
+}
+
+func (server *server) serve() error {
+	for {
+		incomingMessage, err := readMessage(server.reader)
+		if err != nil {
+			var parseErr *responseError
+			if errors.As(err, &parseErr) {
+				if err := server.reply(nil, nil, parseErr); err != nil {
+					return err
+				}
+
+				continue
+			}
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return fmt.Errorf("unable to read a message: %w", err)
+		}
+
+		result, err := server.handle(incomingMessage)
+		if errors.Is(err, errExit) {
+			if !server.isShutdown {
+				return errors.New("exit is received before shutdown")
+			}
+
+			return nil
+		}
+		if incomingMessage.ID == nil {
+			continue
+		}
+
+		var replyErr *responseError
+		if err != nil && !errors.As(err, &replyErr) {
+			replyErr = &responseError{Code: internalErrorCode, Message: err.Error()}
+		}
+		if err := server.reply(incomingMessage.ID, result, replyErr); err != nil {
+			return err
+		}
+	}
+}
+
+func (server *server) handle(incomingMessage message) (interface{}, error) {
+	switch incomingMessage.Method {
+	case "initialize":
+		return map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync":           fullTextDocumentSync,
+				"completionProvider":         map[string]interface{}{},
+				"hoverProvider":              true,
+				"definitionProvider":         true,
+				"documentFormattingProvider": true,
+			},
+			"serverInfo": map[string]string{"name": "calc-lsp"},
+		}, nil
+	case "initialized", "$/cancelRequest", "$/setTrace":
+		return nil, nil
+	case "shutdown":
+		server.isShutdown = true
+		return nil, nil
+	case "exit":
+		return nil, errExit
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err := decodeParams(incomingMessage.Params, &params); err != nil {
+			return nil, err
+		}
+
+		server.documents[params.TextDocument.URI] = params.TextDocument.Text
+		return nil, server.publishDiagnostics(params.TextDocument.URI)
+	case "textDocument/didChange":
+		var params didChangeParams
+		if err := decodeParams(incomingMessage.Params, &params); err != nil {
+			return nil, err
+		}
+		if len(params.ContentChanges) == 0 {
+			return nil, nil
+		}
+
+		server.documents[params.TextDocument.URI] = params.ContentChanges[len(params.ContentChanges)-1].Text
+		return nil, server.publishDiagnostics(params.TextDocument.URI)
+	case "textDocument/didClose":
+		var params didCloseParams
+		if err := decodeParams(incomingMessage.Params, &params); err != nil {
+			return nil, err
+		}
+
+		delete(server.documents, params.TextDocument.URI)
+		return nil, server.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+			URI:         params.TextDocument.URI,
+			Diagnostics: []diagnostic{},
+		})
+	case "textDocument/completion":
+		var params textDocumentPositionParams
+		if err := decodeParams(incomingMessage.Params, &params); err != nil {
+			return nil, err
+		}
+
+		return server.completion(server.documents[params.TextDocument.URI]), nil
+	case "textDocument/hover":
+		var params textDocumentPositionParams
+		if err := decodeParams(incomingMessage.Params, &params); err != nil {
+			return nil, err
+		}
+
+		return server.hover(server.documents[params.TextDocument.URI], params.Position), nil
+	case "textDocument/definition":
+		var params textDocumentPositionParams
+		if err := decodeParams(incomingMessage.Params, &params); err != nil {
+			return nil, err
+		}
+
+		return server.definition(params.TextDocument.URI, params.Position), nil
+	case "textDocument/formatting":
+		var params formattingParams
+		if err := decodeParams(incomingMessage.Params, &params); err != nil {
+			return nil, err
+		}
+
+		return server.formatting(server.documents[params.TextDocument.URI])
+	default:
+		return nil, &responseError{Code: methodNotFoundCode, Message: fmt.Sprintf("unknown method %q", incomingMessage.Method)}
+	}
+}
+
+func (server *server) publishDiagnostics(uri string) error {
+	text := server.documents[uri]
+	diagnostics := []diagnostic{}
+	if err := server.validate(text); err != nil {
+		offset := len(text)
+		if matches := positionPattern.FindAllStringSubmatch(err.Error(), -1); len(matches) != 0 {
+			offset, _ = strconv.Atoi(matches[len(matches)-1][1])
+		}
+
+		tokens, _ := tokenizer.Tokenize(text)
+		diagnostics = append(diagnostics, diagnostic{
+			Range:    rangeForOffsets(text, offset, tokenEnd(tokens, offset)),
+			Severity: errorSeverity,
+			Source:   diagnosticSource,
+			Message:  err.Error(),
+		})
+	}
+
+	return server.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: uri, Diagnostics: diagnostics})
+}
+
+func (server *server) validate(text string) error {
+	commands, err := server.compile(text)
+	if err != nil {
+		return err
+	}
+	if len(commands) == 0 {
+		return nil
+	}
+
+	if _, err := ast.Build(commands, server.functions); err != nil {
+		return fmt.Errorf("unable to build the syntax tree: %w", err)
+	}
+
+	return nil
+}
+
+func (server *server) compile(text string) ([]translator.Command, error) {
+	tokens, err := tokenizer.Tokenize(text)
+	if err != nil {
+		return nil, fmt.Errorf("unable to tokenize: %w", err)
+	}
+
+	functionNames := make(map[string]struct{}, len(server.functions))
+	for functionName := range server.functions {
+		functionNames[functionName] = struct{}{}
+	}
+
+	commands, err := translator.TranslateWithOptions(tokens, functionNames, translator.Options{Constants: server.constants})
+	if err != nil {
+		return nil, fmt.Errorf("unable to translate: %w", err)
+	}
+
+	return commands, nil
+}
+
+func (server *server) completion(text string) []completionItem {
+	var items []completionItem
+	for name := range server.functions {
+		if _, ok := tokenizer.ParseOperator(name); ok {
+			continue
+		}
+
+		entry, _ := server.registry.Lookup(name)
+		items = append(items, completionItem{
+			Label:         name,
+			Kind:          functionCompletion,
+			Detail:        entry.Signature(),
+			Documentation: &markupContent{Kind: "markdown", Value: describe(entry)},
+		})
+	}
+
+	for name, value := range server.constants {
+		items = append(items, completionItem{Label: name, Kind: constantCompletion, Detail: formatConstant(value)})
+	}
+
+	for _, name := range server.definedVariables(text) {
+		items = append(items, completionItem{Label: name, Kind: variableCompletion, Detail: "variable"})
+	}
+
+	for _, keyword := range []string{"let", "in"} {
+		items = append(items, completionItem{Label: keyword, Kind: keywordCompletion})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Kind != items[j].Kind {
+			return items[i].Kind < items[j].Kind
+		}
+
+		return items[i].Label < items[j].Label
+	})
+	return items
+}
+
+func (server *server) hover(text string, textPosition position) *hover {
+	tokens, err := tokenizer.Tokenize(text)
+	if err != nil {
+		return nil
+	}
+
+	token, ok := identifierAt(tokens, positionToOffset(text, textPosition))
+	if !ok {
+		return nil
+	}
+
+	var contents string
+	if entry, ok := server.registry.Lookup(token.Value); ok {
+		contents = fmt.Sprintf("```\n%s\n```", entry.Signature())
+		if description := describe(entry); description != "" {
+			contents += "\n\n" + description
+		}
+	} else if value, ok := server.constants[token.Value]; ok {
+		contents = fmt.Sprintf("```\n%s = %s\n```\n\nconstant", token.Value, formatConstant(value))
+	} else {
+		contents = fmt.Sprintf("```\n%s\n```\n\nvariable", token.Value)
+	}
+
+	return &hover{
+		Contents: markupContent{Kind: "markdown", Value: contents},
+		Range:    rangeForOffsets(text, token.Position, token.Position+len(token.Value)),
+	}
+}
+
+func (server *server) definition(uri string, textPosition position) []location {
+	text := server.documents[uri]
+	tokens, err := tokenizer.Tokenize(text)
+	if err != nil {
+		return nil
+	}
+
+	offset := positionToOffset(text, textPosition)
+	token, ok := identifierAt(tokens, offset)
+	if !ok {
+		return nil
+	}
+
+	commands, err := server.compile(text)
+	if err != nil {
+		return nil
+	}
+
+	definitionPosition := -1
+	assignmentPositions := map[string]int{}
+	var bindings []translator.Command
+	for _, command := range commands {
+		switch command.Kind {
+		case translator.PushVariableCommand:
+			if command.Position != token.Position {
+				continue
+			}
+
+			if position, ok := assignmentPositions[command.Operand]; ok {
+				definitionPosition = position
+			}
+			for bindingIndex := len(bindings) - 1; bindingIndex >= 0; bindingIndex-- {
+				if bindings[bindingIndex].Operand == command.Operand {
+					definitionPosition = bindings[bindingIndex].Position
+					break
+				}
+			}
+		case translator.AssignVariableCommand:
+			if _, ok := assignmentPositions[command.Operand]; !ok {
+				assignmentPositions[command.Operand] = command.Position
+			}
+			if command.Position == token.Position {
+				definitionPosition = command.Position
+			}
+		case translator.BindVariableCommand:
+			bindings = append(bindings, command)
+			if command.Position == token.Position {
+				definitionPosition = command.Position
+			}
+		case translator.UnbindVariableCommand:
+			bindings = bindings[:len(bindings)-1]
+		}
+	}
+	if definitionPosition == -1 {
+		return []location{}
+	}
+
+	return []location{{
+		URI:   uri,
+		Range: rangeForOffsets(text, definitionPosition, definitionPosition+len(token.Value)),
+	}}
+}
+
+func (server *server) formatting(text string) ([]textEdit, error) {
+	formattedText, err := format.Format(text, server.functions)
+	if err != nil {
+		return nil, &responseError{Code: invalidParamsCode, Message: err.Error()}
+	}
+	if formattedText == "" && strings.TrimSpace(text) != "" {
+		return nil, &responseError{Code: internalErrorCode, Message: "the formatted document is unexpectedly empty"}
+	}
+	if formattedText != "" && strings.HasSuffix(text, "\n") {
+		formattedText += "\n"
+	}
+	if formattedText == text {
+		return []textEdit{}, nil
+	}
+
+	return []textEdit{{Range: rangeForOffsets(text, 0, len(text)), NewText: formattedText}}, nil
+}
+
+func (server *server) definedVariables(text string) []string {
+	commands, err := server.compile(text)
+	if err != nil {
+		return nil
+	}
+
+	isDefined := map[string]bool{}
+	var names []string
+	for _, command := range commands {
+		if command.Kind != translator.AssignVariableCommand && command.Kind != translator.BindVariableCommand {
+			continue
+		}
+
+		if !isDefined[command.Operand] {
+			isDefined[command.Operand] = true
+			names = append(names, command.Operand)
+		}
+	}
+
+	return names
+}
+
+func formatConstant(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func describe(entry registry.Entry) string {
+	description := entry.Description
+	if entry.Deprecated != "" {
+		description += "\n\n**Deprecated:** " + entry.Deprecated
+	}
+
+	return strings.TrimSpace(description)
+}
+
+func (server *server) notify(method string, params interface{}) error {
+	content, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("unable to marshal the parameters: %w", err)
+	}
+
+	return writeMessage(server.writer, message{Method: method, Params: content})
+}
+
+func (server *server) reply(id *json.RawMessage, result interface{}, replyErr *responseError) error {
+	outgoingMessage := message{ID: id, Error: replyErr}
+	if id == nil {
+		null := json.RawMessage("null")
+		outgoingMessage.ID = &null
+	}
+	if replyErr == nil {
+		content, err := json.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("unable to marshal the result: %w", err)
+		}
+
+		outgoingMessage.Result = content
+	}
+
+	return writeMessage(server.writer, outgoingMessage)
+}
+
+func decodeParams(params json.RawMessage, value interface{}) error {
+	if err := json.Unmarshal(params, value); err != nil {
+		return &responseError{Code: invalidParamsCode, Message: fmt.Sprintf("unable to decode the parameters: %s", err)}
+	}
+
+	return nil
+}
@@ -0,0 +1,438 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testURI = "file:///tmp/test.calc"
+)
+
+type testClient struct {
+	t             *testing.T
+	writer        io.WriteCloser
+	reader        *bufio.Reader
+	nextID        int
+	serveErrs     chan error
+	notifications []message
+}
+
+func newTestClient(t *testing.T) *testClient {
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+
+	client := &testClient{
+		t:         t,
+		writer:    clientWriter,
+		reader:    bufio.NewReader(clientReader),
+		serveErrs: make(chan error, 1),
+	}
+	go func() {
+		client.serveErrs <- newServer(serverReader, serverWriter).serve()
+		serverWriter.Close()
+	}()
+	t.Cleanup(func() {
+		clientWriter.Close()
+	})
+
+	return client
+}
+
+func (client *testClient) call(method string, params interface{}) (json.RawMessage, *responseError) {
+	client.t.Helper()
+
+	client.nextID++
+	id := json.RawMessage(strconv.Itoa(client.nextID))
+	client.send(message{ID: &id, Method: method, Params: mustMarshal(client.t, params)})
+
+	for {
+		incomingMessage, err := readMessage(client.reader)
+		if !assert.NoError(client.t, err) {
+			return nil, nil
+		}
+		if incomingMessage.ID == nil {
+			client.notifications = append(client.notifications, incomingMessage)
+			continue
+		}
+
+		assert.Equal(client.t, string(id), string(*incomingMessage.ID))
+		return incomingMessage.Result, incomingMessage.Error
+	}
+}
+
+func (client *testClient) notify(method string, params interface{}) {
+	client.t.Helper()
+
+	client.send(message{Method: method, Params: mustMarshal(client.t, params)})
+}
+
+func (client *testClient) diagnostics(uri string) []diagnostic {
+	client.t.Helper()
+
+	incomingMessage, err := readMessage(client.reader)
+	assert.NoError(client.t, err)
+	assert.Equal(client.t, "textDocument/publishDiagnostics", incomingMessage.Method)
+
+	var params publishDiagnosticsParams
+	assert.NoError(client.t, json.Unmarshal(incomingMessage.Params, &params))
+	assert.Equal(client.t, uri, params.URI)
+
+	return params.Diagnostics
+}
+
+func (client *testClient) send(outgoingMessage message) {
+	client.t.Helper()
+
+	assert.NoError(client.t, writeMessage(client.writer, outgoingMessage))
+}
+
+func (client *testClient) open(text string) {
+	client.t.Helper()
+
+	client.notify("textDocument/didOpen", map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": testURI, "languageId": "calc", "version": 1, "text": text},
+	})
+	client.diagnostics(testURI)
+}
+
+func TestServer_lifecycle(t *testing.T) {
+	client := newTestClient(t)
+
+	result, replyErr := client.call("initialize", map[string]interface{}{"processId": nil, "capabilities": map[string]interface{}{}})
+	assert.Nil(t, replyErr)
+	assert.JSONEq(t, `{
+		"capabilities": {
+			"textDocumentSync": 1,
+			"completionProvider": {},
+			"hoverProvider": true,
+			"definitionProvider": true,
+			"documentFormattingProvider": true
+		},
+		"serverInfo": {"name": "calc-lsp"}
+	}`, string(result))
+	client.notify("initialized", map[string]interface{}{})
+
+	_, replyErr = client.call("workspace/unknown", nil)
+	assert.Equal(t, &responseError{Code: methodNotFoundCode, Message: `unknown method "workspace/unknown"`}, replyErr)
+
+	_, replyErr = client.call("textDocument/hover", []int{1})
+	assert.Equal(t, invalidParamsCode, replyErr.Code)
+
+	result, replyErr = client.call("shutdown", nil)
+	assert.Nil(t, replyErr)
+	assert.Equal(t, "null", string(result))
+
+	client.notify("exit", nil)
+	assert.NoError(t, <-client.serveErrs)
+}
+
+func TestServer_exitBeforeShutdown(t *testing.T) {
+	client := newTestClient(t)
+
+	client.notify("exit", nil)
+
+	assert.Error(t, <-client.serveErrs)
+}
+
+func TestServer_diagnostics(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []diagnostic
+	}{
+		{
+			name: "valid",
+			text: "x = 2\nx * 3\n",
+			want: []diagnostic{},
+		},
+		{
+			name: "empty",
+			text: "",
+			want: []diagnostic{},
+		},
+		{
+			name: "tokenizer error",
+			text: "x = 2\ny = x $ 3",
+			want: []diagnostic{{
+				Range:    textRange{Start: position{Line: 1, Character: 6}, End: position{Line: 1, Character: 7}},
+				Severity: errorSeverity,
+				Source:   diagnosticSource,
+				Message:  "unable to tokenize: unknown character '$' at position 12",
+			}},
+		},
+		{
+			name: "translator error",
+			text: "total = price * qty\nsqrt 2",
+			want: []diagnostic{{
+				Range:    textRange{Start: position{Line: 1, Character: 0}, End: position{Line: 1, Character: 4}},
+				Severity: errorSeverity,
+				Source:   diagnosticSource,
+				Message:  "unable to translate: expected a left parenthesis after the function \"sqrt\" at position 20",
+			}},
+		},
+		{
+			name: "assignment to a constant",
+			text: "r = 2\npi = 3",
+			want: []diagnostic{{
+				Range:    textRange{Start: position{Line: 1, Character: 0}, End: position{Line: 1, Character: 2}},
+				Severity: errorSeverity,
+				Source:   diagnosticSource,
+				Message:  "unable to translate: unable to assign to the constant \"pi\" at position 6",
+			}},
+		},
+		{
+			name: "arity error",
+			text: "max(1)",
+			want: []diagnostic{{
+				Range:    textRange{Start: position{Line: 0, Character: 0}, End: position{Line: 0, Character: 3}},
+				Severity: errorSeverity,
+				Source:   diagnosticSource,
+				Message:  "unable to build the syntax tree: node stack is empty for argument #0 at position 0",
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t)
+			client.notify("textDocument/didOpen", map[string]interface{}{
+				"textDocument": map[string]interface{}{"uri": testURI, "text": tt.text},
+			})
+
+			got := client.diagnostics(testURI)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestServer_didChangeAndDidClose(t *testing.T) {
+	client := newTestClient(t)
+	client.open("1 +")
+
+	client.notify("textDocument/didChange", map[string]interface{}{
+		"textDocument":   map[string]interface{}{"uri": testURI, "version": 2},
+		"contentChanges": []map[string]interface{}{{"text": "1 + 2"}},
+	})
+	assert.Equal(t, []diagnostic{}, client.diagnostics(testURI))
+
+	client.notify("textDocument/didClose", map[string]interface{}{"textDocument": map[string]interface{}{"uri": testURI}})
+	assert.Equal(t, []diagnostic{}, client.diagnostics(testURI))
+}
+
+func TestServer_completion(t *testing.T) {
+	client := newTestClient(t)
+	client.open("rate = 0.2\nlet base = 10 in base * rate")
+
+	result, replyErr := client.call("textDocument/completion", textDocumentPositionParams{
+		TextDocument: textDocumentIdentifier{URI: testURI},
+		Position:     position{Line: 1, Character: 0},
+	})
+
+	var got []completionItem
+	assert.Nil(t, replyErr)
+	assert.NoError(t, json.Unmarshal(result, &got))
+	assert.Contains(t, got, completionItem{
+		Label:         "atan2",
+		Kind:          functionCompletion,
+		Detail:        "atan2(y, x)",
+		Documentation: &markupContent{Kind: "markdown", Value: "Returns the arctangent of y/x using the signs of both to pick the quadrant."},
+	})
+	assert.Contains(t, got, completionItem{Label: "rate", Kind: variableCompletion, Detail: "variable"})
+	assert.Contains(t, got, completionItem{Label: "base", Kind: variableCompletion, Detail: "variable"})
+	assert.Contains(t, got, completionItem{Label: "let", Kind: keywordCompletion})
+	assert.Contains(t, got, completionItem{Label: "pi", Kind: constantCompletion, Detail: "3.141592653589793"})
+	for _, item := range got {
+		assert.NotEqual(t, "+", item.Label)
+	}
+}
+
+func TestServer_hover(t *testing.T) {
+	tests := []struct {
+		name         string
+		textPosition position
+		want         string
+	}{
+		{
+			name:         "function",
+			textPosition: position{Line: 1, Character: 2},
+			want: `{
+				"contents": {"kind": "markdown", "value": "` + "```\\nmax(x, y)\\n```\\n\\nReturns the larger of x and y." + `"},
+				"range": {"start": {"line": 1, "character": 0}, "end": {"line": 1, "character": 3}}
+			}`,
+		},
+		{
+			name:         "variable",
+			textPosition: position{Line: 1, Character: 5},
+			want: `{
+				"contents": {"kind": "markdown", "value": "` + "```\\nrate\\n```\\n\\nvariable" + `"},
+				"range": {"start": {"line": 1, "character": 4}, "end": {"line": 1, "character": 8}}
+			}`,
+		},
+		{
+			name:         "constant",
+			textPosition: position{Line: 1, Character: 10},
+			want: `{
+				"contents": {"kind": "markdown", "value": "` + "```\\npi = 3.141592653589793\\n```\\n\\nconstant" + `"},
+				"range": {"start": {"line": 1, "character": 10}, "end": {"line": 1, "character": 12}}
+			}`,
+		},
+		{
+			name:         "nothing",
+			textPosition: position{Line: 0, Character: 6},
+			want:         "null",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t)
+			client.open("rate = 0.2\nmax(rate, pi)")
+
+			got, replyErr := client.call("textDocument/hover", textDocumentPositionParams{
+				TextDocument: textDocumentIdentifier{URI: testURI},
+				Position:     tt.textPosition,
+			})
+
+			assert.Nil(t, replyErr)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestServer_definition(t *testing.T) {
+	text := "rate = 0.2\nrate = rate * 2\nlet rate = 1 in rate + (let x = rate in x)\nrate + other"
+
+	tests := []struct {
+		name         string
+		textPosition position
+		want         []location
+	}{
+		{
+			name:         "assignment",
+			textPosition: position{Line: 1, Character: 8},
+			want:         []location{{URI: testURI, Range: textRange{Start: position{Line: 0, Character: 0}, End: position{Line: 0, Character: 4}}}},
+		},
+		{
+			name:         "assignment target",
+			textPosition: position{Line: 0, Character: 1},
+			want:         []location{{URI: testURI, Range: textRange{Start: position{Line: 0, Character: 0}, End: position{Line: 0, Character: 4}}}},
+		},
+		{
+			name:         "let binding",
+			textPosition: position{Line: 2, Character: 17},
+			want:         []location{{URI: testURI, Range: textRange{Start: position{Line: 2, Character: 4}, End: position{Line: 2, Character: 8}}}},
+		},
+		{
+			name:         "nested let binding",
+			textPosition: position{Line: 2, Character: 40},
+			want:         []location{{URI: testURI, Range: textRange{Start: position{Line: 2, Character: 28}, End: position{Line: 2, Character: 29}}}},
+		},
+		{
+			name:         "let value",
+			textPosition: position{Line: 2, Character: 33},
+			want:         []location{{URI: testURI, Range: textRange{Start: position{Line: 2, Character: 4}, End: position{Line: 2, Character: 8}}}},
+		},
+		{
+			name:         "after the let",
+			textPosition: position{Line: 3, Character: 0},
+			want:         []location{{URI: testURI, Range: textRange{Start: position{Line: 0, Character: 0}, End: position{Line: 0, Character: 4}}}},
+		},
+		{
+			name:         "undefined variable",
+			textPosition: position{Line: 3, Character: 8},
+			want:         []location{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t)
+			client.open(text)
+
+			result, replyErr := client.call("textDocument/definition", textDocumentPositionParams{
+				TextDocument: textDocumentIdentifier{URI: testURI},
+				Position:     tt.textPosition,
+			})
+
+			var got []location
+			assert.Nil(t, replyErr)
+			assert.NoError(t, json.Unmarshal(result, &got))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestServer_formatting(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		want        []textEdit
+		wantErrCode int
+	}{
+		{
+			name: "unformatted",
+			text: "x=1;  y = (x+2)*3 # comment\n",
+			want: []textEdit{{
+				Range:   textRange{Start: position{Line: 0, Character: 0}, End: position{Line: 1, Character: 0}},
+				NewText: "x = 1\ny = (x + 2)*3 # comment\n",
+			}},
+		},
+		{
+			name: "formatted",
+			text: "x = 1\n",
+			want: []textEdit{},
+		},
+		{
+			name: "only a comment",
+			text: "# note",
+			want: []textEdit{},
+		},
+		{
+			name: "only comments",
+			text: "#  note\n\n/* more */",
+			want: []textEdit{{
+				Range:   textRange{Start: position{Line: 0, Character: 0}, End: position{Line: 2, Character: 10}},
+				NewText: "#  note\n/* more */",
+			}},
+		},
+		{
+			name:        "invalid",
+			text:        "x = (1",
+			want:        nil,
+			wantErrCode: invalidParamsCode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t)
+			client.open(tt.text)
+
+			result, replyErr := client.call("textDocument/formatting", formattingParams{
+				TextDocument: textDocumentIdentifier{URI: testURI},
+			})
+
+			if tt.wantErrCode != 0 {
+				assert.Equal(t, tt.wantErrCode, replyErr.Code)
+				return
+			}
+
+			var got []textEdit
+			assert.Nil(t, replyErr)
+			assert.NoError(t, json.Unmarshal(result, &got))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func mustMarshal(t *testing.T, value interface{}) json.RawMessage {
+	t.Helper()
+
+	content, err := json.Marshal(value)
+	assert.NoError(t, err)
+
+	return content
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/rmaidveo/go-calculator/translator"
+)
+
+type cacheEntry struct {
+	expression string
+	commands   []translator.Command
+}
+
+type expressionCache struct {
+	mutex    sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+}
+
+func newExpressionCache(capacity int) *expressionCache {
+	return &expressionCache{
+		capacity: capacity,
+		entries:  map[string]*list.Element{},
+		order:    list.New(),
+	}
+}
+
+func (cache *expressionCache) get(expression string) ([]translator.Command, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	element, ok := cache.entries[expression]
+	if !ok {
+		return nil, false
+	}
+
+	cache.order.MoveToFront(element)
+	return element.Value.(cacheEntry).commands, true
+}
+
+func (cache *expressionCache) put(expression string, commands []translator.Command) {
+	if cache.capacity <= 0 {
+		return
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if element, ok := cache.entries[expression]; ok {
+		element.Value = cacheEntry{expression: expression, commands: commands}
+		cache.order.MoveToFront(element)
+		return
+	}
+
+	cache.entries[expression] = cache.order.PushFront(cacheEntry{expression: expression, commands: commands})
+	if cache.order.Len() > cache.capacity {
+		oldestElement := cache.order.Back()
+		cache.order.Remove(oldestElement)
+		delete(cache.entries, oldestElement.Value.(cacheEntry).expression)
+	}
+}
+
+func (cache *expressionCache) len() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	return cache.order.Len()
+}
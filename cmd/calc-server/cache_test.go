@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/rmaidveo/go-calculator/translator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpressionCache(t *testing.T) {
+	cache := newExpressionCache(2)
+	first := []translator.Command{{Kind: translator.PushNumberCommand, Operand: "1"}}
+	second := []translator.Command{{Kind: translator.PushNumberCommand, Operand: "2"}}
+	third := []translator.Command{{Kind: translator.PushNumberCommand, Operand: "3"}}
+
+	cache.put("1", first)
+	cache.put("2", second)
+	got, ok := cache.get("1")
+	assert.True(t, ok)
+	assert.Equal(t, first, got)
+
+	cache.put("3", third)
+	_, ok = cache.get("2")
+	assert.False(t, ok)
+	got, ok = cache.get("3")
+	assert.True(t, ok)
+	assert.Equal(t, third, got)
+	assert.Equal(t, 2, cache.len())
+
+	cache.put("3", first)
+	got, ok = cache.get("3")
+	assert.True(t, ok)
+	assert.Equal(t, first, got)
+	assert.Equal(t, 2, cache.len())
+}
+
+func TestExpressionCache_disabled(t *testing.T) {
+	cache := newExpressionCache(0)
+	cache.put("1", []translator.Command{{Kind: translator.PushNumberCommand, Operand: "1"}})
+
+	_, ok := cache.get("1")
+
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.len())
+}
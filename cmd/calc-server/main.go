@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(arguments []string) error {
+	httpServer, err := newHTTPServer(arguments)
+	if err != nil {
+		return err
+	}
+
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("unable to serve: %w", err)
+	}
+
+	return nil
+}
+
+func newHTTPServer(arguments []string) (*http.Server, error) {
+	flags := flag.NewFlagSet("calc-server", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	address := flags.String("addr", ":8080", "address to listen on")
+	maxBodySize := flags.Int64("max-body-size", 1<<16, "maximal size of a request body in bytes")
+	timeout := flags.Duration("timeout", time.Second, "maximal duration of an evaluation")
+	cacheSize := flags.Int("cache-size", 1024, "number of compiled expressions to cache; 0 disables the cache")
+	if err := flags.Parse(arguments); err != nil {
+		return nil, fmt.Errorf("unable to parse the flags: %w", err)
+	}
+	if flags.NArg() != 0 {
+		return nil, errors.New("usage: calc-server [-addr address] [-max-body-size bytes] [-timeout duration] [-cache-size count]")
+	}
+	if *maxBodySize <= 0 || *timeout <= 0 || *cacheSize < 0 {
+		return nil, errors.New("body size and timeout must be positive and the cache size must not be negative")
+	}
+
+	server := newServer(serverOptions{maxBodySize: *maxBodySize, timeout: *timeout, cacheSize: *cacheSize})
+	return &http.Server{
+		Addr:              *address,
+		Handler:           server.handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      *timeout + 10*time.Second,
+	}, nil
+}
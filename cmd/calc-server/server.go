@@ -0,0 +1,350 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	calculator "github.com/rmaidveo/go-calculator"
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/rmaidveo/go-calculator/constants"
+	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/rmaidveo/go-calculator/graph"
+	"github.com/rmaidveo/go-calculator/registry"
+	"github.com/rmaidveo/go-calculator/tokenizer"
+	"github.com/rmaidveo/go-calculator/translator"
+)
+
+var (
+	positionPattern = regexp.MustCompile(`at position (\d+)`)
+)
+
+type serverOptions struct {
+	maxBodySize int64
+	timeout     time.Duration
+	cacheSize   int
+}
+
+type server struct {
+	options   serverOptions
+	functions map[string]evaluator.Function
+	registry  *registry.Registry
+	constants map[string]float64
+	cache     *expressionCache
+}
+
+type expressionRequest struct {
+	Expression string             `json:"expression"`
+	Variables  map[string]float64 `json:"variables,omitempty"`
+}
+
+type errorResponse struct {
+	Message  string `json:"message"`
+	Position *int   `json:"position,omitempty"`
+}
+
+type evaluateResponse struct {
+	Result    *float64           `json:"result,omitempty"`
+	Variables map[string]float64 `json:"variables,omitempty"`
+	Error     *errorResponse     `json:"error,omitempty"`
+}
+
+type validateResponse struct {
+	Valid bool           `json:"valid"`
+	Error *errorResponse `json:"error,omitempty"`
+}
+
+type analyzeResponse struct {
+	Variables []string       `json:"variables"`
+	Assigned  []string       `json:"assigned"`
+	Functions []string       `json:"functions"`
+	Error     *errorResponse `json:"error,omitempty"`
+}
+
+type functionResponse struct {
+	Name        string            `json:"name"`
+	Aliases     []string          `json:"aliases"`
+	Arity       int               `json:"arity"`
+	Parameters  []string          `json:"parameters"`
+	Signature   string            `json:"signature"`
+	Description string            `json:"description"`
+	Category    string            `json:"category"`
+	Examples    []exampleResponse `json:"examples"`
+	Deprecated  string            `json:"deprecated,omitempty"`
+	Operator    bool              `json:"operator"`
+}
+
+type exampleResponse struct {
+	Expression string  `json:"expression"`
+	Result     float64 `json:"result"`
+}
+
+func newServer(options serverOptions) *server {
+	functionRegistry := builtin.Registry()
+	return &server{
+		options:   options,
+		functions: functionRegistry.Functions(),
+		registry:  functionRegistry,
+		constants: constants.Mathematical(),
+		cache:     newExpressionCache(options.cacheSize),
+	}
+}
+
+func (server *server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/evaluate", server.allowMethod(http.MethodPost, server.handleEvaluate))
+	mux.HandleFunc("/validate", server.allowMethod(http.MethodPost, server.handleValidate))
+	mux.HandleFunc("/analyze", server.allowMethod(http.MethodPost, server.handleAnalyze))
+	mux.HandleFunc("/functions", server.allowMethod(http.MethodGet, server.handleFunctions))
+	return mux
+}
+
+func (server *server) allowMethod(method string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != method {
+			writer.Header().Set("Allow", method)
+			writeJSON(writer, http.StatusMethodNotAllowed, errorResponse{Message: "method is not allowed"})
+			return
+		}
+
+		handler(writer, request)
+	}
+}
+
+func (server *server) handleEvaluate(writer http.ResponseWriter, request *http.Request) {
+	expressionRequest, ok := server.decodeRequest(writer, request)
+	if !ok {
+		return
+	}
+
+	if err := constants.CheckVariables(server.constants, expressionRequest.Variables); err != nil {
+		writeJSON(writer, http.StatusUnprocessableEntity, evaluateResponse{Error: newErrorResponse(err)})
+		return
+	}
+
+	commands, err := server.compile(expressionRequest.Expression)
+	if err != nil {
+		writeJSON(writer, http.StatusUnprocessableEntity, evaluateResponse{Error: newErrorResponse(err)})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(request.Context(), server.options.timeout)
+	defer cancel()
+
+	type evaluation struct {
+		result    float64
+		variables map[string]float64
+		err       error
+	}
+
+	evaluations := make(chan evaluation, 1)
+	go func() {
+		result, variables, err := evaluator.EvaluateWithVariables(commands, expressionRequest.Variables, server.functions)
+		evaluations <- evaluation{result: result, variables: variables, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		writeJSON(writer, http.StatusServiceUnavailable, evaluateResponse{
+			Error: &errorResponse{Message: fmt.Sprintf("evaluation is not finished in %s", server.options.timeout)},
+		})
+	case evaluation := <-evaluations:
+		if evaluation.err != nil {
+			writeJSON(writer, http.StatusUnprocessableEntity, evaluateResponse{
+				Error: newErrorResponse(fmt.Errorf("unable to evaluate: %w", evaluation.err)),
+			})
+			return
+		}
+
+		if err := checkFinite(evaluation.result, evaluation.variables); err != nil {
+			writeJSON(writer, http.StatusUnprocessableEntity, evaluateResponse{Error: newErrorResponse(err)})
+			return
+		}
+
+		writeJSON(writer, http.StatusOK, evaluateResponse{Result: &evaluation.result, Variables: evaluation.variables})
+	}
+}
+
+func (server *server) handleValidate(writer http.ResponseWriter, request *http.Request) {
+	expressionRequest, ok := server.decodeRequest(writer, request)
+	if !ok {
+		return
+	}
+
+	if _, err := server.compile(expressionRequest.Expression); err != nil {
+		writeJSON(writer, http.StatusOK, validateResponse{Valid: false, Error: newErrorResponse(err)})
+		return
+	}
+
+	writeJSON(writer, http.StatusOK, validateResponse{Valid: true})
+}
+
+func (server *server) handleAnalyze(writer http.ResponseWriter, request *http.Request) {
+	expressionRequest, ok := server.decodeRequest(writer, request)
+	if !ok {
+		return
+	}
+
+	commands, err := server.compile(expressionRequest.Expression)
+	if err != nil {
+		writeJSON(writer, http.StatusUnprocessableEntity, analyzeResponse{
+			Variables: []string{},
+			Assigned:  []string{},
+			Functions: []string{},
+			Error:     newErrorResponse(err),
+		})
+		return
+	}
+
+	assigned := map[string]struct{}{}
+	functions := map[string]struct{}{}
+	for _, command := range commands {
+		switch command.Kind {
+		case translator.AssignVariableCommand:
+			assigned[command.Operand] = struct{}{}
+		case translator.CallFunctionCommand:
+			if _, ok := tokenizer.ParseOperator(command.Operand); !ok {
+				functions[command.Operand] = struct{}{}
+			}
+		}
+	}
+
+	variables := graph.Dependencies(commands)
+	if variables == nil {
+		variables = []string{}
+	}
+
+	writeJSON(writer, http.StatusOK, analyzeResponse{
+		Variables: variables,
+		Assigned:  sortedKeys(assigned),
+		Functions: sortedKeys(functions),
+	})
+}
+
+func (server *server) handleFunctions(writer http.ResponseWriter, request *http.Request) {
+	entries := server.registry.Entries()
+	functions := make([]functionResponse, 0, len(entries))
+	for _, entry := range entries {
+		_, isOperator := tokenizer.ParseOperator(entry.Name)
+		examples := make([]exampleResponse, 0, len(entry.Examples))
+		for _, example := range entry.Examples {
+			examples = append(examples, exampleResponse{Expression: example.Expression, Result: example.Result})
+		}
+
+		aliases := entry.Aliases
+		if aliases == nil {
+			aliases = []string{}
+		}
+
+		functions = append(functions, functionResponse{
+			Name:        entry.Name,
+			Aliases:     aliases,
+			Arity:       entry.Function.Arity,
+			Parameters:  entry.Parameters,
+			Signature:   entry.Signature(),
+			Description: entry.Description,
+			Category:    entry.Category,
+			Examples:    examples,
+			Deprecated:  entry.Deprecated,
+			Operator:    isOperator,
+		})
+	}
+
+	writeJSON(writer, http.StatusOK, functions)
+}
+
+func (server *server) decodeRequest(writer http.ResponseWriter, request *http.Request) (expressionRequest, bool) {
+	request.Body = http.MaxBytesReader(writer, request.Body, server.options.maxBodySize)
+	decoder := json.NewDecoder(request.Body)
+	decoder.DisallowUnknownFields()
+
+	var expressionRequest expressionRequest
+	if err := decoder.Decode(&expressionRequest); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeJSON(writer, http.StatusRequestEntityTooLarge, errorResponse{
+				Message: fmt.Sprintf("request body is larger than %d bytes", maxBytesErr.Limit),
+			})
+			return expressionRequest, false
+		}
+
+		writeJSON(writer, http.StatusBadRequest, errorResponse{Message: fmt.Sprintf("unable to decode the request: %s", err)})
+		return expressionRequest, false
+	}
+
+	return expressionRequest, true
+}
+
+func (server *server) compile(expression string) ([]translator.Command, error) {
+	if commands, ok := server.cache.get(expression); ok {
+		return commands, nil
+	}
+
+	commands, err := calculator.CompileWithOptions(expression, server.functions, calculator.Options{Constants: server.constants})
+	if err != nil {
+		return nil, err
+	}
+
+	server.cache.put(expression, commands)
+	return commands, nil
+}
+
+func newErrorResponse(err error) *errorResponse {
+	response := &errorResponse{Message: err.Error()}
+	if matches := positionPattern.FindAllStringSubmatch(response.Message, -1); len(matches) != 0 {
+		if position, err := strconv.Atoi(matches[len(matches)-1][1]); err == nil {
+			response.Position = &position
+		}
+	}
+
+	return response
+}
+
+func checkFinite(result float64, variables map[string]float64) error {
+	if math.IsInf(result, 0) || math.IsNaN(result) {
+		return fmt.Errorf("result %g is not a finite number", result)
+	}
+
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if value := variables[name]; math.IsInf(value, 0) || math.IsNaN(value) {
+			return fmt.Errorf("variable %q is %g, which is not a finite number", name, value)
+		}
+	}
+
+	return nil
+}
+
+func writeJSON(writer http.ResponseWriter, status int, value interface{}) {
+	body, err := json.Marshal(value)
+	if err != nil {
+		status = http.StatusInternalServerError
+		body, _ = json.Marshal(errorResponse{Message: fmt.Sprintf("unable to encode the response: %s", err)})
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	_, _ = writer.Write(append(body, '\n'))
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer(t *testing.T) {
+	type args struct {
+		method string
+		path   string
+		body   string
+	}
+
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "success/evaluate",
+			args:       args{method: http.MethodPost, path: "/evaluate", body: `{"expression": "x * 2 + 1", "variables": {"x": 3}}`},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"result": 7, "variables": {"x": 3}}`,
+		},
+		{
+			name:       "success/evaluate with assignment",
+			args:       args{method: http.MethodPost, path: "/evaluate", body: `{"expression": "y = 2; y ^ 3"}`},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"result": 8, "variables": {"y": 2}}`,
+		},
+		{
+			name:       "success/evaluate with constants",
+			args:       args{method: http.MethodPost, path: "/evaluate", body: `{"expression": "cos(pi) * e / e"}`},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"result": -1}`,
+		},
+		{
+			name:       "error/evaluate with a variable named as a constant",
+			args:       args{method: http.MethodPost, path: "/evaluate", body: `{"expression": "e", "variables": {"e": 5}}`},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"error": {"message": "variable \"e\" has the name of a constant"}}`,
+		},
+		{
+			name:       "error/evaluate with an assignment to a constant",
+			args:       args{method: http.MethodPost, path: "/evaluate", body: `{"expression": "pi = 3"}`},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"error": {"message": "unable to translate: unable to assign to the constant \"pi\" at position 0", "position": 0}}`,
+		},
+		{
+			name:       "error/evaluate with a syntax error",
+			args:       args{method: http.MethodPost, path: "/evaluate", body: `{"expression": "2 + (3"}`},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"error": {"message": "unable to translate: unexpected left parenthesis is found at position 4", "position": 4}}`,
+		},
+		{
+			name:       "error/evaluate with an evaluation error",
+			args:       args{method: http.MethodPost, path: "/evaluate", body: `{"expression": "1 / x", "variables": {"x": 0}}`},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody: `{"error": {
+				"message": "unable to evaluate: unable to call the function \"/\" at position 2: division by zero",
+				"position": 2
+			}}`,
+		},
+		{
+			name:       "error/evaluate with an infinite result",
+			args:       args{method: http.MethodPost, path: "/evaluate", body: `{"expression": "10^400"}`},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"error": {"message": "result +Inf is not a finite number"}}`,
+		},
+		{
+			name:       "error/evaluate with an overflowing variable",
+			args:       args{method: http.MethodPost, path: "/evaluate", body: `{"expression": "x * 2", "variables": {"x": 1e308}}`},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"error": {"message": "result +Inf is not a finite number"}}`,
+		},
+		{
+			name:       "error/evaluate with an infinite assignment",
+			args:       args{method: http.MethodPost, path: "/evaluate", body: `{"expression": "y = -10^400; 1"}`},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"error": {"message": "variable \"y\" is -Inf, which is not a finite number"}}`,
+		},
+		{
+			name:       "error/evaluate with an unknown variable",
+			args:       args{method: http.MethodPost, path: "/evaluate", body: `{"expression": "x + 1"}`},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"error": {"message": "unable to evaluate: unknown variable \"x\" at position 0", "position": 0}}`,
+		},
+		{
+			name:       "error/evaluate with an unknown field",
+			args:       args{method: http.MethodPost, path: "/evaluate", body: `{"expr": "1"}`},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"message": "unable to decode the request: json: unknown field \"expr\""}`,
+		},
+		{
+			name:       "error/evaluate with a too large body",
+			args:       args{method: http.MethodPost, path: "/evaluate", body: `{"expression": "` + strings.Repeat("1+", 100) + `1"}`},
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantBody:   `{"message": "request body is larger than 128 bytes"}`,
+		},
+		{
+			name:       "error/evaluate with a wrong method",
+			args:       args{method: http.MethodGet, path: "/evaluate"},
+			wantStatus: http.StatusMethodNotAllowed,
+			wantBody:   `{"message": "method is not allowed"}`,
+		},
+		{
+			name:       "success/validate",
+			args:       args{method: http.MethodPost, path: "/validate", body: `{"expression": "sin(x) + 1"}`},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"valid": true}`,
+		},
+		{
+			name:       "success/validate an invalid expression",
+			args:       args{method: http.MethodPost, path: "/validate", body: `{"expression": "1 $ 2"}`},
+			wantStatus: http.StatusOK,
+			wantBody: `{"valid": false, "error": {
+				"message": "unable to tokenize: unknown character '$' at position 2",
+				"position": 2
+			}}`,
+		},
+		{
+			name:       "success/analyze",
+			args:       args{method: http.MethodPost, path: "/analyze", body: `{"expression": "total = price * qty * pi; max(total, let x = 1 in x + limit)"}`},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"variables": ["limit", "price", "qty"], "assigned": ["total"], "functions": ["max"]}`,
+		},
+		{
+			name:       "error/analyze an invalid expression",
+			args:       args{method: http.MethodPost, path: "/analyze", body: `{"expression": "sin 1"}`},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody: `{"variables": [], "assigned": [], "functions": [], "error": {
+				"message": "unable to translate: expected a left parenthesis after the function \"sin\" at position 0",
+				"position": 0
+			}}`,
+		},
+		{
+			name:       "error/unknown path",
+			args:       args{method: http.MethodGet, path: "/unknown"},
+			wantStatus: http.StatusNotFound,
+			wantBody:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newServer(serverOptions{maxBodySize: 128, timeout: time.Second, cacheSize: 16})
+			request := httptest.NewRequest(tt.args.method, tt.args.path, strings.NewReader(tt.args.body))
+			recorder := httptest.NewRecorder()
+
+			server.handler().ServeHTTP(recorder, request)
+
+			assert.Equal(t, tt.wantStatus, recorder.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+				assert.JSONEq(t, tt.wantBody, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestServer_functions(t *testing.T) {
+	server := newServer(serverOptions{maxBodySize: 128, timeout: time.Second})
+	request := httptest.NewRequest(http.MethodGet, "/functions", nil)
+	recorder := httptest.NewRecorder()
+
+	server.handler().ServeHTTP(recorder, request)
+
+	var got []functionResponse
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	assert.Len(t, got, len(server.functions))
+	assert.Contains(t, got, functionResponse{
+		Name:        "atan2",
+		Aliases:     []string{},
+		Arity:       2,
+		Parameters:  []string{"y", "x"},
+		Signature:   "atan2(y, x)",
+		Description: "Returns the arctangent of y/x using the signs of both to pick the quadrant.",
+		Category:    "trigonometric",
+		Examples:    []exampleResponse{{Expression: "atan2(0, 1)", Result: 0}},
+		Operator:    false,
+	})
+	assert.Contains(t, got, functionResponse{
+		Name:        "+",
+		Aliases:     []string{},
+		Arity:       2,
+		Parameters:  []string{"x", "y"},
+		Signature:   "+(x, y)",
+		Description: "Adds y to x.",
+		Category:    "operators",
+		Examples:    []exampleResponse{{Expression: "2 + 3", Result: 5}},
+		Operator:    true,
+	})
+}
+
+func TestServer_timeout(t *testing.T) {
+	server := newServer(serverOptions{maxBodySize: 128, timeout: 10 * time.Millisecond})
+	release := make(chan struct{})
+	defer close(release)
+	server.functions["wait"] = evaluator.Function{
+		Arity: 0,
+		Handler: func(arguments []float64) (float64, error) {
+			<-release
+			return 0, nil
+		},
+	}
+
+	request := httptest.NewRequest(http.MethodPost, "/evaluate", strings.NewReader(`{"expression": "wait()"}`))
+	recorder := httptest.NewRecorder()
+
+	server.handler().ServeHTTP(recorder, request)
+
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.JSONEq(t, `{"error": {"message": "evaluation is not finished in 10ms"}}`, recorder.Body.String())
+}
+
+func TestServer_cache(t *testing.T) {
+	server := newServer(serverOptions{maxBodySize: 128, timeout: time.Second, cacheSize: 16})
+	for iteration := 0; iteration < 3; iteration++ {
+		request := httptest.NewRequest(http.MethodPost, "/evaluate", strings.NewReader(`{"expression": "2 + 2"}`))
+		recorder := httptest.NewRecorder()
+
+		server.handler().ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.JSONEq(t, `{"result": 4}`, recorder.Body.String())
+	}
+
+	assert.Equal(t, 1, server.cache.len())
+}
+
+func TestWriteJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      interface{}
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "success", value: evaluateResponse{Variables: map[string]float64{"x": 1}}, wantStatus: http.StatusOK, wantBody: `{"variables": {"x": 1}}`},
+		{
+			name:       "error/unsupported value",
+			value:      map[string]float64{"x": math.Inf(1)},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `{"message": "unable to encode the response: json: unsupported value: +Inf"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+
+			writeJSON(recorder, http.StatusOK, tt.value)
+
+			assert.Equal(t, tt.wantStatus, recorder.Code)
+			assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+			assert.JSONEq(t, tt.wantBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestNewHTTPServer(t *testing.T) {
+	tests := []struct {
+		name        string
+		arguments   []string
+		wantAddress string
+		wantErr     assert.ErrorAssertionFunc
+	}{
+		{name: "success/defaults", arguments: nil, wantAddress: ":8080", wantErr: assert.NoError},
+		{name: "success/address", arguments: []string{"-addr", "localhost:9000"}, wantAddress: "localhost:9000", wantErr: assert.NoError},
+		{name: "error/unknown flag", arguments: []string{"-unknown"}, wantAddress: "", wantErr: assert.Error},
+		{name: "error/extra argument", arguments: []string{"extra"}, wantAddress: "", wantErr: assert.Error},
+		{name: "error/zero timeout", arguments: []string{"-timeout", "0s"}, wantAddress: "", wantErr: assert.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newHTTPServer(tt.arguments)
+
+			if got != nil {
+				assert.Equal(t, tt.wantAddress, got.Addr)
+			} else {
+				assert.Equal(t, tt.wantAddress, "")
+			}
+			tt.wantErr(t, err)
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	calculator "github.com/rmaidveo/go-calculator"
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/rmaidveo/go-calculator/constants"
+	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/rmaidveo/go-calculator/tokenizer"
+	"github.com/rmaidveo/go-calculator/translator"
+)
+
+const (
+	debugPrompt = "(calc) "
+	debugHelp   = `commands:
+  step, s, <empty line>  execute the next command
+  continue, c            run until a breakpoint or the end
+  break, b <name>        stop before a function call or a variable use
+  clear <name>           remove the breakpoint
+  stack                  print the number stack
+  list, l                print all commands
+  help, h                print this help
+  quit, q                stop debugging`
+)
+
+type debugger struct {
+	text        string
+	commands    []translator.Command
+	input       *bufio.Scanner
+	output      io.Writer
+	next        int
+	stack       []float64
+	breakpoints map[string]struct{}
+	isStepping  bool
+	isQuitting  bool
+}
+
+func runDebug(arguments []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("debug", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	variables := variablesFlag{}
+	flags.Var(variables, "var", "set a variable as name=value; can be repeated")
+	if err := flags.Parse(arguments); err != nil {
+		return fmt.Errorf("unable to parse the flags: %w", err)
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: calc debug [-var name=value]... <expression>")
+	}
+
+	text := flags.Arg(0)
+	mathematicalConstants := constants.Mathematical()
+	if err := constants.CheckVariables(mathematicalConstants, variables); err != nil {
+		return err
+	}
+
+	functions := builtin.Functions()
+	commands, err := calculator.CompileWithOptions(text, functions, calculator.Options{Constants: mathematicalConstants})
+	if err != nil {
+		return err
+	}
+
+	debugger := &debugger{
+		text:        text,
+		commands:    commands,
+		input:       bufio.NewScanner(stdin),
+		output:      stdout,
+		breakpoints: map[string]struct{}{},
+		isStepping:  true,
+	}
+	debugger.pause()
+
+	result, _, err := evaluator.EvaluateWithTracer(commands, variables, functions, debugger.trace)
+	if err != nil {
+		return fmt.Errorf("unable to evaluate: %w", err)
+	}
+	if debugger.isQuitting {
+		return nil
+	}
+
+	fmt.Fprintf(debugger.output, "result: %g\n", result)
+	return nil
+}
+
+func (debugger *debugger) trace(event evaluator.TraceEvent) {
+	if debugger.isQuitting {
+		return
+	}
+
+	debugger.next++
+	debugger.stack = event.StackAfter
+	if debugger.isStepping {
+		fmt.Fprintf(debugger.output, "stack: %v\n", debugger.stack)
+	}
+
+	debugger.pause()
+}
+
+func (debugger *debugger) pause() {
+	if debugger.isQuitting || debugger.next >= len(debugger.commands) {
+		return
+	}
+
+	command := debugger.commands[debugger.next]
+	if !debugger.isStepping {
+		if !debugger.isBreakpoint(command) {
+			return
+		}
+
+		fmt.Fprintf(debugger.output, "breakpoint on %q\n", command.Operand)
+		debugger.isStepping = true
+	}
+
+	fmt.Fprintf(debugger.output, "#%d %s\n%s", debugger.next, command, highlight(debugger.text, command))
+	for {
+		fmt.Fprint(debugger.output, debugPrompt)
+		if !debugger.input.Scan() {
+			fmt.Fprintln(debugger.output)
+			debugger.isQuitting = true
+			return
+		}
+
+		name, argument, _ := strings.Cut(strings.TrimSpace(debugger.input.Text()), " ")
+		argument = strings.TrimSpace(argument)
+		switch name {
+		case "", "step", "s":
+			return
+		case "continue", "c":
+			debugger.isStepping = false
+			return
+		case "break", "b":
+			if argument == "" {
+				fmt.Fprintln(debugger.output, "breakpoint requires a function or a variable name")
+				continue
+			}
+
+			debugger.breakpoints[argument] = struct{}{}
+		case "clear":
+			delete(debugger.breakpoints, argument)
+		case "stack":
+			fmt.Fprintf(debugger.output, "stack: %v\n", debugger.stack)
+		case "list", "l":
+			for commandIndex, command := range debugger.commands {
+				marker := " "
+				if commandIndex == debugger.next {
+					marker = ">"
+				}
+
+				fmt.Fprintf(debugger.output, "%s #%d %s\n", marker, commandIndex, command)
+			}
+		case "help", "h":
+			fmt.Fprintln(debugger.output, debugHelp)
+		case "quit", "q":
+			debugger.isQuitting = true
+			return
+		default:
+			fmt.Fprintf(debugger.output, "unknown command %q, type help for the list of commands\n", name)
+		}
+	}
+}
+
+func (debugger *debugger) isBreakpoint(command translator.Command) bool {
+	switch command.Kind {
+	case translator.CallFunctionCommand,
+		translator.PushVariableCommand,
+		translator.AssignVariableCommand,
+		translator.BindVariableCommand:
+		_, ok := debugger.breakpoints[command.Operand]
+		return ok
+	default:
+		return false
+	}
+}
+
+func highlight(text string, command translator.Command) string {
+	lineStart := strings.LastIndexByte(text[:command.Position], '\n') + 1
+	lineEnd := strings.IndexByte(text[command.Position:], '\n')
+	if lineEnd == -1 {
+		lineEnd = len(text)
+	} else {
+		lineEnd += command.Position
+	}
+
+	spanLength := utf8.RuneCountInString(command.Operand)
+	if command.Kind == translator.PushNumberCommand && !strings.HasPrefix(text[command.Position:], command.Operand) {
+		constantName := text[command.Position:]
+		if constantEnd := strings.IndexFunc(constantName, isNotIdentifierCharacter); constantEnd != -1 {
+			constantName = constantName[:constantEnd]
+		}
+
+		spanLength = utf8.RuneCountInString(constantName)
+	}
+	if _, ok := tokenizer.ParseOperator(command.Operand); ok || spanLength == 0 {
+		spanLength = 1
+	}
+
+	line := text[lineStart:lineEnd]
+	column := utf8.RuneCountInString(text[lineStart:command.Position])
+	return fmt.Sprintf(
+		"  %s\n  %s^%s\n",
+		line,
+		strings.Repeat(" ", column),
+		strings.Repeat("~", spanLength-1),
+	)
+}
+
+func isNotIdentifierCharacter(character rune) bool {
+	return !unicode.IsLetter(character) && !unicode.IsDigit(character) && character != '_'
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rmaidveo/go-calculator/translator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunDebug(t *testing.T) {
+	tests := []struct {
+		name       string
+		arguments  []string
+		stdin      string
+		wantStdout string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "success/step",
+			arguments: []string{"-var", "x=2", "x * 3"},
+			stdin:     "s\n\nstack\nstep\n",
+			wantStdout: "#0 load x\n  x * 3\n  ^\n(calc) " +
+				"stack: [2]\n#1 push 3\n  x * 3\n      ^\n(calc) " +
+				"stack: [2 3]\n#2 call *\n  x * 3\n    ^\n(calc) stack: [2 3]\n(calc) " +
+				"stack: [6]\nresult: 6\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:      "success/breakpoints",
+			arguments: []string{"-var", "x=2", "sqrt(x) + abs(x)"},
+			stdin:     "b sqrt\nb abs\nclear sqrt\nb\nc\nl\nc\n",
+			wantStdout: "#0 load x\n  sqrt(x) + abs(x)\n       ^\n(calc) (calc) (calc) (calc) " +
+				"breakpoint requires a function or a variable name\n(calc) " +
+				"breakpoint on \"abs\"\n#3 call abs\n  sqrt(x) + abs(x)\n            ^~~\n(calc) " +
+				"  #0 load x\n  #1 call sqrt\n  #2 load x\n> #3 call abs\n  #4 call +\n(calc) " +
+				"result: 3.414213562373095\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:      "success/constant",
+			arguments: []string{"2 * tau"},
+			stdin:     "\n\nstack\nc\n",
+			wantStdout: "#0 push 2\n  2 * tau\n  ^\n(calc) " +
+				"stack: [2]\n#1 push 6.283185307179586\n  2 * tau\n      ^~~\n(calc) " +
+				"stack: [2 6.283185307179586]\n#2 call *\n  2 * tau\n    ^\n(calc) stack: [2 6.283185307179586]\n(calc) " +
+				"result: 12.566370614359172\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:       "success/quit",
+			arguments:  []string{"1 + 2"},
+			stdin:      "q\n",
+			wantStdout: "#0 push 1\n  1 + 2\n  ^\n(calc) ",
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "success/end of input",
+			arguments:  []string{"1 + 2"},
+			stdin:      "",
+			wantStdout: "#0 push 1\n  1 + 2\n  ^\n(calc) \n",
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "error/no expression",
+			arguments:  nil,
+			stdin:      "",
+			wantStdout: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "error/invalid variable",
+			arguments:  []string{"-var", "x", "x"},
+			stdin:      "",
+			wantStdout: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "error/variable named as a constant",
+			arguments:  []string{"-var", "pi=3", "pi * 2"},
+			stdin:      "",
+			wantStdout: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "error/unable to compile",
+			arguments:  []string{"(1 + 2"},
+			stdin:      "",
+			wantStdout: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "error/unable to evaluate",
+			arguments:  []string{"1 / 0"},
+			stdin:      "c\n",
+			wantStdout: "#0 push 1\n  1 / 0\n  ^\n(calc) ",
+			wantErr:    assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			err := runDebug(tt.arguments, strings.NewReader(tt.stdin), &stdout)
+
+			assert.Equal(t, tt.wantStdout, stdout.String())
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		command translator.Command
+		want    string
+	}{
+		{
+			name:    "number",
+			text:    "2.50 * x",
+			command: translator.Command{Kind: translator.PushNumberCommand, Operand: "2.50", Position: 0},
+			want:    "  2.50 * x\n  ^~~~\n",
+		},
+		{
+			name:    "negation",
+			text:    "x * -y",
+			command: translator.Command{Kind: translator.CallFunctionCommand, Operand: "neg", Position: 4},
+			want:    "  x * -y\n      ^\n",
+		},
+		{
+			name:    "multiline",
+			text:    "x = 1\ny = ẋ + z",
+			command: translator.Command{Kind: translator.PushVariableCommand, Operand: "z", Position: 16},
+			want:    "  y = ẋ + z\n          ^\n",
+		},
+		{
+			name:    "pop",
+			text:    "x = 1\ny",
+			command: translator.Command{Kind: translator.PopCommand, Position: 5},
+			want:    "  x = 1\n       ^\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := highlight(tt.text, tt.command)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
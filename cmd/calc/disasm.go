@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	calculator "github.com/rmaidveo/go-calculator"
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/rmaidveo/go-calculator/bytecode"
+	"github.com/rmaidveo/go-calculator/constants"
+)
+
+func runDisassemble(arguments []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("disasm", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	expression := flags.String("e", "", "compile and disassemble the expression instead of reading a program")
+	if err := flags.Parse(arguments); err != nil {
+		return fmt.Errorf("unable to parse the flags: %w", err)
+	}
+	if flags.NArg() > 1 || flags.NArg() == 1 && *expression != "" {
+		return errors.New("usage: calc disasm [-e expression | file]")
+	}
+
+	var program bytecode.Program
+	var err error
+	switch {
+	case *expression != "":
+		program, err = compileProgram(*expression)
+	case flags.NArg() == 1:
+		var data []byte
+		data, err = os.ReadFile(flags.Arg(0))
+		if err != nil {
+			return fmt.Errorf("unable to read the file: %w", err)
+		}
+
+		program, err = decodeProgram(data)
+	default:
+		var data []byte
+		data, err = io.ReadAll(stdin)
+		if err != nil {
+			return fmt.Errorf("unable to read the standard input: %w", err)
+		}
+
+		program, err = decodeProgram(data)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(stdout, bytecode.Disassemble(program))
+	return err
+}
+
+func compileProgram(text string) (bytecode.Program, error) {
+	commands, err := calculator.CompileWithOptions(text, builtin.Functions(), calculator.Options{Constants: constants.Mathematical()})
+	if err != nil {
+		return bytecode.Program{}, err
+	}
+
+	program, err := bytecode.FromCommands(commands)
+	if err != nil {
+		return bytecode.Program{}, fmt.Errorf("unable to compile the program: %w", err)
+	}
+
+	return program, nil
+}
+
+func decodeProgram(data []byte) (bytecode.Program, error) {
+	decode := bytecode.Decode
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		decode = bytecode.DecodeJSON
+	}
+
+	program, err := decode(data)
+	if err != nil {
+		return bytecode.Program{}, fmt.Errorf("unable to decode the program: %w", err)
+	}
+
+	return program, nil
+}
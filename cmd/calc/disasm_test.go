@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rmaidveo/go-calculator/bytecode"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunDisassemble(t *testing.T) {
+	const listing = "version 1\n" +
+		"constants: 1\n" +
+		"  #0  2\n" +
+		"symbols: 2\n" +
+		"  #0  variable  x\n" +
+		"  #1  function  *\n" +
+		"instructions: 3\n" +
+		"  0000  load  #0  @0  ; x\n" +
+		"  0001  push  #0  @4  ; 2\n" +
+		"  0002  call  #1  @2  ; *\n"
+
+	program := bytecode.Program{
+		Constants: []float64{2},
+		Symbols: []bytecode.Symbol{
+			{Kind: bytecode.VariableSymbol, Name: "x"},
+			{Kind: bytecode.FunctionSymbol, Name: "*"},
+		},
+		Instructions: []bytecode.Instruction{
+			{Opcode: bytecode.PushVariableOpcode, Operand: 0, Position: 0},
+			{Opcode: bytecode.PushNumberOpcode, Operand: 0, Position: 4},
+			{Opcode: bytecode.CallFunctionOpcode, Operand: 1, Position: 2},
+		},
+	}
+	jsonData, err := bytecode.EncodeJSON(program)
+	assert.NoError(t, err)
+
+	directory := t.TempDir()
+	binaryPath := filepath.Join(directory, "program.bin")
+	err = os.WriteFile(binaryPath, bytecode.Encode(program), 0o644)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		arguments  []string
+		stdin      []byte
+		wantStdout string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "success/expression",
+			arguments:  []string{"-e", "x * 2"},
+			stdin:      nil,
+			wantStdout: listing,
+			wantErr:    assert.NoError,
+		},
+		{
+			name:      "success/expression with an infinite constant",
+			arguments: []string{"-e", "x * inf"},
+			stdin:     nil,
+			wantStdout: "version 1\n" +
+				"constants: 1\n" +
+				"  #0  +Inf\n" +
+				"symbols: 2\n" +
+				"  #0  variable  x\n" +
+				"  #1  function  *\n" +
+				"instructions: 3\n" +
+				"  0000  load  #0  @0  ; x\n" +
+				"  0001  push  #0  @4  ; +Inf\n" +
+				"  0002  call  #1  @2  ; *\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:       "success/binary file",
+			arguments:  []string{binaryPath},
+			stdin:      nil,
+			wantStdout: listing,
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "success/JSON from standard input",
+			arguments:  nil,
+			stdin:      append([]byte("\n  "), jsonData...),
+			wantStdout: listing,
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "error/too many arguments",
+			arguments:  []string{"-e", "x * 2", binaryPath},
+			stdin:      nil,
+			wantStdout: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "error/unknown flag",
+			arguments:  []string{"-unknown"},
+			stdin:      nil,
+			wantStdout: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "error/unable to compile",
+			arguments:  []string{"-e", "(x"},
+			stdin:      nil,
+			wantStdout: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "error/missing file",
+			arguments:  []string{filepath.Join(directory, "missing.bin")},
+			stdin:      nil,
+			wantStdout: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "error/malformed program",
+			arguments:  nil,
+			stdin:      []byte("CALC"),
+			wantStdout: "",
+			wantErr:    assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			err := runDisassemble(tt.arguments, bytes.NewReader(tt.stdin), &stdout)
+
+			assert.Equal(t, tt.wantStdout, stdout.String())
+			tt.wantErr(t, err)
+		})
+	}
+}
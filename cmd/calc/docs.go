@@ -0,0 +1,44 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/rmaidveo/go-calculator/docs"
+)
+
+func runDocs(arguments []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("docs", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	format := flags.String("format", "markdown", "output format: markdown or json")
+	if err := flags.Parse(arguments); err != nil {
+		return fmt.Errorf("unable to parse the flags: %w", err)
+	}
+	if flags.NArg() != 0 {
+		return errors.New("usage: calc docs [-format markdown|json]")
+	}
+
+	reference, err := docs.Generate(builtin.Registry())
+	if err != nil {
+		return fmt.Errorf("unable to generate the reference: %w", err)
+	}
+
+	switch *format {
+	case "markdown":
+		_, err = io.WriteString(stdout, docs.Markdown(reference))
+		return err
+	case "json":
+		content, err := docs.JSON(reference)
+		if err != nil {
+			return err
+		}
+
+		_, err = stdout.Write(append(content, '\n'))
+		return err
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+}
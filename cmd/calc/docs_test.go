@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunDocs(t *testing.T) {
+	tests := []struct {
+		name         string
+		arguments    []string
+		wantContains []string
+		wantErr      assert.ErrorAssertionFunc
+	}{
+		{
+			name:         "success/markdown",
+			arguments:    nil,
+			wantContains: []string{"# Calculator reference\n", "| `^` | `^` | 4 | right |", "#### `sqrt(x)`"},
+			wantErr:      assert.NoError,
+		},
+		{
+			name:         "success/json",
+			arguments:    []string{"-format", "json"},
+			wantContains: []string{`"signature": "sqrt(x)"`, `"precedence": 4`},
+			wantErr:      assert.NoError,
+		},
+		{
+			name:         "error/unknown format",
+			arguments:    []string{"-format", "html"},
+			wantContains: nil,
+			wantErr:      assert.Error,
+		},
+		{
+			name:         "error/unknown flag",
+			arguments:    []string{"-unknown"},
+			wantContains: nil,
+			wantErr:      assert.Error,
+		},
+		{
+			name:         "error/extra arguments",
+			arguments:    []string{"functions"},
+			wantContains: nil,
+			wantErr:      assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			err := runDocs(tt.arguments, nil, &stdout)
+
+			for _, want := range tt.wantContains {
+				assert.Contains(t, stdout.String(), want)
+			}
+			if tt.wantContains == nil {
+				assert.Empty(t, stdout.String())
+			}
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestRunDocs_validJSON(t *testing.T) {
+	var stdout bytes.Buffer
+	err := runDocs([]string{"-format", "json"}, nil, &stdout)
+
+	assert.NoError(t, err)
+	assert.True(t, json.Valid(stdout.Bytes()))
+}
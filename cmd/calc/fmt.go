@@ -0,0 +1,109 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/rmaidveo/go-calculator/format"
+)
+
+var (
+	errNotFormatted = errors.New("not formatted")
+)
+
+func runFormat(arguments []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("fmt", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	check := flags.Bool("check", false, "list files whose formatting differs and fail if there are any")
+	write := flags.Bool("w", false, "write the result to the source files instead of the standard output")
+	if err := flags.Parse(arguments); err != nil {
+		return fmt.Errorf("unable to parse the flags: %w", err)
+	}
+	if *check && *write {
+		return errors.New("flags -check and -w are mutually exclusive")
+	}
+
+	if flags.NArg() == 0 {
+		if *write {
+			return errors.New("flag -w requires file arguments")
+		}
+
+		text, err := io.ReadAll(stdin)
+		if err != nil {
+			return fmt.Errorf("unable to read the standard input: %w", err)
+		}
+
+		formatted, err := formatFile(string(text))
+		if err != nil {
+			return fmt.Errorf("unable to format the standard input: %w", err)
+		}
+		if *check {
+			if formatted != string(text) {
+				return errNotFormatted
+			}
+
+			return nil
+		}
+
+		_, err = io.WriteString(stdout, formatted)
+		return err
+	}
+
+	hasUnformattedFiles := false
+	for _, path := range flags.Args() {
+		text, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("unable to read the file: %w", err)
+		}
+
+		formatted, err := formatFile(string(text))
+		if err != nil {
+			return fmt.Errorf("unable to format the file %s: %w", path, err)
+		}
+
+		switch {
+		case *check:
+			if formatted != string(text) {
+				hasUnformattedFiles = true
+				fmt.Fprintln(stdout, path)
+			}
+		case *write:
+			if formatted == string(text) {
+				continue
+			}
+			if formatted == "" && strings.TrimSpace(string(text)) != "" {
+				return fmt.Errorf("unable to write the file %s: the formatted result is empty", path)
+			}
+
+			if err := os.WriteFile(path, []byte(formatted), 0o644); err != nil {
+				return fmt.Errorf("unable to write the file: %w", err)
+			}
+		default:
+			if _, err := io.WriteString(stdout, formatted); err != nil {
+				return err
+			}
+		}
+	}
+	if hasUnformattedFiles {
+		return errNotFormatted
+	}
+
+	return nil
+}
+
+func formatFile(text string) (string, error) {
+	formatted, err := format.Format(text, builtin.Functions())
+	if err != nil {
+		return "", err
+	}
+	if formatted == "" {
+		return "", nil
+	}
+
+	return formatted + "\n", nil
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunFormat(t *testing.T) {
+	const (
+		formattedText   = "x = 1 # one\ny = 2*(x + 1)^2\n"
+		unformattedText = "x=1 # one\ny = 2*(x+ 1 )^ 2"
+	)
+
+	tests := []struct {
+		name       string
+		arguments  []string
+		stdin      string
+		files      map[string]string
+		wantStdout string
+		wantFiles  map[string]string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "success/standard input",
+			arguments:  nil,
+			stdin:      unformattedText,
+			files:      nil,
+			wantStdout: formattedText,
+			wantFiles:  nil,
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "success/standard input/empty",
+			arguments:  nil,
+			stdin:      "",
+			files:      nil,
+			wantStdout: "",
+			wantFiles:  nil,
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "success/files",
+			arguments:  []string{"a.calc", "b.calc"},
+			stdin:      "",
+			files:      map[string]string{"a.calc": unformattedText, "b.calc": "1+2"},
+			wantStdout: formattedText + "1 + 2\n",
+			wantFiles:  map[string]string{"a.calc": unformattedText, "b.calc": "1+2"},
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "success/check",
+			arguments:  []string{"-check", "a.calc"},
+			stdin:      "",
+			files:      map[string]string{"a.calc": formattedText},
+			wantStdout: "",
+			wantFiles:  map[string]string{"a.calc": formattedText},
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "success/write",
+			arguments:  []string{"-w", "a.calc", "b.calc"},
+			stdin:      "",
+			files:      map[string]string{"a.calc": unformattedText, "b.calc": formattedText},
+			wantStdout: "",
+			wantFiles:  map[string]string{"a.calc": formattedText, "b.calc": formattedText},
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "success/write/only a comment",
+			arguments:  []string{"-w", "a.calc"},
+			stdin:      "",
+			files:      map[string]string{"a.calc": "# rate explanation"},
+			wantStdout: "",
+			wantFiles:  map[string]string{"a.calc": "# rate explanation\n"},
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "error/check/files",
+			arguments:  []string{"-check", "a.calc", "b.calc"},
+			stdin:      "",
+			files:      map[string]string{"a.calc": unformattedText, "b.calc": formattedText},
+			wantStdout: "a.calc\n",
+			wantFiles:  map[string]string{"a.calc": unformattedText, "b.calc": formattedText},
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "error/check/standard input",
+			arguments:  []string{"-check"},
+			stdin:      unformattedText,
+			files:      nil,
+			wantStdout: "",
+			wantFiles:  nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "error/unknown flag",
+			arguments:  []string{"-unknown"},
+			stdin:      "",
+			files:      nil,
+			wantStdout: "",
+			wantFiles:  nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "error/check and write",
+			arguments:  []string{"-check", "-w", "a.calc"},
+			stdin:      "",
+			files:      map[string]string{"a.calc": unformattedText},
+			wantStdout: "",
+			wantFiles:  map[string]string{"a.calc": unformattedText},
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "error/write without files",
+			arguments:  []string{"-w"},
+			stdin:      unformattedText,
+			files:      nil,
+			wantStdout: "",
+			wantFiles:  nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "error/missing file",
+			arguments:  []string{"missing.calc"},
+			stdin:      "",
+			files:      nil,
+			wantStdout: "",
+			wantFiles:  nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "error/invalid expression",
+			arguments:  []string{"a.calc"},
+			stdin:      "",
+			files:      map[string]string{"a.calc": "(1 + 2"},
+			wantStdout: "",
+			wantFiles:  map[string]string{"a.calc": "(1 + 2"},
+			wantErr:    assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			directory := t.TempDir()
+			for name, content := range tt.files {
+				err := os.WriteFile(filepath.Join(directory, name), []byte(content), 0o644)
+				assert.NoError(t, err)
+			}
+
+			arguments := make([]string, 0, len(tt.arguments))
+			for _, argument := range tt.arguments {
+				if strings.HasSuffix(argument, ".calc") {
+					argument = filepath.Join(directory, argument)
+				}
+
+				arguments = append(arguments, argument)
+			}
+
+			var stdout bytes.Buffer
+			err := runFormat(arguments, strings.NewReader(tt.stdin), &stdout)
+
+			assert.Equal(t, tt.wantStdout, strings.ReplaceAll(stdout.String(), directory+string(filepath.Separator), ""))
+			for name, wantContent := range tt.wantFiles {
+				content, err := os.ReadFile(filepath.Join(directory, name))
+				assert.NoError(t, err)
+				assert.Equal(t, wantContent, string(content))
+			}
+			tt.wantErr(t, err)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+type command func(arguments []string, stdin io.Reader, stdout io.Writer) error
+
+var commands = map[string]command{
+	"debug":  runDebug,
+	"disasm": runDisassemble,
+	"docs":   runDocs,
+	"fmt":    runFormat,
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(arguments []string, stdin io.Reader, stdout io.Writer) error {
+	if len(arguments) == 0 {
+		return errors.New(usage())
+	}
+
+	command, ok := commands[arguments[0]]
+	if !ok {
+		return fmt.Errorf("unknown command %q\n%s", arguments[0], usage())
+	}
+
+	return command(arguments[1:], stdin, stdout)
+}
+
+func usage() string {
+	commandNames := make([]string, 0, len(commands))
+	for commandName := range commands {
+		commandNames = append(commandNames, commandName)
+	}
+	sort.Strings(commandNames)
+
+	return fmt.Sprintf("usage: calc <%s> [arguments]", strings.Join(commandNames, "|"))
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		arguments  []string
+		stdin      string
+		wantStdout string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "success",
+			arguments:  []string{"fmt"},
+			stdin:      "2*(x+ 1 )^ 2",
+			wantStdout: "2*(x + 1)^2\n",
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "error/no command",
+			arguments:  nil,
+			stdin:      "",
+			wantStdout: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "error/unknown command",
+			arguments:  []string{"unknown"},
+			stdin:      "",
+			wantStdout: "",
+			wantErr:    assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			err := run(tt.arguments, strings.NewReader(tt.stdin), &stdout)
+
+			assert.Equal(t, tt.wantStdout, stdout.String())
+			tt.wantErr(t, err)
+		})
+	}
+}
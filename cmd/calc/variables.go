@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type variablesFlag map[string]float64
+
+func (variables variablesFlag) String() string {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	assignments := make([]string, 0, len(names))
+	for _, name := range names {
+		assignments = append(assignments, name+"="+strconv.FormatFloat(variables[name], 'g', -1, 64))
+	}
+
+	return strings.Join(assignments, ",")
+}
+
+func (variables variablesFlag) Set(value string) error {
+	name, number, ok := strings.Cut(value, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("expected name=value, got %q", value)
+	}
+
+	parsedNumber, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse the value of the variable %q: %w", name, err)
+	}
+
+	variables[name] = parsedNumber
+	return nil
+}
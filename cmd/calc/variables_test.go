@@ -0,0 +1,61 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVariablesFlag_Set(t *testing.T) {
+	tests := []struct {
+		name          string
+		values        []string
+		wantVariables variablesFlag
+		wantString    string
+		wantErr       assert.ErrorAssertionFunc
+	}{
+		{
+			name:          "success",
+			values:        []string{"y=2.5", "x=1", "x=3"},
+			wantVariables: variablesFlag{"x": 3, "y": 2.5},
+			wantString:    "x=3,y=2.5",
+			wantErr:       assert.NoError,
+		},
+		{
+			name:          "error/without equals sign",
+			values:        []string{"x"},
+			wantVariables: variablesFlag{},
+			wantString:    "",
+			wantErr:       assert.Error,
+		},
+		{
+			name:          "error/without name",
+			values:        []string{"=1"},
+			wantVariables: variablesFlag{},
+			wantString:    "",
+			wantErr:       assert.Error,
+		},
+		{
+			name:          "error/invalid number",
+			values:        []string{"x=one"},
+			wantVariables: variablesFlag{},
+			wantString:    "",
+			wantErr:       assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			variables := variablesFlag{}
+			var err error
+			for _, value := range tt.values {
+				if err = variables.Set(value); err != nil {
+					break
+				}
+			}
+
+			assert.Equal(t, tt.wantVariables, variables)
+			assert.Equal(t, tt.wantString, variables.String())
+			tt.wantErr(t, err)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package constants
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/rmaidveo/go-calculator/tokenizer"
+)
+
+func Mathematical() map[string]float64 {
+	return map[string]float64{
+		"pi":  math.Pi,
+		"e":   math.E,
+		"tau": 2 * math.Pi,
+		"phi": math.Phi,
+		"inf": math.Inf(+1),
+		"nan": math.NaN(),
+	}
+}
+
+func Merge(sets ...map[string]float64) (map[string]float64, error) {
+	constants := map[string]float64{}
+	for _, set := range sets {
+		for name, value := range set {
+			if err := validateName(name); err != nil {
+				return nil, err
+			}
+			if _, ok := constants[name]; ok {
+				return nil, fmt.Errorf("constant %q is already defined", name)
+			}
+
+			constants[name] = value
+		}
+	}
+
+	return constants, nil
+}
+
+func CheckVariables(constants map[string]float64, variables map[string]float64) error {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		if _, ok := constants[name]; ok {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	sort.Strings(names)
+	return fmt.Errorf("variable %q has the name of a constant", names[0])
+}
+
+func validateName(name string) error {
+	tokens, err := tokenizer.Tokenize(name)
+	if err != nil {
+		return fmt.Errorf("unable to tokenize the constant name %q: %w", name, err)
+	}
+	if len(tokens) != 1 || tokens[0].Kind != tokenizer.IdentifierToken || tokens[0].Value != name {
+		return fmt.Errorf("constant name %q is not an identifier", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package constants
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMathematical(t *testing.T) {
+	got := Mathematical()
+
+	assert.Len(t, got, 6)
+	assert.Equal(t, math.Pi, got["pi"])
+	assert.Equal(t, math.E, got["e"])
+	assert.Equal(t, 2*math.Pi, got["tau"])
+	assert.InDelta(t, (1+math.Sqrt(5))/2, got["phi"], 1e-15)
+	assert.True(t, math.IsInf(got["inf"], +1))
+	assert.True(t, math.IsNaN(got["nan"]))
+}
+
+func TestMerge(t *testing.T) {
+	type args struct {
+		sets []map[string]float64
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    map[string]float64
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success/no sets",
+			args:    args{sets: nil},
+			want:    map[string]float64{},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/several sets",
+			args: args{
+				sets: []map[string]float64{{"pi": 3.5, "e": 2.5}, {"g": 9.5}, nil},
+			},
+			want:    map[string]float64{"pi": 3.5, "e": 2.5, "g": 9.5},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/underscores and digits",
+			args:    args{sets: []map[string]float64{{"k_B": 1, "_x2": 2}}},
+			want:    map[string]float64{"k_B": 1, "_x2": 2},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error/duplicate",
+			args: args{
+				sets: []map[string]float64{{"pi": 3.5}, {"pi": 3.5}},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/empty name",
+			args:    args{sets: []map[string]float64{{"": 1}}},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/number",
+			args:    args{sets: []map[string]float64{{"12": 1}}},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/several tokens",
+			args:    args{sets: []map[string]float64{{"x y": 1}}},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/keyword",
+			args:    args{sets: []map[string]float64{{"let": 1}}},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/comment",
+			args:    args{sets: []map[string]float64{{"x #": 1}}},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Merge(tt.args.sets...)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestCheckVariables(t *testing.T) {
+	type args struct {
+		constants map[string]float64
+		variables map[string]float64
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success/no conflicts",
+			args:    args{constants: Mathematical(), variables: map[string]float64{"x": 1, "pie": 2}},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/no variables",
+			args:    args{constants: Mathematical(), variables: nil},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error/conflicts",
+			args: args{constants: Mathematical(), variables: map[string]float64{"x": 1, "pi": 3, "e": 5}},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(t, err, `variable "e" has the name of a constant`, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckVariables(tt.args.constants, tt.args.variables)
+
+			tt.wantErr(t, err)
+		})
+	}
+}
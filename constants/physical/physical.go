@@ -0,0 +1,22 @@
+package physical
+
+func Constants() map[string]float64 {
+	return map[string]float64{
+		"c":         299792458,
+		"g":         9.80665,
+		"G":         6.67430e-11,
+		"h":         6.62607015e-34,
+		"hbar":      1.054571817e-34,
+		"k_B":       1.380649e-23,
+		"N_A":       6.02214076e23,
+		"R":         8.314462618,
+		"q_e":       1.602176634e-19,
+		"m_e":       9.1093837015e-31,
+		"m_p":       1.67262192369e-27,
+		"m_n":       1.67492749804e-27,
+		"epsilon_0": 8.8541878128e-12,
+		"mu_0":      1.25663706212e-6,
+		"sigma":     5.670374419e-8,
+		"atm":       101325,
+	}
+}
@@ -0,0 +1,21 @@
+package physical
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rmaidveo/go-calculator/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstants(t *testing.T) {
+	got := Constants()
+
+	merged, err := constants.Merge(constants.Mathematical(), got)
+	assert.NoError(t, err)
+	assert.Len(t, merged, len(constants.Mathematical())+len(got))
+
+	assert.InDelta(t, got["h"]/(2*math.Pi), got["hbar"], 1e-43)
+	assert.InDelta(t, got["N_A"]*got["k_B"], got["R"], 1e-8)
+	assert.InDelta(t, 1/(got["mu_0"]*got["c"]*got["c"]), got["epsilon_0"], 1e-20)
+}
@@ -23,3 +23,10 @@ func (stack *Stack[T]) Pop() (element T, ok bool) {
 
 	return lastElement, true
 }
+
+func (stack Stack[T]) Elements() []T {
+	elements := make([]T, len(stack.elements))
+	copy(elements, stack.elements)
+
+	return elements
+}
@@ -0,0 +1,43 @@
+package calculator
+
+import (
+	"fmt"
+
+	"github.com/rmaidveo/go-calculator/ast"
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/rmaidveo/go-calculator/derivative"
+	"github.com/rmaidveo/go-calculator/evaluator"
+)
+
+func Derivative(text string, variable string) (string, error) {
+	node, err := DerivativeWithRules(text, variable, builtin.Functions(), derivative.Rules())
+	if err != nil {
+		return "", err
+	}
+
+	return node.String(), nil
+}
+
+func DerivativeWithRules(
+	text string,
+	variable string,
+	functions map[string]evaluator.Function,
+	rules map[string]derivative.Rule,
+) (*ast.Node, error) {
+	commands, err := Compile(text, functions)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := ast.Build(commands, functions)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build the syntax tree: %w", err)
+	}
+
+	derivativeNode, err := derivative.Differentiate(node, variable, rules)
+	if err != nil {
+		return nil, fmt.Errorf("unable to differentiate: %w", err)
+	}
+
+	return derivativeNode, nil
+}
@@ -0,0 +1,156 @@
+package derivative
+
+import (
+	"math"
+
+	"github.com/rmaidveo/go-calculator/ast"
+)
+
+type Builder struct {
+	Position int
+}
+
+func (builder Builder) Number(number float64) *ast.Node {
+	return ast.Number(number, builder.Position)
+}
+
+func (builder Builder) Call(name string, arguments ...*ast.Node) *ast.Node {
+	return ast.Call(name, builder.Position, arguments...)
+}
+
+func (builder Builder) Add(x *ast.Node, y *ast.Node) *ast.Node {
+	switch {
+	case isNumber(x, 0):
+		return y
+	case isNumber(y, 0):
+		return x
+	}
+	if numberX, numberY, ok := numbers(x, y); ok {
+		return builder.Number(numberX + numberY)
+	}
+	if y.Kind == ast.CallNode && y.Value == "neg" {
+		return builder.Call("-", x, y.Arguments[0])
+	}
+
+	return builder.Call("+", x, y)
+}
+
+func (builder Builder) Subtract(x *ast.Node, y *ast.Node) *ast.Node {
+	switch {
+	case isNumber(y, 0):
+		return x
+	case isNumber(x, 0):
+		return builder.Negate(y)
+	}
+	if numberX, numberY, ok := numbers(x, y); ok {
+		return builder.Number(numberX - numberY)
+	}
+
+	return builder.Call("-", x, y)
+}
+
+func (builder Builder) Multiply(x *ast.Node, y *ast.Node) *ast.Node {
+	if number, ok := numberOf(x); ok {
+		x = builder.Number(number)
+	}
+	if number, ok := numberOf(y); ok {
+		y = builder.Number(number)
+	}
+
+	switch {
+	case isNumber(x, 0) || isNumber(y, 0):
+		return builder.Number(0)
+	case isNumber(x, 1):
+		return y
+	case isNumber(y, 1):
+		return x
+	case isNumber(x, -1):
+		return builder.Negate(y)
+	case isNumber(y, -1):
+		return builder.Negate(x)
+	}
+	if numberX, numberY, ok := numbers(x, y); ok {
+		return builder.Number(numberX * numberY)
+	}
+	if x.Kind == ast.CallNode && x.Value == "/" && isNumber(x.Arguments[0], 1) {
+		return builder.Divide(y, x.Arguments[1])
+	}
+	if x.Kind == ast.CallNode && x.Value == "neg" {
+		return builder.Negate(builder.Multiply(x.Arguments[0], y))
+	}
+	if y.Kind == ast.CallNode && y.Value == "neg" {
+		return builder.Negate(builder.Multiply(x, y.Arguments[0]))
+	}
+
+	return builder.Call("*", x, y)
+}
+
+func (builder Builder) Divide(x *ast.Node, y *ast.Node) *ast.Node {
+	switch {
+	case isNumber(x, 0):
+		return builder.Number(0)
+	case isNumber(y, 1):
+		return x
+	}
+	if numberX, numberY, ok := numbers(x, y); ok && numberY != 0 {
+		return builder.Number(numberX / numberY)
+	}
+	if x.Kind == ast.CallNode && x.Value == "neg" {
+		return builder.Negate(builder.Divide(x.Arguments[0], y))
+	}
+
+	return builder.Call("/", x, y)
+}
+
+func (builder Builder) Power(x *ast.Node, y *ast.Node) *ast.Node {
+	switch {
+	case isNumber(y, 0):
+		return builder.Number(1)
+	case isNumber(y, 1):
+		return x
+	}
+	if numberX, numberY, ok := numbers(x, y); ok {
+		return builder.Number(math.Pow(numberX, numberY))
+	}
+
+	return builder.Call("^", x, y)
+}
+
+func (builder Builder) Negate(x *ast.Node) *ast.Node {
+	if number, ok := numberOf(x); ok {
+		return builder.Number(-number)
+	}
+	if x.Kind == ast.CallNode && x.Value == "neg" {
+		return x.Arguments[0]
+	}
+
+	return builder.Call("neg", x)
+}
+
+func numberOf(node *ast.Node) (float64, bool) {
+	if node.Kind == ast.CallNode && node.Value == "neg" && len(node.Arguments) == 1 {
+		number, ok := numberOf(node.Arguments[0])
+		return -number, ok
+	}
+
+	return node.Number()
+}
+
+func isNumber(node *ast.Node, number float64) bool {
+	nodeNumber, ok := numberOf(node)
+	return ok && nodeNumber == number
+}
+
+func numbers(x *ast.Node, y *ast.Node) (float64, float64, bool) {
+	numberX, ok := numberOf(x)
+	if !ok {
+		return 0, 0, false
+	}
+
+	numberY, ok := numberOf(y)
+	if !ok {
+		return 0, 0, false
+	}
+
+	return numberX, numberY, true
+}
@@ -0,0 +1,45 @@
+package derivative
+
+import (
+	"testing"
+
+	"github.com/rmaidveo/go-calculator/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder(t *testing.T) {
+	builder := Builder{Position: 42}
+	x := ast.Variable("x", 0)
+	y := ast.Variable("y", 0)
+
+	tests := []struct {
+		name string
+		node *ast.Node
+		want string
+	}{
+		{name: "add/zero", node: builder.Add(builder.Number(0), x), want: "x"},
+		{name: "add/numbers", node: builder.Add(builder.Number(2), builder.Number(3)), want: "5"},
+		{name: "add/negation", node: builder.Add(x, builder.Negate(y)), want: "x - y"},
+		{name: "subtract/zero", node: builder.Subtract(x, builder.Number(0)), want: "x"},
+		{name: "subtract/from zero", node: builder.Subtract(builder.Number(0), x), want: "-x"},
+		{name: "multiply/zero", node: builder.Multiply(x, builder.Number(0)), want: "0"},
+		{name: "multiply/one", node: builder.Multiply(builder.Number(1), x), want: "x"},
+		{name: "multiply/minus one", node: builder.Multiply(x, builder.Number(-1)), want: "-x"},
+		{name: "multiply/reciprocal", node: builder.Multiply(builder.Divide(builder.Number(1), y), x), want: "x/y"},
+		{name: "multiply/negation", node: builder.Multiply(builder.Negate(x), y), want: "-(x*y)"},
+		{name: "divide/zero", node: builder.Divide(builder.Number(0), x), want: "0"},
+		{name: "divide/by zero", node: builder.Divide(builder.Number(1), builder.Number(0)), want: "1/0"},
+		{name: "power/zero", node: builder.Power(x, builder.Number(0)), want: "1"},
+		{name: "power/one", node: builder.Power(x, builder.Number(1)), want: "x"},
+		{name: "negate/number", node: builder.Negate(builder.Number(2)), want: "-2"},
+		{name: "negate/negation", node: builder.Negate(builder.Negate(x)), want: "x"},
+		{name: "call", node: builder.Call("sin", x), want: "sin(x)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.node.String()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package derivative
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rmaidveo/go-calculator/ast"
+)
+
+type differentiator struct {
+	variable    string
+	rules       map[string]Rule
+	derivatives map[string]*ast.Node
+}
+
+func Differentiate(node *ast.Node, variable string, rules map[string]Rule) (*ast.Node, error) {
+	differentiator := differentiator{
+		variable:    variable,
+		rules:       rules,
+		derivatives: map[string]*ast.Node{},
+	}
+
+	if node.Kind != ast.SequenceNode {
+		return differentiator.differentiate(node)
+	}
+
+	statements := make([]*ast.Node, 0, len(node.Arguments))
+	for _, statement := range node.Arguments[:len(node.Arguments)-1] {
+		if err := differentiator.addStatement(statement); err != nil {
+			return nil, err
+		}
+
+		statements = append(statements, statement)
+	}
+
+	lastStatement := node.Arguments[len(node.Arguments)-1]
+	derivative, err := differentiator.differentiate(lastStatement)
+	if err != nil {
+		return nil, err
+	}
+
+	statements = append(statements, derivative)
+	sequence := &ast.Node{
+		Kind:      ast.SequenceNode,
+		Position:  node.Position,
+		Arguments: statements,
+	}
+	return sequence, nil
+}
+
+func (differentiator differentiator) addStatement(statement *ast.Node) error {
+	if statement.Kind != ast.AssignmentNode {
+		if _, err := differentiator.differentiate(statement); err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	derivative, err := differentiator.differentiate(statement.Arguments[0])
+	if err != nil {
+		return err
+	}
+	if references(derivative, statement.Value) {
+		return fmt.Errorf("unable to differentiate the reassignment of %q at position %d", statement.Value, statement.Position)
+	}
+
+	differentiator.derivatives[statement.Value] = derivative
+	return nil
+}
+
+func (differentiator differentiator) differentiate(node *ast.Node) (*ast.Node, error) {
+	builder := Builder{Position: node.Position}
+	switch node.Kind {
+	case ast.NumberNode:
+		return builder.Number(0), nil
+	case ast.VariableNode:
+		if derivative, ok := differentiator.derivatives[node.Value]; ok {
+			return derivative, nil
+		}
+		if node.Value == differentiator.variable {
+			return builder.Number(1), nil
+		}
+
+		return builder.Number(0), nil
+	case ast.CallNode:
+		rule, ok := differentiator.rules[node.Value]
+		if !ok {
+			return nil, fmt.Errorf("no derivative rule for the function %q at position %d", node.Value, node.Position)
+		}
+
+		derivatives := make([]*ast.Node, 0, len(node.Arguments))
+		for _, argument := range node.Arguments {
+			derivative, err := differentiator.differentiate(argument)
+			if err != nil {
+				return nil, err
+			}
+
+			derivatives = append(derivatives, derivative)
+		}
+
+		derivative, err := rule(builder, node.Arguments, derivatives)
+		if err != nil {
+			return nil, fmt.Errorf("unable to differentiate the function %q at position %d: %w", node.Value, node.Position, err)
+		}
+
+		return derivative, nil
+	case ast.LetNode:
+		return differentiator.differentiateLet(node)
+	case ast.AssignmentNode:
+		return nil, fmt.Errorf("unable to differentiate the nested assignment to %q at position %d", node.Value, node.Position)
+	default:
+		return nil, errors.New("unable to differentiate a nested sequence")
+	}
+}
+
+func (differentiator differentiator) differentiateLet(node *ast.Node) (*ast.Node, error) {
+	value, body := node.Arguments[0], node.Arguments[1]
+	valueDerivative, err := differentiator.differentiate(value)
+	if err != nil {
+		return nil, err
+	}
+	if references(valueDerivative, node.Value) {
+		return nil, fmt.Errorf("unable to differentiate the shadowing binding of %q at position %d", node.Value, node.Position)
+	}
+
+	previousDerivative, hadPreviousDerivative := differentiator.derivatives[node.Value]
+	differentiator.derivatives[node.Value] = valueDerivative
+	defer func() {
+		if hadPreviousDerivative {
+			differentiator.derivatives[node.Value] = previousDerivative
+		} else {
+			delete(differentiator.derivatives, node.Value)
+		}
+	}()
+
+	bodyDerivative, err := differentiator.differentiate(body)
+	if err != nil {
+		return nil, err
+	}
+	if !references(bodyDerivative, node.Value) {
+		return bodyDerivative, nil
+	}
+
+	letNode := &ast.Node{
+		Kind:      ast.LetNode,
+		Value:     node.Value,
+		Position:  node.Position,
+		Arguments: []*ast.Node{value, bodyDerivative},
+	}
+	return letNode, nil
+}
+
+func references(node *ast.Node, variable string) bool {
+	if node.Kind == ast.VariableNode && node.Value == variable {
+		return true
+	}
+
+	for _, argument := range node.Arguments {
+		if references(argument, variable) {
+			return true
+		}
+	}
+
+	return false
+}
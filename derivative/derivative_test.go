@@ -0,0 +1,206 @@
+package derivative
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rmaidveo/go-calculator/ast"
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/rmaidveo/go-calculator/tokenizer"
+	"github.com/rmaidveo/go-calculator/translator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDifferentiate(t *testing.T) {
+	type args struct {
+		text     string
+		variable string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success/number",
+			args:    args{text: "23", variable: "x"},
+			want:    "0",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/variable",
+			args:    args{text: "x", variable: "x"},
+			want:    "1",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/other variable",
+			args:    args{text: "y", variable: "x"},
+			want:    "0",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/product",
+			args:    args{text: "x^2 * sin(x)", variable: "x"},
+			want:    "2*x*sin(x) + x^2*cos(x)",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/sum",
+			args:    args{text: "3 * x - y + x", variable: "x"},
+			want:    "4",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/quotient",
+			args:    args{text: "1 / x", variable: "x"},
+			want:    "-1/x^2",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/chain rule",
+			args:    args{text: "sqrt(x^2 + 1)", variable: "x"},
+			want:    "2*x/(2*sqrt(x^2 + 1))",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/exponential",
+			args:    args{text: "2^x", variable: "x"},
+			want:    "2^x*log(2)",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/negative exponent",
+			args:    args{text: "x^-2", variable: "x"},
+			want:    "-2*x^-3",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/let binding",
+			args:    args{text: "let y = x^2 in y * y", variable: "x"},
+			want:    "let y = x^2 in 2*x*y + y*(2*x)",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/statements",
+			args:    args{text: "a = x^2; a * 3", variable: "x"},
+			want:    "a = x^2; 2*x*3",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/linear postfix operators",
+			args:    args{text: "x° + percent(3 * x)", variable: "x"},
+			want:    "1° + percent(3)",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error/no derivative rule",
+			args:    args{text: "max(x, 2)", variable: "x"},
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/not differentiable",
+			args:    args{text: "3 % x", variable: "x"},
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/reassignment",
+			args:    args{text: "a = x; a = a * x; a", variable: "x"},
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/shadowing binding",
+			args:    args{text: "let x = x^2 in x", variable: "x"},
+			want:    "",
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := build(t, tt.args.text)
+
+			got, err := Differentiate(node, tt.args.variable, Rules())
+
+			if got != nil {
+				assert.Equal(t, tt.want, got.String())
+			}
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestDifferentiate_matchesNumericalDerivative(t *testing.T) {
+	texts := []string{
+		"x^3 - 2*x + 1",
+		"sin(x) * cos(x)",
+		"tan(x) / (1 + x^2)",
+		"exp(-x^2 / 2)",
+		"log(x) + log10(x)",
+		"x^x",
+		"sqrt(x) * abs(x - 2)",
+		"asin(x / 4) + acos(x / 4) + atan(x)",
+		"atan2(x, 2) + pow(2, x) + floor(x)",
+	}
+	for _, text := range texts {
+		t.Run(text, func(t *testing.T) {
+			derivative, err := Differentiate(build(t, text), "x", Rules())
+			assert.NoError(t, err)
+
+			for _, x := range []float64{0.3, 1.1, 2.7} {
+				got := evaluate(t, derivative, x)
+
+				const step = 1e-6
+				want := (evaluate(t, build(t, text), x+step) - evaluate(t, build(t, text), x-step)) / (2 * step)
+
+				assert.InDelta(t, want, got, 1e-4)
+			}
+		})
+	}
+}
+
+func TestDifferentiate_userRule(t *testing.T) {
+	rules := Rules()
+	rules["square"] = func(builder Builder, arguments []*ast.Node, derivatives []*ast.Node) (*ast.Node, error) {
+		return builder.Multiply(builder.Multiply(builder.Number(2), arguments[0]), derivatives[0]), nil
+	}
+
+	node := ast.Call("square", 0, ast.Call("sin", 7, ast.Variable("x", 11)))
+
+	got, err := Differentiate(node, "x", rules)
+
+	assert.Equal(t, "2*sin(x)*cos(x)", got.String())
+	assert.NoError(t, err)
+}
+
+func build(t *testing.T, text string) *ast.Node {
+	functions := builtin.Functions()
+	functionNames := map[string]struct{}{}
+	for functionName := range functions {
+		functionNames[functionName] = struct{}{}
+	}
+
+	tokens, err := tokenizer.Tokenize(text)
+	assert.NoError(t, err)
+
+	commands, err := translator.Translate(tokens, functionNames)
+	assert.NoError(t, err)
+
+	node, err := ast.Build(commands, functions)
+	assert.NoError(t, err)
+
+	return node
+}
+
+func evaluate(t *testing.T, node *ast.Node, x float64) float64 {
+	result, err := evaluator.Evaluate(node.Commands(), map[string]float64{"x": x}, builtin.Functions())
+	assert.NoError(t, err)
+	assert.False(t, math.IsNaN(result))
+
+	return result
+}
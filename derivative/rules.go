@@ -0,0 +1,145 @@
+package derivative
+
+import (
+	"errors"
+
+	"github.com/rmaidveo/go-calculator/ast"
+)
+
+var (
+	errNotDifferentiable = errors.New("function is not differentiable")
+)
+
+type Rule func(builder Builder, arguments []*ast.Node, derivatives []*ast.Node) (*ast.Node, error)
+
+func Rules() map[string]Rule {
+	return map[string]Rule{
+		"+": func(builder Builder, arguments []*ast.Node, derivatives []*ast.Node) (*ast.Node, error) {
+			return builder.Add(derivatives[0], derivatives[1]), nil
+		},
+		"-": func(builder Builder, arguments []*ast.Node, derivatives []*ast.Node) (*ast.Node, error) {
+			return builder.Subtract(derivatives[0], derivatives[1]), nil
+		},
+		"*": func(builder Builder, arguments []*ast.Node, derivatives []*ast.Node) (*ast.Node, error) {
+			return builder.Add(
+				builder.Multiply(derivatives[0], arguments[1]),
+				builder.Multiply(arguments[0], derivatives[1]),
+			), nil
+		},
+		"/": func(builder Builder, arguments []*ast.Node, derivatives []*ast.Node) (*ast.Node, error) {
+			if isNumber(derivatives[1], 0) {
+				return builder.Divide(derivatives[0], arguments[1]), nil
+			}
+
+			return builder.Divide(
+				builder.Subtract(
+					builder.Multiply(derivatives[0], arguments[1]),
+					builder.Multiply(arguments[0], derivatives[1]),
+				),
+				builder.Power(arguments[1], builder.Number(2)),
+			), nil
+		},
+		"%": func(builder Builder, arguments []*ast.Node, derivatives []*ast.Node) (*ast.Node, error) {
+			if !isNumber(derivatives[1], 0) {
+				return nil, errNotDifferentiable
+			}
+
+			return derivatives[0], nil
+		},
+		"^":   power,
+		"pow": power,
+		"neg": func(builder Builder, arguments []*ast.Node, derivatives []*ast.Node) (*ast.Node, error) {
+			return builder.Negate(derivatives[0]), nil
+		},
+		"°": func(builder Builder, arguments []*ast.Node, derivatives []*ast.Node) (*ast.Node, error) {
+			return builder.Call("°", derivatives[0]), nil
+		},
+		"percent": func(builder Builder, arguments []*ast.Node, derivatives []*ast.Node) (*ast.Node, error) {
+			return builder.Call("percent", derivatives[0]), nil
+		},
+
+		"abs": chain(func(builder Builder, x *ast.Node) *ast.Node {
+			return builder.Divide(x, builder.Call("abs", x))
+		}),
+		"sqrt": chain(func(builder Builder, x *ast.Node) *ast.Node {
+			return builder.Divide(builder.Number(1), builder.Multiply(builder.Number(2), builder.Call("sqrt", x)))
+		}),
+		"exp": chain(func(builder Builder, x *ast.Node) *ast.Node {
+			return builder.Call("exp", x)
+		}),
+		"log": chain(func(builder Builder, x *ast.Node) *ast.Node {
+			return builder.Divide(builder.Number(1), x)
+		}),
+		"log10": chain(func(builder Builder, x *ast.Node) *ast.Node {
+			return builder.Divide(builder.Number(1), builder.Multiply(x, builder.Call("log", builder.Number(10))))
+		}),
+		"sin": chain(func(builder Builder, x *ast.Node) *ast.Node {
+			return builder.Call("cos", x)
+		}),
+		"cos": chain(func(builder Builder, x *ast.Node) *ast.Node {
+			return builder.Negate(builder.Call("sin", x))
+		}),
+		"tan": chain(func(builder Builder, x *ast.Node) *ast.Node {
+			return builder.Divide(builder.Number(1), builder.Power(builder.Call("cos", x), builder.Number(2)))
+		}),
+		"asin": chain(func(builder Builder, x *ast.Node) *ast.Node {
+			return builder.Divide(
+				builder.Number(1),
+				builder.Call("sqrt", builder.Subtract(builder.Number(1), builder.Power(x, builder.Number(2)))),
+			)
+		}),
+		"acos": chain(func(builder Builder, x *ast.Node) *ast.Node {
+			return builder.Negate(builder.Divide(
+				builder.Number(1),
+				builder.Call("sqrt", builder.Subtract(builder.Number(1), builder.Power(x, builder.Number(2)))),
+			))
+		}),
+		"atan": chain(func(builder Builder, x *ast.Node) *ast.Node {
+			return builder.Divide(builder.Number(1), builder.Add(builder.Number(1), builder.Power(x, builder.Number(2))))
+		}),
+		"atan2": func(builder Builder, arguments []*ast.Node, derivatives []*ast.Node) (*ast.Node, error) {
+			y, x := arguments[0], arguments[1]
+			return builder.Divide(
+				builder.Subtract(builder.Multiply(x, derivatives[0]), builder.Multiply(y, derivatives[1])),
+				builder.Add(builder.Power(x, builder.Number(2)), builder.Power(y, builder.Number(2))),
+			), nil
+		},
+		"floor": constant,
+		"ceil":  constant,
+		"round": constant,
+	}
+}
+
+func chain(outerDerivative func(builder Builder, x *ast.Node) *ast.Node) Rule {
+	return func(builder Builder, arguments []*ast.Node, derivatives []*ast.Node) (*ast.Node, error) {
+		return builder.Multiply(outerDerivative(builder, arguments[0]), derivatives[0]), nil
+	}
+}
+
+func power(builder Builder, arguments []*ast.Node, derivatives []*ast.Node) (*ast.Node, error) {
+	x, y := arguments[0], arguments[1]
+	switch {
+	case isNumber(derivatives[1], 0):
+		return builder.Multiply(
+			builder.Multiply(y, builder.Power(x, builder.Subtract(y, builder.Number(1)))),
+			derivatives[0],
+		), nil
+	case isNumber(derivatives[0], 0):
+		return builder.Multiply(
+			builder.Multiply(builder.Power(x, y), builder.Call("log", x)),
+			derivatives[1],
+		), nil
+	default:
+		return builder.Multiply(
+			builder.Power(x, y),
+			builder.Add(
+				builder.Multiply(derivatives[1], builder.Call("log", x)),
+				builder.Divide(builder.Multiply(y, derivatives[0]), x),
+			),
+		), nil
+	}
+}
+
+func constant(builder Builder, arguments []*ast.Node, derivatives []*ast.Node) (*ast.Node, error) {
+	return builder.Number(0), nil
+}
@@ -0,0 +1,106 @@
+package calculator
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rmaidveo/go-calculator/ast"
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/rmaidveo/go-calculator/derivative"
+	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDerivative(t *testing.T) {
+	type args struct {
+		text     string
+		variable string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success",
+			args:    args{text: "x^2 * sin(x)", variable: "x"},
+			want:    "2*x*sin(x) + x^2*cos(x)",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error/unable to compile",
+			args:    args{text: "x^2 * sin(x", variable: "x"},
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/unable to differentiate",
+			args:    args{text: "min(x, 1)", variable: "x"},
+			want:    "",
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Derivative(tt.args.text, tt.args.variable)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestDerivative_evaluable(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		variable string
+		x        float64
+		want     float64
+	}{
+		{name: "small exponent", text: "x^0.00001", variable: "x", x: 2, want: 0.00001 * math.Pow(2, -0.99999)},
+		{name: "large coefficient", text: "1000000000000000000000000 * x^2", variable: "x", x: 3, want: 6e24},
+		{name: "product", text: "x^2 * sin(x)", variable: "x", x: 1, want: 2*math.Sin(1) + math.Cos(1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, err := Derivative(tt.text, tt.variable)
+			assert.NoError(t, err)
+
+			got, err := Calculate(text, map[string]float64{tt.variable: tt.x}, builtin.Functions())
+
+			assert.InEpsilon(t, tt.want, got, 1e-12)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDerivativeWithRules(t *testing.T) {
+	functions := builtin.Functions()
+	functions["square"] = evaluator.Function{
+		Arity: 1,
+		Pure:  true,
+		Handler: func(arguments []float64) (float64, error) {
+			return arguments[0] * arguments[0], nil
+		},
+	}
+
+	rules := derivative.Rules()
+	rules["square"] = func(
+		builder derivative.Builder,
+		arguments []*ast.Node,
+		derivatives []*ast.Node,
+	) (*ast.Node, error) {
+		return builder.Multiply(builder.Multiply(builder.Number(2), arguments[0]), derivatives[0]), nil
+	}
+
+	node, err := DerivativeWithRules("square(3 * x)", "x", functions, rules)
+	assert.NoError(t, err)
+	assert.Equal(t, "2*(3*x)*3", node.String())
+
+	result, err := Calculate(node.String(), map[string]float64{"x": 2}, functions)
+	assert.NoError(t, err)
+	assert.Equal(t, 36.0, result)
+}
@@ -0,0 +1,371 @@
+package docs
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	calculator "github.com/rmaidveo/go-calculator"
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/rmaidveo/go-calculator/registry"
+	"github.com/rmaidveo/go-calculator/tokenizer"
+	"github.com/rmaidveo/go-calculator/translator"
+)
+
+const (
+	exampleTolerance = 1e-12
+)
+
+type Reference struct {
+	Functions []FunctionReference `json:"functions"`
+	Operators []OperatorReference `json:"operators"`
+	Syntax    []SyntaxReference   `json:"syntax"`
+}
+
+type FunctionReference struct {
+	Name        string             `json:"name"`
+	Aliases     []string           `json:"aliases"`
+	Signature   string             `json:"signature"`
+	Arity       int                `json:"arity"`
+	Category    string             `json:"category"`
+	Description string             `json:"description"`
+	Pure        bool               `json:"pure"`
+	Deprecated  string             `json:"deprecated,omitempty"`
+	Examples    []ExampleReference `json:"examples"`
+}
+
+type OperatorReference struct {
+	Symbol           string             `json:"symbol"`
+	Function         string             `json:"function"`
+	Unary            bool               `json:"unary"`
+	Postfix          bool               `json:"postfix"`
+	Precedence       int                `json:"precedence"`
+	RightAssociative bool               `json:"rightAssociative"`
+	Description      string             `json:"description"`
+	Examples         []ExampleReference `json:"examples"`
+}
+
+type SyntaxReference struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Examples    []ExampleReference `json:"examples"`
+	options     calculator.Options
+}
+
+type ExampleReference struct {
+	Expression string  `json:"expression"`
+	Result     float64 `json:"result"`
+}
+
+var syntax = []SyntaxReference{
+	{
+		Name:        "numbers",
+		Description: "Decimal numbers with an optional fractional part; the integer part may be omitted.",
+		Examples:    []ExampleReference{{Expression: "12", Result: 12}, {Expression: "3.25", Result: 3.25}, {Expression: ".5", Result: 0.5}},
+	},
+	{
+		Name:        "variables",
+		Description: "Identifiers of letters, digits and underscores that do not start with a digit and are not function names.",
+		Examples:    []ExampleReference{{Expression: "x_1 = 2; x_1 * 3", Result: 6}},
+	},
+	{
+		Name:        "function calls",
+		Description: "A function name followed by parenthesized arguments separated by commas.",
+		Examples:    []ExampleReference{{Expression: "max(2, sqrt(16))", Result: 4}},
+	},
+	{
+		Name: "brackets",
+		Description: "Square brackets and braces group like parentheses and must be closed by their own kind; " +
+			"|x| is the absolute value, ⌊x⌋ the floor and ⌈x⌉ the ceiling of x.",
+		Examples: []ExampleReference{{Expression: "[1 + {2 * 3}] * |2 - 3|", Result: 7}, {Expression: "⌊2.5⌋ + ⌈2.5⌉", Result: 5}},
+	},
+	{
+		Name: "unicode aliases",
+		Description: "×, · and ⋅ multiply, ÷ divides, − subtracts or negates, √ takes the square root of the following operand, " +
+			"π stands for the constant pi and requires the mathematical constants, superscript digits raise to a power; ≤, ≥ and ≠ are rejected because comparisons are not supported.",
+		Examples: []ExampleReference{{Expression: "6 × 7 ÷ 2 − √16", Result: 17}, {Expression: "x = 3; x² + 2⁻¹", Result: 9.5}},
+	},
+	{
+		Name: "statements",
+		Description: "Statements are separated by semicolons or newlines; the value of the last statement is the result. " +
+			"A line that starts with a binary operator continues the previous line.",
+		Examples: []ExampleReference{{Expression: "1; 2\n3", Result: 3}, {Expression: "2\n+ 3", Result: 5}},
+	},
+	{
+		Name:        "assignments",
+		Description: "A variable name and an equals sign at the start of a statement assign the value to the variable; assignments can be chained.",
+		Examples:    []ExampleReference{{Expression: "a = b = 2; a + b", Result: 4}},
+	},
+	{
+		Name:        "let bindings",
+		Description: "let name = value in body binds the variable only inside the body.",
+		Examples:    []ExampleReference{{Expression: "x = 1; (let x = 10 in x * 2) + x", Result: 21}},
+	},
+	{
+		Name:        "comments",
+		Description: "Line comments start with # or //, block comments are enclosed in /* and */.",
+		Examples:    []ExampleReference{{Expression: "1 + /* two */ 2 # three", Result: 3}},
+	},
+	{
+		Name: "implicit multiplication (as explicit)",
+		Description: "An opt-in mode where a number, variable, constant or parenthesized group followed by a variable, " +
+			"constant, function call or parenthesized group is multiplied by it; a number cannot follow another operand. " +
+			"The implicit product binds like *, so 1/2x means (1/2)*x.",
+		Examples: []ExampleReference{{Expression: "x = 4; 1/2x", Result: 2}, {Expression: "a = 3; (a + 1)(a - 1)", Result: 8}},
+		options:  calculator.Options{ImplicitMultiplication: translator.ImplicitMultiplicationAsExplicit},
+	},
+	{
+		Name: "implicit multiplication (binds tighter)",
+		Description: "The same opt-in mode, but the implicit product binds tighter than *, / and % and looser than ^, " +
+			"so 1/2x means 1/(2*x) and 2x^2 means 2*(x^2).",
+		Examples: []ExampleReference{{Expression: "x = 4; 1/2x", Result: 0.125}, {Expression: "x = 3; 2x^2", Result: 18}},
+		options:  calculator.Options{ImplicitMultiplication: translator.ImplicitMultiplicationBindsTighter},
+	},
+	{
+		Name: "percent mode",
+		Description: "An opt-in mode where % written after an operand divides it by 100 instead of taking the remainder; " +
+			"like ! and ° it binds tighter than any other operator, so it cannot be followed by another operand.",
+		Examples: []ExampleReference{{Expression: "price = 80; price * (1 - 15%)", Result: 68}},
+		options:  calculator.Options{PostfixPercent: true},
+	},
+}
+
+func Generate(functionRegistry *registry.Registry) (Reference, error) {
+	functions := functionRegistry.Functions()
+	reference := Reference{Syntax: make([]SyntaxReference, 0, len(syntax))}
+	for _, entry := range functionRegistry.Entries() {
+		examples, err := verifyExamples(entry.Examples, functions, calculator.Options{})
+		if err != nil {
+			return Reference{}, fmt.Errorf("unable to verify the examples of %q: %w", entry.Name, err)
+		}
+
+		if operatorTokenKind, ok := tokenizer.ParseOperator(entry.Name); ok {
+			unary := operatorTokenKind == tokenizer.NegationToken
+			symbol := entry.Name
+			if unary {
+				symbol = tokenizer.MinusToken.String()
+			}
+
+			reference.Operators = append(reference.Operators, OperatorReference{
+				Symbol:           symbol,
+				Function:         entry.Name,
+				Unary:            unary,
+				Postfix:          operatorTokenKind.IsPostfix(),
+				Precedence:       operatorTokenKind.Precedence(),
+				RightAssociative: operatorTokenKind.IsRightAssociative(),
+				Description:      entry.Description,
+				Examples:         examples,
+			})
+			continue
+		}
+
+		aliases := entry.Aliases
+		if aliases == nil {
+			aliases = []string{}
+		}
+
+		reference.Functions = append(reference.Functions, FunctionReference{
+			Name:        entry.Name,
+			Aliases:     aliases,
+			Signature:   entry.Signature(),
+			Arity:       entry.Function.Arity,
+			Category:    entry.Category,
+			Description: entry.Description,
+			Pure:        functions[entry.Name].Pure,
+			Deprecated:  entry.Deprecated,
+			Examples:    examples,
+		})
+	}
+	sortOperators(reference.Operators)
+
+	for _, syntaxReference := range syntax {
+		if _, err := verifyExamples(
+			toRegistryExamples(syntaxReference.Examples),
+			builtin.Functions(),
+			syntaxReference.options,
+		); err != nil {
+			return Reference{}, fmt.Errorf("unable to verify the examples of the %s syntax: %w", syntaxReference.Name, err)
+		}
+
+		reference.Syntax = append(reference.Syntax, syntaxReference)
+	}
+
+	return reference, nil
+}
+
+func JSON(reference Reference) ([]byte, error) {
+	content, err := json.MarshalIndent(reference, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal the reference: %w", err)
+	}
+
+	return content, nil
+}
+
+func Markdown(reference Reference) string {
+	var builder strings.Builder
+	builder.WriteString("# Calculator reference\n")
+
+	builder.WriteString("\n## Syntax\n")
+	for _, syntaxReference := range reference.Syntax {
+		fmt.Fprintf(&builder, "\n### %s\n\n%s\n", capitalize(syntaxReference.Name), syntaxReference.Description)
+		writeExamples(&builder, syntaxReference.Examples)
+	}
+
+	builder.WriteString("\n## Operators\n\nOperators with a higher precedence bind tighter.\n\n")
+	builder.WriteString("| Operator | Function | Precedence | Associativity | Description |\n")
+	builder.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, operator := range reference.Operators {
+		associativity := "left"
+		if operator.RightAssociative {
+			associativity = "right"
+		}
+
+		symbol := "`" + operator.Symbol + "`"
+		if operator.Unary {
+			symbol = "unary `-`"
+		}
+		if operator.Postfix {
+			symbol = "postfix " + symbol
+		}
+
+		fmt.Fprintf(
+			&builder,
+			"| %s | `%s` | %d | %s | %s |\n",
+			symbol,
+			operator.Function,
+			operator.Precedence,
+			associativity,
+			escapeTableCell(operator.Description),
+		)
+	}
+
+	builder.WriteString("\n## Functions\n")
+	category := ""
+	for functionIndex, function := range sortFunctionsByCategory(reference.Functions) {
+		if functionIndex == 0 || function.Category != category {
+			category = function.Category
+			title := capitalize(category)
+			if title == "" {
+				title = "Other"
+			}
+
+			fmt.Fprintf(&builder, "\n### %s\n", title)
+		}
+
+		fmt.Fprintf(&builder, "\n#### `%s`\n\n%s\n", function.Signature, function.Description)
+		if len(function.Aliases) != 0 {
+			fmt.Fprintf(&builder, "\nAliases: `%s`.\n", strings.Join(function.Aliases, "`, `"))
+		}
+		if function.Deprecated != "" {
+			fmt.Fprintf(&builder, "\n**Deprecated:** %s\n", function.Deprecated)
+		}
+
+		writeExamples(&builder, function.Examples)
+	}
+
+	return builder.String()
+}
+
+func sortOperators(operators []OperatorReference) {
+	sort.SliceStable(operators, func(i int, j int) bool {
+		if operators[i].Precedence != operators[j].Precedence {
+			return operators[i].Precedence > operators[j].Precedence
+		}
+		if operators[i].Unary != operators[j].Unary {
+			return operators[i].Unary
+		}
+
+		return operators[i].Symbol < operators[j].Symbol
+	})
+}
+
+func sortFunctionsByCategory(functions []FunctionReference) []FunctionReference {
+	sortedFunctions := append([]FunctionReference(nil), functions...)
+	sort.SliceStable(sortedFunctions, func(i int, j int) bool {
+		if sortedFunctions[i].Category != sortedFunctions[j].Category {
+			if sortedFunctions[i].Category == "" || sortedFunctions[j].Category == "" {
+				return sortedFunctions[j].Category == ""
+			}
+
+			return sortedFunctions[i].Category < sortedFunctions[j].Category
+		}
+
+		return sortedFunctions[i].Name < sortedFunctions[j].Name
+	})
+
+	return sortedFunctions
+}
+
+func verifyExamples(
+	examples []registry.Example,
+	functions map[string]evaluator.Function,
+	options calculator.Options,
+) ([]ExampleReference, error) {
+	references := make([]ExampleReference, 0, len(examples))
+	for _, example := range examples {
+		commands, err := calculator.CompileWithOptions(example.Expression, functions, options)
+		if err != nil {
+			return nil, fmt.Errorf("unable to compile the example %q: %w", example.Expression, err)
+		}
+
+		result, err := evaluator.Evaluate(commands, map[string]float64{}, functions)
+		if err != nil {
+			return nil, fmt.Errorf("unable to evaluate the example %q: %w", example.Expression, err)
+		}
+		if !isClose(result, example.Result) {
+			return nil, fmt.Errorf("example %q evaluates to %g, but %g is documented", example.Expression, result, example.Result)
+		}
+
+		references = append(references, ExampleReference{Expression: example.Expression, Result: example.Result})
+	}
+
+	return references, nil
+}
+
+func toRegistryExamples(examples []ExampleReference) []registry.Example {
+	registryExamples := make([]registry.Example, 0, len(examples))
+	for _, example := range examples {
+		registryExamples = append(registryExamples, registry.Example{Expression: example.Expression, Result: example.Result})
+	}
+
+	return registryExamples
+}
+
+func isClose(got float64, want float64) bool {
+	if math.IsNaN(want) {
+		return math.IsNaN(got)
+	}
+	if got == want {
+		return true
+	}
+
+	return math.Abs(got-want) <= exampleTolerance*math.Max(math.Abs(got), math.Abs(want))
+}
+
+func writeExamples(builder *strings.Builder, examples []ExampleReference) {
+	if len(examples) == 0 {
+		return
+	}
+
+	builder.WriteString("\n```\n")
+	for _, example := range examples {
+		fmt.Fprintf(builder, "%s\n# => %g\n", example.Expression, example.Result)
+	}
+	builder.WriteString("```\n")
+}
+
+func escapeTableCell(text string) string {
+	return strings.ReplaceAll(text, "|", `\|`)
+}
+
+func capitalize(text string) string {
+	if text == "" {
+		return ""
+	}
+
+	return strings.ToUpper(text[:1]) + text[1:]
+}
@@ -0,0 +1,269 @@
+package docs
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/rmaidveo/go-calculator/registry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	double := registry.Entry{
+		Name:        "double",
+		Aliases:     []string{"twice"},
+		Parameters:  []string{"x"},
+		Category:    "custom",
+		Description: "Doubles x.",
+		Examples:    []registry.Example{{Expression: "double(2)", Result: 4}},
+		Function: evaluator.Function{
+			Arity:   1,
+			Pure:    true,
+			Handler: func(arguments []float64) (float64, error) { return 2 * arguments[0], nil },
+		},
+	}
+
+	wrongDouble := double
+	wrongDouble.Examples = []registry.Example{{Expression: "double(2)", Result: 5}}
+
+	invalidDouble := double
+	invalidDouble.Examples = []registry.Example{{Expression: "double(2", Result: 4}}
+
+	tests := []struct {
+		name          string
+		entries       []registry.Entry
+		wantFunctions []FunctionReference
+		wantOperators []OperatorReference
+		wantErr       assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			entries: []registry.Entry{
+				double,
+				{
+					Name:        "neg",
+					Parameters:  []string{"x"},
+					Description: "Negates x.",
+					Examples:    []registry.Example{{Expression: "-2", Result: -2}},
+					Function: evaluator.Function{
+						Arity:   1,
+						Handler: func(arguments []float64) (float64, error) { return -arguments[0], nil },
+					},
+				},
+				{
+					Name:        "*",
+					Parameters:  []string{"x", "y"},
+					Description: "Multiplies x by y.",
+					Function: evaluator.Function{
+						Arity:   2,
+						Handler: func(arguments []float64) (float64, error) { return arguments[0] * arguments[1], nil },
+					},
+				},
+			},
+			wantFunctions: []FunctionReference{
+				{
+					Name:        "double",
+					Aliases:     []string{"twice"},
+					Signature:   "double(x)",
+					Arity:       1,
+					Category:    "custom",
+					Description: "Doubles x.",
+					Pure:        true,
+					Examples:    []ExampleReference{{Expression: "double(2)", Result: 4}},
+				},
+			},
+			wantOperators: []OperatorReference{
+				{
+					Symbol:           "-",
+					Function:         "neg",
+					Unary:            true,
+					Precedence:       4,
+					RightAssociative: true,
+					Description:      "Negates x.",
+					Examples:         []ExampleReference{{Expression: "-2", Result: -2}},
+				},
+				{
+					Symbol:      "*",
+					Function:    "*",
+					Precedence:  2,
+					Description: "Multiplies x by y.",
+					Examples:    []ExampleReference{},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:          "error/wrong example result",
+			entries:       []registry.Entry{wrongDouble},
+			wantFunctions: nil,
+			wantOperators: nil,
+			wantErr:       assert.Error,
+		},
+		{
+			name:          "error/invalid example",
+			entries:       []registry.Entry{invalidDouble},
+			wantFunctions: nil,
+			wantOperators: nil,
+			wantErr:       assert.Error,
+		},
+		{
+			name:          "success/empty registry",
+			entries:       []registry.Entry{},
+			wantFunctions: nil,
+			wantOperators: nil,
+			wantErr:       assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			functionRegistry := registry.New(registry.Options{})
+			for _, entry := range tt.entries {
+				assert.NoError(t, functionRegistry.Register(entry))
+			}
+
+			got, err := Generate(functionRegistry)
+
+			assert.Equal(t, tt.wantFunctions, got.Functions)
+			assert.Equal(t, tt.wantOperators, got.Operators)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestGenerate_builtin(t *testing.T) {
+	functionRegistry := builtin.Registry()
+
+	got, err := Generate(functionRegistry)
+
+	assert.NoError(t, err)
+	assert.Len(t, got.Functions, len(functionRegistry.Entries())-len(got.Operators))
+	assert.Len(t, got.Operators, 9)
+	assert.Len(t, got.Syntax, len(syntax))
+	for operatorIndex := 1; operatorIndex < len(got.Operators); operatorIndex++ {
+		assert.GreaterOrEqual(t, got.Operators[operatorIndex-1].Precedence, got.Operators[operatorIndex].Precedence)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	reference := Reference{
+		Functions: []FunctionReference{
+			{
+				Name:      "double",
+				Aliases:   []string{},
+				Signature: "double(x)",
+				Arity:     1,
+				Examples:  []ExampleReference{{Expression: "double(2)", Result: 4}},
+			},
+		},
+		Operators: []OperatorReference{{Symbol: "+", Function: "+", Precedence: 1, Examples: []ExampleReference{}}},
+		Syntax:    []SyntaxReference{{Name: "numbers", Examples: []ExampleReference{{Expression: "1", Result: 1}}}},
+	}
+
+	got, err := JSON(reference)
+
+	assert.NoError(t, err)
+
+	var gotReference Reference
+	assert.NoError(t, json.Unmarshal(got, &gotReference))
+	assert.Equal(t, reference, gotReference)
+	assert.Contains(t, string(got), `"signature": "double(x)"`)
+	assert.NotContains(t, string(got), `"deprecated"`)
+}
+
+func TestMarkdown(t *testing.T) {
+	reference := Reference{
+		Functions: []FunctionReference{
+			{
+				Name:        "old",
+				Signature:   "old()",
+				Category:    "custom",
+				Description: "Returns one.",
+				Deprecated:  "use one instead",
+			},
+			{
+				Name:        "double",
+				Aliases:     []string{"twice", "x2"},
+				Signature:   "double(x)",
+				Category:    "custom",
+				Description: "Doubles x.",
+				Examples:    []ExampleReference{{Expression: "double(2)", Result: 4}},
+			},
+			{
+				Name:        "id",
+				Signature:   "id(x)",
+				Description: "Returns x.",
+			},
+		},
+		Operators: []OperatorReference{
+			{Symbol: "-", Function: "neg", Unary: true, Precedence: 4, RightAssociative: true, Description: "Negates x."},
+			{Symbol: "!", Function: "!", Postfix: true, Precedence: 5, Description: "Returns x!."},
+			{Symbol: "|", Function: "|", Precedence: 1, Description: "Returns x | y."},
+		},
+		Syntax: []SyntaxReference{
+			{
+				Name:        "numbers",
+				Description: "Decimal numbers.",
+				Examples:    []ExampleReference{{Expression: ".5", Result: 0.5}},
+			},
+		},
+	}
+
+	got := Markdown(reference)
+
+	want := strings.Join([]string{
+		"# Calculator reference",
+		"",
+		"## Syntax",
+		"",
+		"### Numbers",
+		"",
+		"Decimal numbers.",
+		"",
+		"```",
+		".5",
+		"# => 0.5",
+		"```",
+		"",
+		"## Operators",
+		"",
+		"Operators with a higher precedence bind tighter.",
+		"",
+		"| Operator | Function | Precedence | Associativity | Description |",
+		"| --- | --- | --- | --- | --- |",
+		"| unary `-` | `neg` | 4 | right | Negates x. |",
+		"| postfix `!` | `!` | 5 | left | Returns x!. |",
+		"| `|` | `|` | 1 | left | Returns x \\| y. |",
+		"",
+		"## Functions",
+		"",
+		"### Custom",
+		"",
+		"#### `double(x)`",
+		"",
+		"Doubles x.",
+		"",
+		"Aliases: `twice`, `x2`.",
+		"",
+		"```",
+		"double(2)",
+		"# => 4",
+		"```",
+		"",
+		"#### `old()`",
+		"",
+		"Returns one.",
+		"",
+		"**Deprecated:** use one instead",
+		"",
+		"### Other",
+		"",
+		"#### `id(x)`",
+		"",
+		"Returns x.",
+		"",
+	}, "\n")
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,83 @@
+package calculator
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/rmaidveo/go-calculator/translator"
+)
+
+type Formula struct {
+	Name     string
+	Commands []translator.Command
+}
+
+type FormulaResult struct {
+	Name  string
+	Value float64
+	Err   error
+}
+
+func EvaluateAll(
+	ctx context.Context,
+	formulas []Formula,
+	variables map[string]float64,
+	functions map[string]evaluator.Function,
+	workers int,
+) ([]FormulaResult, error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	results := make([]FormulaResult, len(formulas))
+	formulaIndexes := make(chan int)
+	var waitGroup sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+
+			for formulaIndex := range formulaIndexes {
+				formula := formulas[formulaIndex]
+				result, err := evaluator.Evaluate(formula.Commands, variables, functions)
+				if err != nil {
+					err = fmt.Errorf("unable to evaluate the formula %q: %w", formula.Name, err)
+				}
+
+				results[formulaIndex] = FormulaResult{Name: formula.Name, Value: result, Err: err}
+			}
+		}()
+	}
+
+	dispatchedCount := 0
+dispatching:
+	for dispatchedCount < len(formulas) {
+		select {
+		case <-ctx.Done():
+			break dispatching
+		default:
+		}
+
+		select {
+		case formulaIndexes <- dispatchedCount:
+			dispatchedCount++
+		case <-ctx.Done():
+			break dispatching
+		}
+	}
+	close(formulaIndexes)
+	waitGroup.Wait()
+
+	if dispatchedCount < len(formulas) {
+		for formulaIndex := dispatchedCount; formulaIndex < len(formulas); formulaIndex++ {
+			results[formulaIndex] = FormulaResult{Name: formulas[formulaIndex].Name, Err: ctx.Err()}
+		}
+
+		return results, ctx.Err()
+	}
+
+	return results, nil
+}
@@ -0,0 +1,141 @@
+package calculator
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateAll(t *testing.T) {
+	functions := builtin.Functions()
+	variables := map[string]float64{"x": 2, "y": 3}
+
+	type args struct {
+		texts   []string
+		workers int
+	}
+
+	tests := []struct {
+		name       string
+		args       args
+		wantValues []float64
+		wantErrs   []bool
+	}{
+		{
+			name:       "success/sequential",
+			args:       args{texts: []string{"x + y", "x * y", "y ^ x"}, workers: 1},
+			wantValues: []float64{5, 6, 9},
+			wantErrs:   []bool{false, false, false},
+		},
+		{
+			name:       "success/parallel with errors",
+			args:       args{texts: []string{"x / 0", "z = x + y; z * 2", "unknown + 1", "x - y"}, workers: 3},
+			wantValues: []float64{0, 10, 0, -1},
+			wantErrs:   []bool{true, false, true, false},
+		},
+		{
+			name:       "success/default number of workers",
+			args:       args{texts: []string{"sqrt(x * 8)"}, workers: 0},
+			wantValues: []float64{4},
+			wantErrs:   []bool{false},
+		},
+		{
+			name:       "success/no formulas",
+			args:       args{texts: nil, workers: 2},
+			wantValues: []float64{},
+			wantErrs:   []bool{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formulas := make([]Formula, 0, len(tt.args.texts))
+			for textIndex, text := range tt.args.texts {
+				commands, err := Compile(text, functions)
+				assert.NoError(t, err)
+
+				formulas = append(formulas, Formula{Name: fmt.Sprintf("formula%d", textIndex), Commands: commands})
+			}
+
+			got, err := EvaluateAll(context.Background(), formulas, variables, functions, tt.args.workers)
+
+			assert.NoError(t, err)
+			gotValues := make([]float64, 0, len(got))
+			gotErrs := make([]bool, 0, len(got))
+			for resultIndex, result := range got {
+				assert.Equal(t, formulas[resultIndex].Name, result.Name)
+				gotValues = append(gotValues, result.Value)
+				gotErrs = append(gotErrs, result.Err != nil)
+			}
+			assert.Equal(t, tt.wantValues, gotValues)
+			assert.Equal(t, tt.wantErrs, gotErrs)
+		})
+	}
+}
+
+func TestEvaluateAll_manyFormulas(t *testing.T) {
+	functions := builtin.Functions()
+	variables := map[string]float64{"x": 1.5}
+
+	formulas := make([]Formula, 0, 5000)
+	for formulaIndex := 0; formulaIndex < cap(formulas); formulaIndex++ {
+		commands, err := Compile(fmt.Sprintf("let y = x * %d in y + %d; y = 1", formulaIndex, formulaIndex), functions)
+		assert.NoError(t, err)
+
+		formulas = append(formulas, Formula{Name: fmt.Sprint(formulaIndex), Commands: commands})
+	}
+
+	got, err := EvaluateAll(context.Background(), formulas, variables, functions, 8)
+
+	assert.NoError(t, err)
+	assert.Len(t, got, len(formulas))
+	for resultIndex, result := range got {
+		assert.Equal(t, fmt.Sprint(resultIndex), result.Name)
+		assert.Equal(t, 1.0, result.Value)
+		assert.NoError(t, result.Err)
+	}
+	assert.Equal(t, map[string]float64{"x": 1.5}, variables)
+}
+
+func TestEvaluateAll_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var callCount int32
+	functions := map[string]evaluator.Function{
+		"stop": {
+			Arity: 0,
+			Handler: func(arguments []float64) (float64, error) {
+				if atomic.AddInt32(&callCount, 1) == 3 {
+					cancel()
+				}
+
+				return 1, nil
+			},
+		},
+	}
+
+	formulas := make([]Formula, 0, 100)
+	for formulaIndex := 0; formulaIndex < cap(formulas); formulaIndex++ {
+		commands, err := Compile("stop()", functions)
+		assert.NoError(t, err)
+
+		formulas = append(formulas, Formula{Name: fmt.Sprint(formulaIndex), Commands: commands})
+	}
+
+	got, err := EvaluateAll(ctx, formulas, map[string]float64{}, functions, 1)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, got, len(formulas))
+	assert.NoError(t, got[0].Err)
+	assert.ErrorIs(t, got[len(got)-1].Err, context.Canceled)
+	for _, result := range got {
+		if result.Err == nil {
+			assert.Equal(t, 1.0, result.Value)
+		}
+	}
+}
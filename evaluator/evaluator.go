@@ -10,43 +10,143 @@ import (
 )
 
 type Function struct {
-	Arity   int
-	Handler func(arguments []float64) (float64, error)
+	Arity       int
+	Pure        bool
+	Identity    *Identity
+	SelfInverse bool
+	Handler     func(arguments []float64) (float64, error)
+	Derivative  func(arguments []float64) ([]float64, error)
 }
 
+// an identity element of a binary function, e.g. 0 for addition;
+// it is always an identity on the right and also on the left for commutative functions
+type Identity struct {
+	Value       float64
+	Commutative bool
+}
+
+type TraceEvent struct {
+	Command     translator.Command
+	StackBefore []float64
+	StackAfter  []float64
+	Arguments   []float64
+	Value       float64
+}
+
+type Tracer func(event TraceEvent)
+
 func Evaluate(
 	commands []translator.Command,
 	variables map[string]float64,
 	functions map[string]Function,
 ) (float64, error) {
-	var numberStack containers.Stack[float64]
+	result, _, err := evaluate(commands, variables, functions, nil)
+	return result, err
+}
+
+func EvaluateWithVariables(
+	commands []translator.Command,
+	variables map[string]float64,
+	functions map[string]Function,
+) (float64, map[string]float64, error) {
+	return EvaluateWithTracer(commands, variables, functions, nil)
+}
+
+func EvaluateWithTracer(
+	commands []translator.Command,
+	variables map[string]float64,
+	functions map[string]Function,
+	tracer Tracer,
+) (float64, map[string]float64, error) {
+	result, variableScope, err := evaluate(commands, variables, functions, tracer)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return result, variableScope.snapshot(), nil
+}
+
+func evaluate(
+	commands []translator.Command,
+	variables map[string]float64,
+	functions map[string]Function,
+	tracer Tracer,
+) (float64, scope[float64], error) {
+	var trace func(event traceEvent[float64])
+	if tracer != nil {
+		trace = func(event traceEvent[float64]) {
+			tracer(TraceEvent(event))
+		}
+	}
+
+	return run(commands, variables, functions, arithmetic[float64]{
+		number: func(number float64) float64 {
+			return number
+		},
+		call: func(function Function, arguments []float64) (float64, error) {
+			return function.Handler(arguments)
+		},
+		trace: trace,
+	})
+}
+
+type arithmetic[T any] struct {
+	number func(number float64) T
+	call   func(function Function, arguments []T) (T, error)
+	trace  func(event traceEvent[T])
+}
+
+type traceEvent[T any] struct {
+	Command     translator.Command
+	StackBefore []T
+	StackAfter  []T
+	Arguments   []T
+	Value       T
+}
+
+func run[T any](
+	commands []translator.Command,
+	variables map[string]T,
+	functions map[string]Function,
+	arithmetic arithmetic[T],
+) (T, scope[T], error) {
+	var zero T
+	var numberStack containers.Stack[T]
+	variableScope := newScope(variables)
 	for _, command := range commands {
+		var event traceEvent[T]
+		if arithmetic.trace != nil {
+			event = traceEvent[T]{Command: command, StackBefore: numberStack.Elements()}
+		}
+
 		switch command.Kind {
 		case translator.PushNumberCommand:
 			number, err := strconv.ParseFloat(command.Operand, 64)
 			if err != nil {
-				return 0, fmt.Errorf("unable to parse the number at position %d: %w", command.Position, err)
+				return zero, scope[T]{}, fmt.Errorf("unable to parse the number at position %d: %w", command.Position, err)
 			}
 
-			numberStack.Push(number)
+			event.Value = arithmetic.number(number)
+			numberStack.Push(event.Value)
 		case translator.PushVariableCommand:
-			number, ok := variables[command.Operand]
+			number, ok := variableScope.get(command.Operand)
 			if !ok {
-				return 0, fmt.Errorf("unknown variable %q at position %d", command.Operand, command.Position)
+				return zero, scope[T]{}, fmt.Errorf("unknown variable %q at position %d", command.Operand, command.Position)
 			}
 
 			numberStack.Push(number)
+			event.Value = number
 		case translator.CallFunctionCommand:
 			function, ok := functions[command.Operand]
 			if !ok {
-				return 0, fmt.Errorf("unknown function %q at position %d", command.Operand, command.Position)
+				return zero, scope[T]{}, fmt.Errorf("unknown function %q at position %d", command.Operand, command.Position)
 			}
 
-			var arguments []float64
+			var arguments []T
 			for argumentIndex := 0; argumentIndex < function.Arity; argumentIndex++ {
 				number, ok := numberStack.Pop()
 				if !ok {
-					return 0, fmt.Errorf("number stack is empty for argument #%d at position %d", argumentIndex, command.Position)
+					return zero, scope[T]{}, fmt.Errorf("number stack is empty for argument #%d at position %d", argumentIndex, command.Position)
 				}
 
 				arguments = append(arguments, number)
@@ -54,21 +154,62 @@ func Evaluate(
 
 			reverseSlice(arguments)
 
-			number, err := function.Handler(arguments)
+			number, err := arithmetic.call(function, arguments)
 			if err != nil {
-				return 0, fmt.Errorf("unable to call the function %q at position %d: %w", command.Operand, command.Position, err)
+				return zero, scope[T]{}, fmt.Errorf("unable to call the function %q at position %d: %w", command.Operand, command.Position, err)
 			}
 
 			numberStack.Push(number)
+			event.Arguments = arguments
+			event.Value = number
+		case translator.AssignVariableCommand:
+			number, ok := numberStack.Pop()
+			if !ok {
+				return zero, scope[T]{}, fmt.Errorf("number stack is empty for the assignment to %q at position %d", command.Operand, command.Position)
+			}
+
+			variableScope.set(command.Operand, number)
+			numberStack.Push(number)
+			event.Value = number
+		case translator.BindVariableCommand:
+			number, ok := numberStack.Pop()
+			if !ok {
+				return zero, scope[T]{}, fmt.Errorf("number stack is empty for the binding of %q at position %d", command.Operand, command.Position)
+			}
+
+			variableScope.bind(command.Operand, number)
+			event.Value = number
+		case translator.UnbindVariableCommand:
+			if !variableScope.unbind(command.Operand) {
+				return zero, scope[T]{}, fmt.Errorf("no binding of %q is found at position %d", command.Operand, command.Position)
+			}
+		case translator.PopCommand:
+			number, ok := numberStack.Pop()
+			if !ok {
+				return zero, scope[T]{}, fmt.Errorf("number stack is empty for the statement ending at position %d", command.Position)
+			}
+			if !numberStack.IsEmpty() {
+				return zero, scope[T]{}, fmt.Errorf("number stack has extra values for the statement ending at position %d", command.Position)
+			}
+
+			event.Value = number
+		}
+
+		if arithmetic.trace != nil {
+			event.StackAfter = numberStack.Elements()
+			arithmetic.trace(event)
 		}
 	}
 
 	result, ok := numberStack.Pop()
 	if !ok {
-		return 0, errors.New("number stack is empty")
+		return zero, scope[T]{}, errors.New("number stack is empty")
+	}
+	if !numberStack.IsEmpty() {
+		return zero, scope[T]{}, errors.New("number stack has extra values")
 	}
 
-	return result, nil
+	return result, variableScope, nil
 }
 
 func reverseSlice[T any](slice []T) {
@@ -66,6 +66,45 @@ func TestEvaluate(t *testing.T) {
 			want:    65,
 			wantErr: assert.NoError,
 		},
+		{
+			name: "success/assign variable",
+			args: args{
+				commands: []translator.Command{
+					{Kind: translator.PushNumberCommand, Operand: "23", Position: 142},
+					{Kind: translator.AssignVariableCommand, Operand: "x", Position: 140},
+					{Kind: translator.PopCommand, Position: 145},
+					{Kind: translator.PushVariableCommand, Operand: "x", Position: 150},
+				},
+				variables: map[string]float64{},
+				functions: map[string]Function{},
+			},
+			want:    23,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/bind variable",
+			args: args{
+				commands: []translator.Command{
+					{Kind: translator.PushNumberCommand, Operand: "23", Position: 142},
+					{Kind: translator.BindVariableCommand, Operand: "x", Position: 140},
+					{Kind: translator.PushVariableCommand, Operand: "x", Position: 150},
+					{Kind: translator.UnbindVariableCommand, Operand: "x", Position: 140},
+					{Kind: translator.PushVariableCommand, Operand: "x", Position: 160},
+					{Kind: translator.CallFunctionCommand, Operand: "+", Position: 155},
+				},
+				variables: map[string]float64{"x": 42},
+				functions: map[string]Function{
+					"+": {
+						Arity: 2,
+						Handler: func(arguments []float64) (float64, error) {
+							return arguments[0] + arguments[1], nil
+						},
+					},
+				},
+			},
+			want:    65,
+			wantErr: assert.NoError,
+		},
 		{
 			name: "error/push number",
 			args: args{
@@ -145,6 +184,84 @@ func TestEvaluate(t *testing.T) {
 			want:    0,
 			wantErr: assert.Error,
 		},
+		{
+			name: "error/assign variable/number stack is empty",
+			args: args{
+				commands: []translator.Command{
+					{Kind: translator.AssignVariableCommand, Operand: "x", Position: 140},
+				},
+				variables: map[string]float64{},
+				functions: map[string]Function{},
+			},
+			want:    0,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/bind variable/number stack is empty",
+			args: args{
+				commands: []translator.Command{
+					{Kind: translator.BindVariableCommand, Operand: "x", Position: 140},
+				},
+				variables: map[string]float64{},
+				functions: map[string]Function{},
+			},
+			want:    0,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/unbind variable/no binding",
+			args: args{
+				commands: []translator.Command{
+					{Kind: translator.PushNumberCommand, Operand: "23", Position: 142},
+					{Kind: translator.UnbindVariableCommand, Operand: "x", Position: 140},
+				},
+				variables: map[string]float64{},
+				functions: map[string]Function{},
+			},
+			want:    0,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/pop/number stack is empty",
+			args: args{
+				commands: []translator.Command{
+					{Kind: translator.PopCommand, Position: 140},
+					{Kind: translator.PushNumberCommand, Operand: "23", Position: 142},
+				},
+				variables: map[string]float64{},
+				functions: map[string]Function{},
+			},
+			want:    0,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/pop/extra values",
+			args: args{
+				commands: []translator.Command{
+					{Kind: translator.PushNumberCommand, Operand: "12", Position: 140},
+					{Kind: translator.PushNumberCommand, Operand: "23", Position: 143},
+					{Kind: translator.PopCommand, Position: 145},
+					{Kind: translator.PushNumberCommand, Operand: "42", Position: 147},
+				},
+				variables: map[string]float64{},
+				functions: map[string]Function{},
+			},
+			want:    0,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/extra values",
+			args: args{
+				commands: []translator.Command{
+					{Kind: translator.PushNumberCommand, Operand: "12", Position: 140},
+					{Kind: translator.PushNumberCommand, Operand: "23", Position: 143},
+				},
+				variables: map[string]float64{},
+				functions: map[string]Function{},
+			},
+			want:    0,
+			wantErr: assert.Error,
+		},
 		{
 			name: "error/no commands",
 			args: args{
@@ -165,3 +282,121 @@ func TestEvaluate(t *testing.T) {
 		})
 	}
 }
+
+func TestEvaluateWithVariables(t *testing.T) {
+	type args struct {
+		commands  []translator.Command
+		variables map[string]float64
+		functions map[string]Function
+	}
+
+	tests := []struct {
+		name          string
+		args          args
+		want          float64
+		wantVariables map[string]float64
+		wantErr       assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success/without assignments",
+			args: args{
+				commands: []translator.Command{
+					{Kind: translator.PushVariableCommand, Operand: "x", Position: 142},
+				},
+				variables: map[string]float64{"x": 23},
+				functions: map[string]Function{},
+			},
+			want:          23,
+			wantVariables: map[string]float64{"x": 23},
+			wantErr:       assert.NoError,
+		},
+		{
+			name: "success/with assignments",
+			args: args{
+				commands: []translator.Command{
+					{Kind: translator.PushNumberCommand, Operand: "42", Position: 142},
+					{Kind: translator.AssignVariableCommand, Operand: "y", Position: 140},
+				},
+				variables: map[string]float64{"x": 23},
+				functions: map[string]Function{},
+			},
+			want:          42,
+			wantVariables: map[string]float64{"x": 23, "y": 42},
+			wantErr:       assert.NoError,
+		},
+		{
+			name: "success/with bindings",
+			args: args{
+				commands: []translator.Command{
+					{Kind: translator.PushNumberCommand, Operand: "42", Position: 142},
+					{Kind: translator.BindVariableCommand, Operand: "y", Position: 140},
+					{Kind: translator.PushVariableCommand, Operand: "y", Position: 150},
+					{Kind: translator.UnbindVariableCommand, Operand: "y", Position: 140},
+				},
+				variables: map[string]float64{"x": 23},
+				functions: map[string]Function{},
+			},
+			want:          42,
+			wantVariables: map[string]float64{"x": 23},
+			wantErr:       assert.NoError,
+		},
+		{
+			name: "error",
+			args: args{
+				commands:  []translator.Command{},
+				variables: map[string]float64{"x": 23},
+				functions: map[string]Function{},
+			},
+			want:          0,
+			wantVariables: nil,
+			wantErr:       assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			originalVariables := copyVariables(tt.args.variables)
+			got, gotVariables, err := EvaluateWithVariables(tt.args.commands, tt.args.variables, tt.args.functions)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantVariables, gotVariables)
+			assert.Equal(t, originalVariables, tt.args.variables)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestEvaluateWithTracer(t *testing.T) {
+	commands := []translator.Command{
+		{Kind: translator.PushNumberCommand, Operand: "2", Position: 4},
+		{Kind: translator.PushVariableCommand, Operand: "y", Position: 8},
+		{Kind: translator.CallFunctionCommand, Operand: "*", Position: 6},
+		{Kind: translator.AssignVariableCommand, Operand: "x", Position: 0},
+		{Kind: translator.PopCommand, Position: 9},
+		{Kind: translator.PushVariableCommand, Operand: "x", Position: 11},
+	}
+	functions := map[string]Function{
+		"*": {
+			Arity: 2,
+			Handler: func(arguments []float64) (float64, error) {
+				return arguments[0] * arguments[1], nil
+			},
+		},
+	}
+
+	var events []TraceEvent
+	got, gotVariables, err := EvaluateWithTracer(commands, map[string]float64{"y": 3}, functions, func(event TraceEvent) {
+		events = append(events, event)
+	})
+
+	assert.Equal(t, 6.0, got)
+	assert.Equal(t, map[string]float64{"x": 6, "y": 3}, gotVariables)
+	assert.NoError(t, err)
+	assert.Equal(t, []TraceEvent{
+		{Command: commands[0], StackBefore: []float64{}, StackAfter: []float64{2}, Value: 2},
+		{Command: commands[1], StackBefore: []float64{2}, StackAfter: []float64{2, 3}, Value: 3},
+		{Command: commands[2], StackBefore: []float64{2, 3}, StackAfter: []float64{6}, Arguments: []float64{2, 3}, Value: 6},
+		{Command: commands[3], StackBefore: []float64{6}, StackAfter: []float64{6}, Value: 6},
+		{Command: commands[4], StackBefore: []float64{6}, StackAfter: []float64{}, Value: 6},
+		{Command: commands[5], StackBefore: []float64{}, StackAfter: []float64{6}, Value: 6},
+	}, events)
+}
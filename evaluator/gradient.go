@@ -0,0 +1,98 @@
+package evaluator
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rmaidveo/go-calculator/translator"
+)
+
+type dualNumber struct {
+	value   float64
+	tangent []float64
+}
+
+func EvaluateGradient(
+	commands []translator.Command,
+	variables map[string]float64,
+	functions map[string]Function,
+	gradientVariables []string,
+) (float64, map[string]float64, error) {
+	dualVariables := make(map[string]dualNumber, len(variables))
+	for name, value := range variables {
+		dualVariables[name] = dualNumber{value: value}
+	}
+	for gradientIndex, name := range gradientVariables {
+		value, ok := variables[name]
+		if !ok {
+			return 0, nil, fmt.Errorf("unknown gradient variable %q", name)
+		}
+
+		tangent := make([]float64, len(gradientVariables))
+		tangent[gradientIndex] = 1
+
+		dualVariables[name] = dualNumber{value: value, tangent: tangent}
+	}
+
+	result, _, err := run(commands, dualVariables, functions, arithmetic[dualNumber]{
+		number: func(number float64) dualNumber {
+			return dualNumber{value: number}
+		},
+		call: func(function Function, arguments []dualNumber) (dualNumber, error) {
+			return callDual(function, arguments, len(gradientVariables))
+		},
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	gradient := make(map[string]float64, len(gradientVariables))
+	for gradientIndex, name := range gradientVariables {
+		if result.tangent != nil {
+			gradient[name] = result.tangent[gradientIndex]
+		} else {
+			gradient[name] = 0
+		}
+	}
+
+	return result.value, gradient, nil
+}
+
+func callDual(function Function, arguments []dualNumber, gradientSize int) (dualNumber, error) {
+	values := make([]float64, 0, len(arguments))
+	hasTangent := false
+	for _, argument := range arguments {
+		values = append(values, argument.value)
+		hasTangent = hasTangent || argument.tangent != nil
+	}
+
+	value, err := function.Handler(values)
+	if err != nil {
+		return dualNumber{}, err
+	}
+	if !hasTangent {
+		return dualNumber{value: value}, nil
+	}
+	if function.Derivative == nil {
+		return dualNumber{}, errors.New("function has no derivative")
+	}
+
+	partialDerivatives, err := function.Derivative(values)
+	if err != nil {
+		return dualNumber{}, fmt.Errorf("unable to calculate the derivative: %w", err)
+	}
+	if len(partialDerivatives) != len(arguments) {
+		return dualNumber{}, fmt.Errorf("derivative has %d partial derivatives for %d arguments", len(partialDerivatives), len(arguments))
+	}
+
+	tangent := make([]float64, gradientSize)
+	for argumentIndex, argument := range arguments {
+		for gradientIndex, component := range argument.tangent {
+			if component != 0 {
+				tangent[gradientIndex] += partialDerivatives[argumentIndex] * component
+			}
+		}
+	}
+
+	return dualNumber{value: value, tangent: tangent}, nil
+}
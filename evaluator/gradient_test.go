@@ -0,0 +1,223 @@
+package evaluator
+
+import (
+	"testing"
+
+	"github.com/rmaidveo/go-calculator/translator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateGradient(t *testing.T) {
+	type args struct {
+		commands          []translator.Command
+		variables         map[string]float64
+		functions         map[string]Function
+		gradientVariables []string
+	}
+
+	functions := map[string]Function{
+		"*": {
+			Arity: 2,
+			Handler: func(arguments []float64) (float64, error) {
+				return arguments[0] * arguments[1], nil
+			},
+			Derivative: func(arguments []float64) ([]float64, error) {
+				return []float64{arguments[1], arguments[0]}, nil
+			},
+		},
+		"+": {
+			Arity: 2,
+			Handler: func(arguments []float64) (float64, error) {
+				return arguments[0] + arguments[1], nil
+			},
+			Derivative: func(arguments []float64) ([]float64, error) {
+				return []float64{1, 1}, nil
+			},
+		},
+		"floor": {
+			Arity: 1,
+			Handler: func(arguments []float64) (float64, error) {
+				return float64(int(arguments[0])), nil
+			},
+		},
+		"broken": {
+			Arity: 1,
+			Handler: func(arguments []float64) (float64, error) {
+				return arguments[0], nil
+			},
+			Derivative: func(arguments []float64) ([]float64, error) {
+				return []float64{1, 2}, nil
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		args         args
+		want         float64
+		wantGradient map[string]float64
+		wantErr      assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success/number",
+			args: args{
+				commands: []translator.Command{
+					{Kind: translator.PushNumberCommand, Operand: "23", Position: 42},
+				},
+				variables:         map[string]float64{"x": 2},
+				functions:         functions,
+				gradientVariables: []string{"x"},
+			},
+			want:         23,
+			wantGradient: map[string]float64{"x": 0},
+			wantErr:      assert.NoError,
+		},
+		{
+			name: "success/variable",
+			args: args{
+				commands: []translator.Command{
+					{Kind: translator.PushVariableCommand, Operand: "x", Position: 42},
+				},
+				variables:         map[string]float64{"x": 2, "y": 3},
+				functions:         functions,
+				gradientVariables: []string{"x", "y"},
+			},
+			want:         2,
+			wantGradient: map[string]float64{"x": 1, "y": 0},
+			wantErr:      assert.NoError,
+		},
+		{
+			name: "success/chain rule",
+			args: args{
+				commands: []translator.Command{
+					{Kind: translator.PushVariableCommand, Operand: "x", Position: 0},
+					{Kind: translator.PushVariableCommand, Operand: "x", Position: 2},
+					{Kind: translator.CallFunctionCommand, Operand: "*", Position: 1},
+					{Kind: translator.PushVariableCommand, Operand: "y", Position: 6},
+					{Kind: translator.CallFunctionCommand, Operand: "*", Position: 5},
+					{Kind: translator.PushVariableCommand, Operand: "z", Position: 10},
+					{Kind: translator.CallFunctionCommand, Operand: "+", Position: 8},
+				},
+				variables:         map[string]float64{"x": 2, "y": 3, "z": 4},
+				functions:         functions,
+				gradientVariables: []string{"x", "y"},
+			},
+			want:         16,
+			wantGradient: map[string]float64{"x": 12, "y": 4},
+			wantErr:      assert.NoError,
+		},
+		{
+			name: "success/function without derivative on constants",
+			args: args{
+				commands: []translator.Command{
+					{Kind: translator.PushVariableCommand, Operand: "y", Position: 6},
+					{Kind: translator.CallFunctionCommand, Operand: "floor", Position: 0},
+					{Kind: translator.PushVariableCommand, Operand: "x", Position: 11},
+					{Kind: translator.CallFunctionCommand, Operand: "*", Position: 9},
+				},
+				variables:         map[string]float64{"x": 2, "y": 3.5},
+				functions:         functions,
+				gradientVariables: []string{"x"},
+			},
+			want:         6,
+			wantGradient: map[string]float64{"x": 3},
+			wantErr:      assert.NoError,
+		},
+		{
+			name: "success/assignment and let binding",
+			args: args{
+				commands: []translator.Command{
+					{Kind: translator.PushVariableCommand, Operand: "x", Position: 4},
+					{Kind: translator.PushVariableCommand, Operand: "x", Position: 8},
+					{Kind: translator.CallFunctionCommand, Operand: "*", Position: 6},
+					{Kind: translator.AssignVariableCommand, Operand: "y", Position: 0},
+					{Kind: translator.PopCommand, Position: 9},
+					{Kind: translator.PushVariableCommand, Operand: "y", Position: 19},
+					{Kind: translator.BindVariableCommand, Operand: "z", Position: 15},
+					{Kind: translator.PushVariableCommand, Operand: "z", Position: 24},
+					{Kind: translator.PushVariableCommand, Operand: "x", Position: 28},
+					{Kind: translator.CallFunctionCommand, Operand: "*", Position: 26},
+					{Kind: translator.UnbindVariableCommand, Operand: "z", Position: 15},
+				},
+				variables:         map[string]float64{"x": 3},
+				functions:         functions,
+				gradientVariables: []string{"x"},
+			},
+			want:         27,
+			wantGradient: map[string]float64{"x": 27},
+			wantErr:      assert.NoError,
+		},
+		{
+			name: "error/unknown gradient variable",
+			args: args{
+				commands: []translator.Command{
+					{Kind: translator.PushNumberCommand, Operand: "23", Position: 42},
+				},
+				variables:         map[string]float64{},
+				functions:         functions,
+				gradientVariables: []string{"x"},
+			},
+			want:         0,
+			wantGradient: nil,
+			wantErr:      assert.Error,
+		},
+		{
+			name: "error/function without derivative",
+			args: args{
+				commands: []translator.Command{
+					{Kind: translator.PushVariableCommand, Operand: "x", Position: 6},
+					{Kind: translator.CallFunctionCommand, Operand: "floor", Position: 0},
+				},
+				variables:         map[string]float64{"x": 2.5},
+				functions:         functions,
+				gradientVariables: []string{"x"},
+			},
+			want:         0,
+			wantGradient: nil,
+			wantErr:      assert.Error,
+		},
+		{
+			name: "error/wrong count of partial derivatives",
+			args: args{
+				commands: []translator.Command{
+					{Kind: translator.PushVariableCommand, Operand: "x", Position: 7},
+					{Kind: translator.CallFunctionCommand, Operand: "broken", Position: 0},
+				},
+				variables:         map[string]float64{"x": 2.5},
+				functions:         functions,
+				gradientVariables: []string{"x"},
+			},
+			want:         0,
+			wantGradient: nil,
+			wantErr:      assert.Error,
+		},
+		{
+			name: "error/unknown variable",
+			args: args{
+				commands: []translator.Command{
+					{Kind: translator.PushVariableCommand, Operand: "y", Position: 42},
+				},
+				variables:         map[string]float64{"x": 2},
+				functions:         functions,
+				gradientVariables: []string{"x"},
+			},
+			want:         0,
+			wantGradient: nil,
+			wantErr:      assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotGradient, err := EvaluateGradient(
+				tt.args.commands,
+				tt.args.variables,
+				tt.args.functions,
+				tt.args.gradientVariables,
+			)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantGradient, gotGradient)
+			tt.wantErr(t, err)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package evaluator
+
+type binding[T any] struct {
+	name     string
+	value    T
+	hadValue bool
+}
+
+type scope[T any] struct {
+	variables     map[string]T
+	ownsVariables bool
+	bindings      []binding[T]
+}
+
+func newScope[T any](variables map[string]T) scope[T] {
+	return scope[T]{variables: variables}
+}
+
+func (scope *scope[T]) get(name string) (T, bool) {
+	value, ok := scope.variables[name]
+	return value, ok
+}
+
+func (scope *scope[T]) set(name string, value T) {
+	if !scope.ownsVariables {
+		scope.variables = copyVariables(scope.variables)
+		scope.ownsVariables = true
+	}
+
+	scope.variables[name] = value
+}
+
+func (scope *scope[T]) bind(name string, value T) {
+	previousValue, hadValue := scope.get(name)
+	scope.bindings = append(scope.bindings, binding[T]{
+		name:     name,
+		value:    previousValue,
+		hadValue: hadValue,
+	})
+
+	scope.set(name, value)
+}
+
+func (scope *scope[T]) unbind(name string) bool {
+	if len(scope.bindings) == 0 || scope.bindings[len(scope.bindings)-1].name != name {
+		return false
+	}
+
+	lastBinding := scope.bindings[len(scope.bindings)-1]
+	scope.bindings = scope.bindings[:len(scope.bindings)-1]
+
+	if lastBinding.hadValue {
+		scope.variables[name] = lastBinding.value
+	} else {
+		delete(scope.variables, name)
+	}
+
+	return true
+}
+
+func (scope *scope[T]) snapshot() map[string]T {
+	if scope.ownsVariables {
+		return scope.variables
+	}
+
+	return copyVariables(scope.variables)
+}
+
+func copyVariables[T any](variables map[string]T) map[string]T {
+	variablesCopy := make(map[string]T, len(variables))
+	for name, value := range variables {
+		variablesCopy[name] = value
+	}
+
+	return variablesCopy
+}
@@ -0,0 +1,68 @@
+package explain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rmaidveo/go-calculator/ast"
+	"github.com/rmaidveo/go-calculator/containers"
+	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/rmaidveo/go-calculator/translator"
+)
+
+func Explain(
+	commands []translator.Command,
+	variables map[string]float64,
+	functions map[string]evaluator.Function,
+) (string, error) {
+	var lines []string
+	var nodeStack containers.Stack[*ast.Node]
+	_, _, err := evaluator.EvaluateWithTracer(commands, variables, functions, func(event evaluator.TraceEvent) {
+		command := event.Command
+		switch command.Kind {
+		case translator.PushNumberCommand:
+			nodeStack.Push(ast.Number(event.Value, command.Position))
+		case translator.PushVariableCommand:
+			nodeStack.Push(ast.Variable(command.Operand, command.Position))
+		case translator.CallFunctionCommand:
+			arguments := make([]*ast.Node, len(event.Arguments))
+			values := make([]*ast.Node, len(event.Arguments))
+			for argumentIndex := len(event.Arguments) - 1; argumentIndex >= 0; argumentIndex-- {
+				arguments[argumentIndex], _ = nodeStack.Pop()
+				values[argumentIndex] = ast.Number(event.Arguments[argumentIndex], command.Position)
+			}
+
+			node := ast.Call(command.Operand, command.Position, arguments...)
+			lines = append(lines, explainCall(node, ast.Call(command.Operand, command.Position, values...), event.Value))
+			nodeStack.Push(node)
+		case translator.AssignVariableCommand:
+			nodeStack.Pop()
+			nodeStack.Push(ast.Variable(command.Operand, command.Position))
+			lines = append(lines, explainBinding(command.Operand, event.Value))
+		case translator.BindVariableCommand:
+			nodeStack.Pop()
+			lines = append(lines, explainBinding(command.Operand, event.Value))
+		case translator.PopCommand:
+			nodeStack.Pop()
+		}
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to evaluate: %w", err)
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func explainCall(node *ast.Node, valueNode *ast.Node, value float64) string {
+	expression, substitution := node.String(), valueNode.String()
+	result := ast.Number(value, node.Position).String()
+	if expression == substitution || substitution == result {
+		return expression + " = " + result
+	}
+
+	return expression + " = " + substitution + " = " + result
+}
+
+func explainBinding(name string, value float64) string {
+	return name + " = " + ast.Number(value, 0).String()
+}
@@ -0,0 +1,88 @@
+package explain
+
+import (
+	"testing"
+
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/rmaidveo/go-calculator/tokenizer"
+	"github.com/rmaidveo/go-calculator/translator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplain(t *testing.T) {
+	type args struct {
+		text      string
+		variables map[string]float64
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success/number",
+			args:    args{text: "23", variables: map[string]float64{}},
+			want:    "",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/single call",
+			args:    args{text: "price * qty", variables: map[string]float64{"price": 12.5, "qty": 33}},
+			want:    "price*qty = 12.5*33 = 412.5",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/constants",
+			args:    args{text: "2 + 3", variables: map[string]float64{}},
+			want:    "2 + 3 = 5",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/nested calls",
+			args: args{text: "(a + b) * -c + sqrt(4)", variables: map[string]float64{"a": 1, "b": 2, "c": 4}},
+			want: "a + b = 1 + 2 = 3\n" +
+				"-c = -4\n" +
+				"(a + b)*-c = 3*-4 = -12\n" +
+				"sqrt(4) = 2\n" +
+				"(a + b)*-c + sqrt(4) = -12 + 2 = -10",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/statements",
+			args: args{text: "total = price * qty; let tax = 0.25 in total * tax", variables: map[string]float64{"price": 2, "qty": 3}},
+			want: "price*qty = 2*3 = 6\n" +
+				"total = 6\n" +
+				"tax = 0.25\n" +
+				"total*tax = 6*0.25 = 1.5",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error",
+			args:    args{text: "x / 0", variables: map[string]float64{"x": 1}},
+			want:    "",
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			functions := builtin.Functions()
+			functionNames := make(map[string]struct{}, len(functions))
+			for functionName := range functions {
+				functionNames[functionName] = struct{}{}
+			}
+
+			tokens, err := tokenizer.Tokenize(tt.args.text)
+			assert.NoError(t, err)
+
+			commands, err := translator.Translate(tokens, functionNames)
+			assert.NoError(t, err)
+
+			got, err := Explain(commands, tt.args.variables, functions)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package format
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rmaidveo/go-calculator/ast"
+	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/rmaidveo/go-calculator/tokenizer"
+	"github.com/rmaidveo/go-calculator/translator"
+)
+
+type statement struct {
+	commands         []translator.Command
+	start            int
+	end              int
+	leadingComments  []tokenizer.Comment
+	trailingComments []tokenizer.Comment
+}
+
+func Format(text string, functions map[string]evaluator.Function) (string, error) {
+	tokens, danglingComments, err := tokenizer.TokenizeWithComments(text, tokenizer.Options{})
+	if err != nil {
+		return "", fmt.Errorf("unable to tokenize: %w", err)
+	}
+
+	functionNames := make(map[string]struct{}, len(functions))
+	for functionName := range functions {
+		functionNames[functionName] = struct{}{}
+	}
+
+	commands, err := translator.Translate(tokens, functionNames)
+	if err != nil {
+		return "", fmt.Errorf("unable to translate: %w", err)
+	}
+
+	statements := splitStatements(commands, tokens)
+	footerComments := attachComments(statements, tokens, danglingComments)
+
+	var lines []string
+	for _, statement := range statements {
+		for _, comment := range statement.leadingComments {
+			lines = append(lines, comment.Text)
+		}
+
+		node, err := ast.Build(statement.commands, functions)
+		if err != nil {
+			return "", fmt.Errorf("unable to build the statement at position %d: %w", statement.start, err)
+		}
+
+		line := Node(node)
+		for _, comment := range statement.trailingComments {
+			line += " " + comment.Text
+		}
+
+		lines = append(lines, line)
+	}
+	for _, comment := range footerComments {
+		lines = append(lines, comment.Text)
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func Node(node *ast.Node) string {
+	return normalizeNumbers(node).String()
+}
+
+func normalizeNumbers(node *ast.Node) *ast.Node {
+	normalizedNode := *node
+	if number, ok := node.Number(); ok {
+		normalizedNode.Value = strconv.FormatFloat(number, 'f', -1, 64)
+	}
+
+	normalizedNode.Arguments = make([]*ast.Node, 0, len(node.Arguments))
+	for _, argument := range node.Arguments {
+		normalizedNode.Arguments = append(normalizedNode.Arguments, normalizeNumbers(argument))
+	}
+
+	return &normalizedNode
+}
+
+func splitStatements(commands []translator.Command, tokens []tokenizer.Token) []*statement {
+	var statements []*statement
+	statementCommands := []translator.Command{}
+	regionStart := -1
+	for _, command := range commands {
+		if command.Kind != translator.PopCommand {
+			statementCommands = append(statementCommands, command)
+			continue
+		}
+
+		statements = append(statements, newStatement(statementCommands, tokens, regionStart, command.Position))
+		statementCommands = []translator.Command{}
+		regionStart = command.Position
+	}
+	if len(commands) > 0 {
+		statements = append(statements, newStatement(statementCommands, tokens, regionStart, math.MaxInt))
+	}
+
+	return statements
+}
+
+func newStatement(commands []translator.Command, tokens []tokenizer.Token, regionStart int, regionEnd int) *statement {
+	statement := &statement{commands: commands, start: -1, end: -1}
+	for _, token := range tokens {
+		if token.Position <= regionStart || token.Position >= regionEnd || token.Kind.IsStatementSeparator() {
+			continue
+		}
+
+		if statement.start == -1 {
+			statement.start = token.Position
+		}
+		statement.end = token.Position
+	}
+
+	return statement
+}
+
+func attachComments(
+	statements []*statement,
+	tokens []tokenizer.Token,
+	danglingComments []tokenizer.Comment,
+) []tokenizer.Comment {
+	comments := append([]tokenizer.Comment(nil), danglingComments...)
+	for _, token := range tokens {
+		comments = append(comments, token.LeadingComments...)
+		comments = append(comments, token.TrailingComments...)
+	}
+	sort.SliceStable(comments, func(i int, j int) bool {
+		return comments[i].Position < comments[j].Position
+	})
+
+	var footerComments []tokenizer.Comment
+	for _, comment := range comments {
+		statementIndex := sort.Search(len(statements), func(statementIndex int) bool {
+			return comment.Position < statements[statementIndex].end
+		})
+		switch {
+		case statementIndex == len(statements):
+			lastStatement := len(statements) - 1
+			if lastStatement < 0 || hasNewlineBetween(tokens, statements[lastStatement].end, comment.Position) {
+				footerComments = append(footerComments, comment)
+				continue
+			}
+
+			statements[lastStatement].trailingComments = append(statements[lastStatement].trailingComments, comment)
+		case comment.Position < statements[statementIndex].start &&
+			statementIndex > 0 &&
+			!hasNewlineBetween(tokens, statements[statementIndex-1].end, comment.Position):
+			previousStatement := statements[statementIndex-1]
+			previousStatement.trailingComments = append(previousStatement.trailingComments, comment)
+		default:
+			statements[statementIndex].leadingComments = append(statements[statementIndex].leadingComments, comment)
+		}
+	}
+
+	return footerComments
+}
+
+func hasNewlineBetween(tokens []tokenizer.Token, from int, to int) bool {
+	for _, token := range tokens {
+		if token.Kind == tokenizer.NewlineToken && token.Position > from && token.Position < to {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,199 @@
+package format
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	calculator "github.com/rmaidveo/go-calculator"
+	"github.com/rmaidveo/go-calculator/ast"
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success/empty",
+			text:    "",
+			want:    "",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/spacing and parentheses",
+			text:    "2*(x+ 1 )^ 2",
+			want:    "2*(x + 1)^2",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/redundant parentheses",
+			text:    "((x)) + (y * z) - (a - b) + (2 ^ (3 ^ 4))",
+			want:    "x + y*z - (a - b) + 2^3^4",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/associativity",
+			text:    "(2 ^ 3) ^ 4 - (x / y) / z + x / (y / z)",
+			want:    "(2^3)^4 - x/y/z + x/(y/z)",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/negation",
+			text:    "(-2)^2 + -(2^2) - (-x) + +y",
+			want:    "(-2)^2 + -2^2 - -x + y",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/numbers",
+			text:    "007 + 2.50 + .5 + 5. + 1.000",
+			want:    "7 + 2.5 + 0.5 + 5 + 1",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/functions",
+			text:    "max( sin(x) ,2 )+atan2(y,x)",
+			want:    "max(sin(x), 2) + atan2(y, x)",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/statements",
+			text:    "rate=0.50;years = 2\n\n\ntotal =\n  100 * (1 + rate) * years",
+			want:    "rate = 0.5\nyears = 2\ntotal = 100*(1 + rate)*years",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/let binding",
+			text:    "2 * (let x = 3 in x*x)",
+			want:    "2*(let x = 3 in x*x)",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/comments",
+			text: "# header\n" +
+				"x = 1 # trailing\n" +
+				"y = 2; /* same line */ z = (x +\n" +
+				"  // hoisted\n" +
+				"  y)\n" +
+				"# footer",
+			want: "# header\n" +
+				"x = 1 # trailing\n" +
+				"y = 2 /* same line */\n" +
+				"// hoisted\n" +
+				"z = x + y\n" +
+				"# footer",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/only comments",
+			text:    "# first\n\n/* second */",
+			want:    "# first\n/* second */",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/only a comment without a trailing newline",
+			text:    "# note",
+			want:    "# note",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error/unable to tokenize",
+			text:    "2 $ 3",
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/unable to translate",
+			text:    "(2 + 3",
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/unable to build",
+			text:    "2 3",
+			want:    "",
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Format(tt.text, builtin.Functions())
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestNode(t *testing.T) {
+	node := ast.Call("*", 0, ast.Variable("x", 0), ast.Call("+", 0, &ast.Node{Kind: ast.NumberNode, Value: "1e+21"}, ast.Variable("y", 0)))
+
+	got := Node(node)
+
+	assert.Equal(t, "x*(1000000000000000000000 + y)", got)
+	assert.Equal(t, "1e+21", node.Arguments[1].Arguments[0].Value)
+}
+
+func TestFormat_roundTrip(t *testing.T) {
+	functions := builtin.Functions()
+	random := rand.New(rand.NewSource(1))
+	for iteration := 0; iteration < 2000; iteration++ {
+		text := randomExpression(random, 4)
+		variables := map[string]float64{
+			"x": randomOperand(random),
+			"y": randomOperand(random),
+		}
+
+		t.Run(fmt.Sprintf("%s/%v", text, variables), func(t *testing.T) {
+			formatted, err := Format(text, functions)
+			assert.NoError(t, err)
+
+			reformatted, err := Format(formatted, functions)
+			assert.NoError(t, err)
+			assert.Equal(t, formatted, reformatted)
+
+			want, wantErr := calculator.Calculate(text, variables, functions)
+			got, gotErr := calculator.Calculate(formatted, variables, functions)
+
+			assert.Equal(t, wantErr == nil, gotErr == nil)
+			if math.IsNaN(want) {
+				assert.True(t, math.IsNaN(got))
+			} else {
+				assert.Equal(t, want, got)
+			}
+		})
+	}
+}
+
+func randomExpression(random *rand.Rand, depth int) string {
+	if depth == 0 || random.Intn(4) == 0 {
+		operands := []string{"0", "1.0", "2", ".5", "03", "x", "y"}
+		return operands[random.Intn(len(operands))]
+	}
+
+	switch random.Intn(4) {
+	case 0:
+		return "-" + randomExpression(random, depth-1)
+	case 1:
+		functionNames := []string{"sin", "sqrt", "abs"}
+		return fmt.Sprintf("%s(%s)", functionNames[random.Intn(len(functionNames))], randomExpression(random, depth-1))
+	default:
+		operators := []string{"+", "-", "*", "/", "%", "^"}
+		return strings.Join([]string{
+			"(" + randomExpression(random, depth-1),
+			operators[random.Intn(len(operators))],
+			randomExpression(random, depth-1) + ")",
+		}, " ")
+	}
+}
+
+func randomOperand(random *rand.Rand) float64 {
+	operands := []float64{0, 1, -1, 0.5, 2, 10}
+	return operands[random.Intn(len(operands))]
+}
@@ -0,0 +1,408 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/rmaidveo/go-calculator/translator"
+)
+
+type formula struct {
+	commands     []translator.Command
+	dependencies []string
+}
+
+type Graph struct {
+	formulas   map[string]formula
+	functions  map[string]evaluator.Function
+	order      []string
+	orderIndex map[string]int
+	dependents map[string][]string
+	values     map[string]float64
+	errs       map[string]error
+}
+
+func New(formulas map[string][]translator.Command, functions map[string]evaluator.Function) (*Graph, error) {
+	graph := &Graph{
+		formulas:   make(map[string]formula, len(formulas)),
+		functions:  functions,
+		orderIndex: make(map[string]int, len(formulas)),
+		dependents: map[string][]string{},
+		values:     map[string]float64{},
+		errs:       map[string]error{},
+	}
+
+	names := make([]string, 0, len(formulas))
+	for name := range formulas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		dependencies := Dependencies(formulas[name])
+		graph.formulas[name] = formula{commands: formulas[name], dependencies: dependencies}
+		for _, dependency := range dependencies {
+			graph.dependents[dependency] = append(graph.dependents[dependency], name)
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	states := make(map[string]int, len(formulas))
+	var path []string
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch states[name] {
+		case visiting:
+			cycleStart := 0
+			for path[cycleStart] != name {
+				cycleStart++
+			}
+
+			cycle := append(append([]string(nil), path[cycleStart:]...), name)
+			return fmt.Errorf("dependency cycle is found: %s", strings.Join(cycle, " -> "))
+		case visited:
+			return nil
+		}
+
+		states[name] = visiting
+		path = append(path, name)
+		for _, dependency := range graph.formulas[name].dependencies {
+			if _, ok := graph.formulas[dependency]; !ok {
+				continue
+			}
+
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		states[name] = visited
+
+		graph.orderIndex[name] = len(graph.order)
+		graph.order = append(graph.order, name)
+		return nil
+	}
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+
+	return graph, nil
+}
+
+func Dependencies(commands []translator.Command) []string {
+	var dependencies []string
+	isDependency := map[string]bool{}
+	localVariables := map[string]int{}
+	for _, command := range commands {
+		switch command.Kind {
+		case translator.PushVariableCommand:
+			if localVariables[command.Operand] == 0 && !isDependency[command.Operand] {
+				isDependency[command.Operand] = true
+				dependencies = append(dependencies, command.Operand)
+			}
+		case translator.AssignVariableCommand:
+			if localVariables[command.Operand] == 0 {
+				localVariables[command.Operand] = 1
+			}
+		case translator.BindVariableCommand:
+			localVariables[command.Operand]++
+		case translator.UnbindVariableCommand:
+			localVariables[command.Operand]--
+		}
+	}
+
+	sort.Strings(dependencies)
+	return dependencies
+}
+
+func (graph *Graph) Order() []string {
+	return append([]string(nil), graph.order...)
+}
+
+func (graph *Graph) Dependencies(name string) []string {
+	return append([]string(nil), graph.formulas[name].dependencies...)
+}
+
+func (graph *Graph) Dependents(name string) []string {
+	return append([]string(nil), graph.dependents[name]...)
+}
+
+func (graph *Graph) Inputs() []string {
+	var inputs []string
+	for name := range graph.dependents {
+		if _, ok := graph.formulas[name]; !ok {
+			inputs = append(inputs, name)
+		}
+	}
+
+	sort.Strings(inputs)
+	return inputs
+}
+
+func (graph *Graph) Evaluate(inputs map[string]float64) error {
+	for name, value := range inputs {
+		if _, ok := graph.formulas[name]; ok {
+			return fmt.Errorf("unable to set the formula %q as an input", name)
+		}
+
+		graph.values[name] = value
+	}
+
+	for _, name := range graph.order {
+		graph.recalculate(name)
+	}
+
+	return nil
+}
+
+func (graph *Graph) Set(name string, value float64) ([]string, error) {
+	if _, ok := graph.formulas[name]; ok {
+		return nil, fmt.Errorf("unable to set the formula %q as an input", name)
+	}
+
+	graph.values[name] = value
+
+	affected := graph.dependentsOf(name)
+	for _, dependent := range affected {
+		graph.recalculate(dependent)
+	}
+
+	return affected, nil
+}
+
+func (graph *Graph) SetFormula(name string, commands []translator.Command) ([]string, error) {
+	dependencies := Dependencies(commands)
+	if cycle := graph.findCycle(name, dependencies); cycle != nil {
+		return nil, fmt.Errorf("dependency cycle is found: %s", strings.Join(cycle, " -> "))
+	}
+
+	// a new formula goes first, so that it precedes its dependents, and the edges to its dependencies
+	// are added one by one, so that the order stays valid for all the other edges on each reordering
+	if _, ok := graph.formulas[name]; ok {
+		graph.removeEdges(name)
+	} else {
+		delete(graph.values, name)
+		graph.order = append([]string{name}, graph.order...)
+		for orderIndex, formulaName := range graph.order {
+			graph.orderIndex[formulaName] = orderIndex
+		}
+	}
+
+	for dependencyIndex, dependency := range dependencies {
+		graph.formulas[name] = formula{commands: commands, dependencies: dependencies[:dependencyIndex+1]}
+		graph.dependents[dependency] = insertName(graph.dependents[dependency], name)
+		if _, ok := graph.formulas[dependency]; ok && graph.orderIndex[dependency] > graph.orderIndex[name] {
+			graph.reorder(dependency, name)
+		}
+	}
+	graph.formulas[name] = formula{commands: commands, dependencies: dependencies}
+
+	affected := append([]string{name}, graph.dependentsOf(name)...)
+	for _, dependent := range affected {
+		graph.recalculate(dependent)
+	}
+
+	return affected, nil
+}
+
+func (graph *Graph) RemoveFormula(name string) {
+	if _, ok := graph.formulas[name]; !ok {
+		return
+	}
+
+	graph.removeEdges(name)
+	delete(graph.formulas, name)
+	delete(graph.values, name)
+	delete(graph.errs, name)
+
+	orderIndex := graph.orderIndex[name]
+	delete(graph.orderIndex, name)
+	graph.order = append(graph.order[:orderIndex], graph.order[orderIndex+1:]...)
+	for _, formulaName := range graph.order[orderIndex:] {
+		graph.orderIndex[formulaName]--
+	}
+}
+
+func (graph *Graph) Value(name string) (float64, error) {
+	if err, ok := graph.errs[name]; ok {
+		return 0, err
+	}
+
+	value, ok := graph.values[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown variable %q", name)
+	}
+
+	return value, nil
+}
+
+func (graph *Graph) dependentsOf(name string) []string {
+	isAffected := map[string]bool{}
+	queue := []string{name}
+	for len(queue) != 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, dependent := range graph.dependents[current] {
+			if !isAffected[dependent] {
+				isAffected[dependent] = true
+				queue = append(queue, dependent)
+			}
+		}
+	}
+
+	affected := make([]string, 0, len(isAffected))
+	for dependent := range isAffected {
+		affected = append(affected, dependent)
+	}
+	sort.Slice(affected, func(i, j int) bool {
+		return graph.orderIndex[affected[i]] < graph.orderIndex[affected[j]]
+	})
+
+	return affected
+}
+
+// returns the path from the name back to itself through the dependencies if there is one
+func (graph *Graph) findCycle(name string, dependencies []string) []string {
+	isVisited := map[string]bool{}
+	var path []string
+	var visit func(current string, dependencies []string) bool
+	visit = func(current string, dependencies []string) bool {
+		path = append(path, current)
+		for _, dependency := range dependencies {
+			if dependency == name {
+				path = append(path, name)
+				return true
+			}
+
+			formula, ok := graph.formulas[dependency]
+			if !ok || isVisited[dependency] {
+				continue
+			}
+
+			isVisited[dependency] = true
+			if visit(dependency, formula.dependencies) {
+				return true
+			}
+		}
+		path = path[:len(path)-1]
+
+		return false
+	}
+	if !visit(name, dependencies) {
+		return nil
+	}
+
+	return path
+}
+
+func (graph *Graph) removeEdges(name string) {
+	for _, dependency := range graph.formulas[name].dependencies {
+		dependents := removeName(graph.dependents[dependency], name)
+		if len(dependents) == 0 {
+			delete(graph.dependents, dependency)
+			continue
+		}
+
+		graph.dependents[dependency] = dependents
+	}
+}
+
+// moves the formulas between the two names in the order, so that the first one precedes the second one;
+// only the formulas that depend on the second one or that the first one depends on are moved
+func (graph *Graph) reorder(before string, after string) {
+	lowerIndex, upperIndex := graph.orderIndex[after], graph.orderIndex[before]
+	forward := graph.collect(after, func(name string) []string {
+		return graph.dependents[name]
+	}, func(orderIndex int) bool {
+		return orderIndex <= upperIndex
+	})
+	backward := graph.collect(before, func(name string) []string {
+		return graph.formulas[name].dependencies
+	}, func(orderIndex int) bool {
+		return orderIndex >= lowerIndex
+	})
+
+	names := append(backward, forward...)
+	orderIndexes := make([]int, 0, len(names))
+	for _, name := range names {
+		orderIndexes = append(orderIndexes, graph.orderIndex[name])
+	}
+	sort.Ints(orderIndexes)
+
+	for nameIndex, name := range names {
+		graph.orderIndex[name] = orderIndexes[nameIndex]
+		graph.order[orderIndexes[nameIndex]] = name
+	}
+}
+
+func (graph *Graph) collect(name string, next func(name string) []string, isInRegion func(orderIndex int) bool) []string {
+	isCollected := map[string]bool{name: true}
+	names := []string{name}
+	for queueIndex := 0; queueIndex < len(names); queueIndex++ {
+		for _, nextName := range next(names[queueIndex]) {
+			orderIndex, ok := graph.orderIndex[nextName]
+			if !ok || isCollected[nextName] || !isInRegion(orderIndex) {
+				continue
+			}
+
+			isCollected[nextName] = true
+			names = append(names, nextName)
+		}
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		return graph.orderIndex[names[i]] < graph.orderIndex[names[j]]
+	})
+	return names
+}
+
+func (graph *Graph) recalculate(name string) {
+	delete(graph.values, name)
+	delete(graph.errs, name)
+
+	formula := graph.formulas[name]
+	for _, dependency := range formula.dependencies {
+		if err, ok := graph.errs[dependency]; ok {
+			graph.errs[name] = fmt.Errorf("unable to evaluate the dependency %q of %q: %w", dependency, name, err)
+			return
+		}
+	}
+
+	value, err := evaluator.Evaluate(formula.commands, graph.values, graph.functions)
+	if err != nil {
+		graph.errs[name] = fmt.Errorf("unable to evaluate %q: %w", name, err)
+		return
+	}
+
+	graph.values[name] = value
+}
+
+func insertName(names []string, name string) []string {
+	nameIndex := sort.SearchStrings(names, name)
+	if nameIndex < len(names) && names[nameIndex] == name {
+		return names
+	}
+
+	names = append(names, "")
+	copy(names[nameIndex+1:], names[nameIndex:])
+	names[nameIndex] = name
+	return names
+}
+
+func removeName(names []string, name string) []string {
+	nameIndex := sort.SearchStrings(names, name)
+	if nameIndex == len(names) || names[nameIndex] != name {
+		return names
+	}
+
+	return append(names[:nameIndex], names[nameIndex+1:]...)
+}
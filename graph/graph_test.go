@@ -0,0 +1,270 @@
+package graph
+
+import (
+	"testing"
+
+	calculator "github.com/rmaidveo/go-calculator"
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/rmaidveo/go-calculator/translator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name      string
+		texts     map[string]string
+		wantOrder []string
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success/chain",
+			texts: map[string]string{
+				"net":   "gross - tax",
+				"tax":   "gross * rate",
+				"gross": "price * qty",
+			},
+			wantOrder: []string{"gross", "tax", "net"},
+			wantErr:   assert.NoError,
+		},
+		{
+			name: "success/local variables are not dependencies",
+			texts: map[string]string{
+				"a": "let b = 2 in b * x",
+				"b": "a + 1",
+			},
+			wantOrder: []string{"a", "b"},
+			wantErr:   assert.NoError,
+		},
+		{
+			name: "error/cycle",
+			texts: map[string]string{
+				"a": "b + 1",
+				"b": "c + 1",
+				"c": "a + x",
+			},
+			wantOrder: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(t, err, "dependency cycle is found: a -> b -> c -> a", msgAndArgs...)
+			},
+		},
+		{
+			name: "error/self reference",
+			texts: map[string]string{
+				"a": "a + 1",
+			},
+			wantOrder: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(t, err, "dependency cycle is found: a -> a", msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(compileAll(t, tt.texts), builtin.Functions())
+
+			if got != nil {
+				assert.Equal(t, tt.wantOrder, got.Order())
+			} else {
+				assert.Nil(t, tt.wantOrder)
+			}
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestDependencies(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{
+			name: "variables",
+			text: "y * x + y",
+			want: []string{"x", "y"},
+		},
+		{
+			name: "let bindings",
+			text: "(let x = y in x * 2) + x",
+			want: []string{"x", "y"},
+		},
+		{
+			name: "assignments",
+			text: "a = b; a * c",
+			want: []string{"b", "c"},
+		},
+		{
+			name: "constants",
+			text: "2 + 3",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands, err := calculator.Compile(tt.text, builtin.Functions())
+			assert.NoError(t, err)
+
+			got := Dependencies(commands)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGraph_Set(t *testing.T) {
+	graph, err := New(compileAll(t, map[string]string{
+		"gross":    "price * qty",
+		"tax":      "gross * rate",
+		"net":      "gross - tax",
+		"discount": "price * 0.1",
+		"shipping": "weight * 2",
+	}), builtin.Functions())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"price", "qty", "rate", "weight"}, graph.Inputs())
+	assert.Equal(t, []string{"gross", "rate"}, graph.Dependencies("tax"))
+	assert.Equal(t, []string{"net", "tax"}, graph.Dependents("gross"))
+
+	err = graph.Evaluate(map[string]float64{"price": 10, "qty": 3, "rate": 0.5, "weight": 4})
+	assert.NoError(t, err)
+	assertValues(t, graph, map[string]float64{"gross": 30, "tax": 15, "net": 15, "discount": 1, "shipping": 8})
+
+	recalculated, err := graph.Set("rate", 0.25)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tax", "net"}, recalculated)
+	assertValues(t, graph, map[string]float64{"gross": 30, "tax": 7.5, "net": 22.5, "discount": 1, "shipping": 8})
+
+	recalculated, err = graph.Set("price", 20)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"discount", "gross", "tax", "net"}, recalculated)
+	assertValues(t, graph, map[string]float64{"gross": 60, "tax": 15, "net": 45, "discount": 2, "shipping": 8})
+
+	recalculated, err = graph.Set("unused", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{}, recalculated)
+
+	recalculated, err = graph.Set("tax", 1)
+	assert.Nil(t, recalculated)
+	assert.EqualError(t, err, `unable to set the formula "tax" as an input`)
+}
+
+func TestGraph_SetFormula(t *testing.T) {
+	graph, err := New(compileAll(t, map[string]string{
+		"gross": "price * qty",
+		"tax":   "gross * rate",
+		"net":   "gross - tax",
+	}), builtin.Functions())
+	assert.NoError(t, err)
+
+	err = graph.Evaluate(map[string]float64{"price": 10, "qty": 3, "rate": 0.5, "fee": 2, "base": 10})
+	assert.NoError(t, err)
+
+	recalculated, err := graph.SetFormula("tax", compile(t, "gross * rate + fee"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tax", "net"}, recalculated)
+	assert.Equal(t, []string{"fee", "gross", "rate"}, graph.Dependencies("tax"))
+	assertValues(t, graph, map[string]float64{"gross": 30, "tax": 17, "net": 13})
+
+	recalculated, err = graph.SetFormula("price", compile(t, "base * 2"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"price", "gross", "tax", "net"}, recalculated)
+	assertValues(t, graph, map[string]float64{"price": 20, "gross": 60, "tax": 32, "net": 28})
+
+	recalculated, err = graph.SetFormula("total", compile(t, "net + tax"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"total"}, recalculated)
+	assert.Equal(t, []string{"price", "gross", "tax", "net", "total"}, graph.Order())
+	assertValues(t, graph, map[string]float64{"total": 60})
+
+	recalculated, err = graph.SetFormula("gross", compile(t, "total * 2"))
+	assert.Nil(t, recalculated)
+	assert.EqualError(t, err, "dependency cycle is found: gross -> total -> net -> gross")
+	assertValues(t, graph, map[string]float64{"gross": 60, "total": 60})
+
+	recalculated, err = graph.Set("base", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"price", "gross", "tax", "net", "total"}, recalculated)
+	assertValues(t, graph, map[string]float64{"price": 10, "gross": 30, "tax": 17, "net": 13, "total": 30})
+}
+
+func TestGraph_RemoveFormula(t *testing.T) {
+	graph, err := New(compileAll(t, map[string]string{
+		"gross": "price * qty",
+		"tax":   "gross * rate",
+		"net":   "gross - tax",
+	}), builtin.Functions())
+	assert.NoError(t, err)
+
+	err = graph.Evaluate(map[string]float64{"price": 10, "qty": 3, "rate": 0.5})
+	assert.NoError(t, err)
+
+	graph.RemoveFormula("tax")
+	assert.Equal(t, []string{"gross", "net"}, graph.Order())
+	assert.Equal(t, []string{"net"}, graph.Dependents("gross"))
+	assert.Equal(t, []string{"price", "qty", "tax"}, graph.Inputs())
+
+	recalculated, err := graph.Set("tax", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"net"}, recalculated)
+	assertValues(t, graph, map[string]float64{"gross": 30, "net": 29})
+
+	graph.RemoveFormula("unknown")
+	assert.Equal(t, []string{"gross", "net"}, graph.Order())
+}
+
+func TestGraph_Value_errors(t *testing.T) {
+	graph, err := New(compileAll(t, map[string]string{
+		"ratio":  "a / b",
+		"scaled": "ratio * 2",
+		"other":  "a + 1",
+	}), builtin.Functions())
+	assert.NoError(t, err)
+
+	err = graph.Evaluate(map[string]float64{"a": 1})
+	assert.NoError(t, err)
+
+	_, err = graph.Value("scaled")
+	assert.Error(t, err)
+	assertValues(t, graph, map[string]float64{"other": 2})
+
+	_, err = graph.Set("b", 0)
+	assert.NoError(t, err)
+	_, err = graph.Value("ratio")
+	assert.Error(t, err)
+
+	_, err = graph.Set("b", 4)
+	assert.NoError(t, err)
+	assertValues(t, graph, map[string]float64{"ratio": 0.25, "scaled": 0.5, "other": 2})
+
+	_, err = graph.Value("unknown")
+	assert.EqualError(t, err, `unknown variable "unknown"`)
+}
+
+func compile(t *testing.T, text string) []translator.Command {
+	t.Helper()
+
+	commands, err := calculator.Compile(text, builtin.Functions())
+	assert.NoError(t, err)
+
+	return commands
+}
+
+func compileAll(t *testing.T, texts map[string]string) map[string][]translator.Command {
+	t.Helper()
+
+	formulas := make(map[string][]translator.Command, len(texts))
+	for name, text := range texts {
+		formulas[name] = compile(t, text)
+	}
+
+	return formulas
+}
+
+func assertValues(t *testing.T, graph *Graph, want map[string]float64) {
+	t.Helper()
+
+	for name, wantValue := range want {
+		got, err := graph.Value(name)
+		assert.NoError(t, err, name)
+		assert.Equal(t, wantValue, got, name)
+	}
+}
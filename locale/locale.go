@@ -0,0 +1,88 @@
+package locale
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type Locale struct {
+	DecimalSeparator  rune
+	ArgumentSeparator rune
+	GroupingSeparator rune
+}
+
+var (
+	English = Locale{DecimalSeparator: '.', ArgumentSeparator: ','}
+	German  = Locale{DecimalSeparator: ',', ArgumentSeparator: ';', GroupingSeparator: '.'}
+	French  = Locale{DecimalSeparator: ',', ArgumentSeparator: ';', GroupingSeparator: ' '}
+)
+
+var locales = map[string]Locale{
+	"en": English,
+	"de": German,
+	"fr": French,
+}
+
+func Parse(name string) (Locale, error) {
+	numberLocale, ok := locales[name]
+	if !ok {
+		return Locale{}, fmt.Errorf("unknown locale %q", name)
+	}
+
+	return numberLocale, nil
+}
+
+func (numberLocale Locale) Validate() error {
+	if numberLocale.DecimalSeparator != '.' && numberLocale.DecimalSeparator != ',' {
+		return fmt.Errorf("unsupported decimal separator %q", numberLocale.DecimalSeparator)
+	}
+	if numberLocale.ArgumentSeparator != ',' && numberLocale.ArgumentSeparator != ';' {
+		return fmt.Errorf("unsupported argument separator %q", numberLocale.ArgumentSeparator)
+	}
+	if numberLocale.DecimalSeparator == numberLocale.ArgumentSeparator {
+		return fmt.Errorf("decimal separator %q is also the argument separator", numberLocale.DecimalSeparator)
+	}
+	if numberLocale.GroupingSeparator == 0 {
+		return nil
+	}
+	if numberLocale.GroupingSeparator == numberLocale.DecimalSeparator ||
+		numberLocale.GroupingSeparator == numberLocale.ArgumentSeparator ||
+		unicode.IsDigit(numberLocale.GroupingSeparator) {
+		return fmt.Errorf("unsupported grouping separator %q", numberLocale.GroupingSeparator)
+	}
+
+	return nil
+}
+
+func (numberLocale Locale) FormatNumber(number float64) string {
+	if math.IsInf(number, 0) || math.IsNaN(number) || math.Abs(number) >= 1e21 {
+		text := strconv.FormatFloat(number, 'g', -1, 64)
+		return strings.Replace(text, ".", string(numberLocale.DecimalSeparator), 1)
+	}
+
+	text := strconv.FormatFloat(number, 'f', -1, 64)
+	integerPart, fractionalPart, hasFractionalPart := strings.Cut(text, ".")
+	sign := ""
+	if strings.HasPrefix(integerPart, "-") {
+		sign, integerPart = "-", integerPart[1:]
+	}
+
+	var builder strings.Builder
+	builder.WriteString(sign)
+	for digitIndex, digit := range integerPart {
+		if numberLocale.GroupingSeparator != 0 && digitIndex > 0 && (len(integerPart)-digitIndex)%3 == 0 {
+			builder.WriteRune(numberLocale.GroupingSeparator)
+		}
+
+		builder.WriteRune(digit)
+	}
+	if hasFractionalPart {
+		builder.WriteRune(numberLocale.DecimalSeparator)
+		builder.WriteString(fractionalPart)
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,99 @@
+package locale
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    Locale
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "success/english", text: "en", want: English, wantErr: assert.NoError},
+		{name: "success/german", text: "de", want: German, wantErr: assert.NoError},
+		{name: "success/french", text: "fr", want: French, wantErr: assert.NoError},
+		{name: "error/unknown", text: "xx", want: Locale{}, wantErr: assert.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.text)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestLocale_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		numberLocale Locale
+		wantErr      assert.ErrorAssertionFunc
+	}{
+		{name: "success/english", numberLocale: English, wantErr: assert.NoError},
+		{name: "success/german", numberLocale: German, wantErr: assert.NoError},
+		{name: "success/french", numberLocale: French, wantErr: assert.NoError},
+		{
+			name:         "error/decimal separator",
+			numberLocale: Locale{DecimalSeparator: ';', ArgumentSeparator: ','},
+			wantErr:      assert.Error,
+		},
+		{
+			name:         "error/argument separator",
+			numberLocale: Locale{DecimalSeparator: '.', ArgumentSeparator: '.'},
+			wantErr:      assert.Error,
+		},
+		{
+			name:         "error/same decimal and argument separators",
+			numberLocale: Locale{DecimalSeparator: ',', ArgumentSeparator: ','},
+			wantErr:      assert.Error,
+		},
+		{
+			name:         "error/grouping separator is the argument separator",
+			numberLocale: Locale{DecimalSeparator: '.', ArgumentSeparator: ',', GroupingSeparator: ','},
+			wantErr:      assert.Error,
+		},
+		{
+			name:         "error/grouping separator is a digit",
+			numberLocale: Locale{DecimalSeparator: '.', ArgumentSeparator: ',', GroupingSeparator: '0'},
+			wantErr:      assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.numberLocale.Validate()
+
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestLocale_FormatNumber(t *testing.T) {
+	tests := []struct {
+		name         string
+		numberLocale Locale
+		number       float64
+		want         string
+	}{
+		{name: "english", numberLocale: English, number: 1234567.25, want: "1234567.25"},
+		{name: "german", numberLocale: German, number: 1234567.25, want: "1.234.567,25"},
+		{name: "french", numberLocale: French, number: -1234.5, want: "-1\u202f234,5"},
+		{name: "german/integer", numberLocale: German, number: 100, want: "100"},
+		{name: "german/small number", numberLocale: German, number: 0.125, want: "0,125"},
+		{name: "german/large number", numberLocale: German, number: 1.5e21, want: "1,5e+21"},
+		{name: "german/infinity", numberLocale: German, number: math.Inf(-1), want: "-Inf"},
+		{name: "german/nan", numberLocale: German, number: math.NaN(), want: "NaN"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.numberLocale.FormatNumber(tt.number)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package optimizer
+
+import (
+	"fmt"
+
+	"github.com/rmaidveo/go-calculator/ast"
+	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/rmaidveo/go-calculator/translator"
+)
+
+func Optimize(
+	commands []translator.Command,
+	functions map[string]evaluator.Function,
+) ([]translator.Command, error) {
+	node, err := ast.Build(commands, functions)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build the syntax tree: %w", err)
+	}
+
+	return OptimizeNode(node, functions).Commands(), nil
+}
+
+func OptimizeNode(node *ast.Node, functions map[string]evaluator.Function) *ast.Node {
+	if len(node.Arguments) == 0 {
+		return node
+	}
+
+	arguments := make([]*ast.Node, 0, len(node.Arguments))
+	for _, argument := range node.Arguments {
+		arguments = append(arguments, OptimizeNode(argument, functions))
+	}
+
+	optimizedNode := *node
+	optimizedNode.Arguments = arguments
+	if optimizedNode.Kind != ast.CallNode {
+		return &optimizedNode
+	}
+
+	function, ok := functions[optimizedNode.Value]
+	if !ok || !function.Pure {
+		return &optimizedNode
+	}
+	if foldedNode, ok := foldConstants(&optimizedNode, function); ok {
+		return foldedNode
+	}
+	if simplifiedNode, ok := removeIdentity(&optimizedNode, function); ok {
+		return simplifiedNode
+	}
+
+	return &optimizedNode
+}
+
+func foldConstants(node *ast.Node, function evaluator.Function) (*ast.Node, bool) {
+	arguments := make([]float64, 0, len(node.Arguments))
+	for _, argument := range node.Arguments {
+		number, ok := argument.Number()
+		if !ok {
+			return nil, false
+		}
+
+		arguments = append(arguments, number)
+	}
+
+	// a failing call is kept as is, so that the error is reported at run time with its position
+	number, err := function.Handler(arguments)
+	if err != nil {
+		return nil, false
+	}
+
+	return ast.Number(number, node.Position), true
+}
+
+func removeIdentity(node *ast.Node, function evaluator.Function) (*ast.Node, bool) {
+	if function.SelfInverse && len(node.Arguments) == 1 {
+		return collapseDoubleInverse(node)
+	}
+	if function.Identity == nil || len(node.Arguments) != 2 {
+		return nil, false
+	}
+
+	leftArgument, rightArgument := node.Arguments[0], node.Arguments[1]
+	if rightArgument.IsNumber(function.Identity.Value) {
+		return leftArgument, true
+	}
+	if function.Identity.Commutative && leftArgument.IsNumber(function.Identity.Value) {
+		return rightArgument, true
+	}
+
+	return nil, false
+}
+
+func collapseDoubleInverse(node *ast.Node) (*ast.Node, bool) {
+	argument := node.Arguments[0]
+	if argument.Kind != ast.CallNode || argument.Value != node.Value || len(argument.Arguments) != 1 {
+		return nil, false
+	}
+
+	return argument.Arguments[0], true
+}
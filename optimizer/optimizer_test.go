@@ -0,0 +1,242 @@
+package optimizer
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	calculator "github.com/rmaidveo/go-calculator"
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/rmaidveo/go-calculator/constants"
+	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/rmaidveo/go-calculator/translator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptimize(t *testing.T) {
+	type args struct {
+		text      string
+		functions map[string]evaluator.Function
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    []translator.Command
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success/constant folding",
+			args: args{text: "(2 * 3.5) * r", functions: builtin.Functions()},
+			want: []translator.Command{
+				{Kind: translator.PushNumberCommand, Operand: "7", Position: 3},
+				{Kind: translator.PushVariableCommand, Operand: "r", Position: 12},
+				{Kind: translator.CallFunctionCommand, Operand: "*", Position: 10},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/constant folding/with functions",
+			args: args{text: "max(sqrt(16), 3) + x", functions: builtin.Functions()},
+			want: []translator.Command{
+				{Kind: translator.PushNumberCommand, Operand: "4", Position: 0},
+				{Kind: translator.PushVariableCommand, Operand: "x", Position: 19},
+				{Kind: translator.CallFunctionCommand, Operand: "+", Position: 17},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/constant folding/impure function",
+			args: args{
+				text: "random(2 * 3)",
+				functions: map[string]evaluator.Function{
+					"*":      builtin.Functions()["*"],
+					"random": {Arity: 1},
+				},
+			},
+			want: []translator.Command{
+				{Kind: translator.PushNumberCommand, Operand: "6", Position: 9},
+				{Kind: translator.CallFunctionCommand, Operand: "random", Position: 0},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/constant folding/failing function",
+			args: args{text: "x + 1 / 0", functions: builtin.Functions()},
+			want: []translator.Command{
+				{Kind: translator.PushVariableCommand, Operand: "x", Position: 0},
+				{Kind: translator.PushNumberCommand, Operand: "1", Position: 4},
+				{Kind: translator.PushNumberCommand, Operand: "0", Position: 8},
+				{Kind: translator.CallFunctionCommand, Operand: "/", Position: 6},
+				{Kind: translator.CallFunctionCommand, Operand: "+", Position: 2},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/identities",
+			args: args{text: "((((x * 1) + 0) ^ (3 - 2)) / 1) * ((1 * y) - 0)", functions: builtin.Functions()},
+			want: []translator.Command{
+				{Kind: translator.PushVariableCommand, Operand: "x", Position: 4},
+				{Kind: translator.PushVariableCommand, Operand: "y", Position: 40},
+				{Kind: translator.CallFunctionCommand, Operand: "*", Position: 32},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/double negation",
+			args: args{text: "--x * ---y", functions: builtin.Functions()},
+			want: []translator.Command{
+				{Kind: translator.PushVariableCommand, Operand: "x", Position: 2},
+				{Kind: translator.PushVariableCommand, Operand: "y", Position: 9},
+				{Kind: translator.CallFunctionCommand, Operand: "neg", Position: 6},
+				{Kind: translator.CallFunctionCommand, Operand: "*", Position: 4},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/identities/custom functions",
+			args: args{
+				text: "(x + 0) * 1 + --y",
+				functions: map[string]evaluator.Function{
+					"+": {
+						Arity: 2,
+						Pure:  true,
+						Handler: func(arguments []float64) (float64, error) {
+							return arguments[0] * arguments[1], nil
+						},
+					},
+					"*": {
+						Arity: 2,
+						Pure:  true,
+						Handler: func(arguments []float64) (float64, error) {
+							return arguments[0] + arguments[1], nil
+						},
+					},
+					"neg": {
+						Arity: 1,
+						Pure:  true,
+						Handler: func(arguments []float64) (float64, error) {
+							return arguments[0] / 2, nil
+						},
+					},
+				},
+			},
+			want: []translator.Command{
+				{Kind: translator.PushVariableCommand, Operand: "x", Position: 1},
+				{Kind: translator.PushNumberCommand, Operand: "0", Position: 5},
+				{Kind: translator.CallFunctionCommand, Operand: "+", Position: 3},
+				{Kind: translator.PushNumberCommand, Operand: "1", Position: 10},
+				{Kind: translator.CallFunctionCommand, Operand: "*", Position: 8},
+				{Kind: translator.PushVariableCommand, Operand: "y", Position: 16},
+				{Kind: translator.CallFunctionCommand, Operand: "neg", Position: 15},
+				{Kind: translator.CallFunctionCommand, Operand: "neg", Position: 14},
+				{Kind: translator.CallFunctionCommand, Operand: "+", Position: 12},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/statements",
+			args: args{text: "k = 2 * 3; let y = k * 1 in y + 0", functions: builtin.Functions()},
+			want: []translator.Command{
+				{Kind: translator.PushNumberCommand, Operand: "6", Position: 6},
+				{Kind: translator.AssignVariableCommand, Operand: "k", Position: 0},
+				{Kind: translator.PopCommand, Position: 15},
+				{Kind: translator.PushVariableCommand, Operand: "k", Position: 19},
+				{Kind: translator.BindVariableCommand, Operand: "y", Position: 15},
+				{Kind: translator.PushVariableCommand, Operand: "y", Position: 28},
+				{Kind: translator.UnbindVariableCommand, Operand: "y", Position: 15},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error",
+			args:    args{text: "x +", functions: builtin.Functions()},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands, err := calculator.Compile(tt.args.text, tt.args.functions)
+			assert.NoError(t, err)
+
+			got, err := Optimize(commands, tt.args.functions)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestOptimize_constants(t *testing.T) {
+	commands, err := calculator.CompileWithOptions("2 * pi * r", builtin.Functions(), calculator.Options{Constants: constants.Mathematical()})
+	assert.NoError(t, err)
+
+	got, err := Optimize(commands, builtin.Functions())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []translator.Command{
+		{Kind: translator.PushNumberCommand, Operand: "6.283185307179586", Position: 2},
+		{Kind: translator.PushVariableCommand, Operand: "r", Position: 9},
+		{Kind: translator.CallFunctionCommand, Operand: "*", Position: 7},
+	}, got)
+}
+
+func TestOptimize_preservesResults(t *testing.T) {
+	functions := builtin.Functions()
+	random := rand.New(rand.NewSource(1))
+	for iteration := 0; iteration < 2000; iteration++ {
+		text := randomExpression(random, 4)
+		variables := map[string]float64{
+			"x": randomOperand(random),
+			"y": randomOperand(random),
+		}
+
+		t.Run(fmt.Sprintf("%s/%v", text, variables), func(t *testing.T) {
+			commands, err := calculator.Compile(text, functions)
+			assert.NoError(t, err)
+
+			optimizedCommands, err := Optimize(commands, functions)
+			assert.NoError(t, err)
+
+			want, wantErr := evaluator.Evaluate(commands, variables, functions)
+			got, gotErr := evaluator.Evaluate(optimizedCommands, variables, functions)
+
+			assert.Equal(t, wantErr, gotErr)
+			if math.IsNaN(want) {
+				assert.True(t, math.IsNaN(got))
+			} else {
+				assert.Equal(t, want, got)
+			}
+		})
+	}
+}
+
+func randomExpression(random *rand.Rand, depth int) string {
+	if depth == 0 || random.Intn(4) == 0 {
+		operands := []string{"0", "1", "2", "0.5", "3", "x", "y"}
+		return operands[random.Intn(len(operands))]
+	}
+
+	switch random.Intn(4) {
+	case 0:
+		return "-" + randomExpression(random, depth-1)
+	case 1:
+		functionNames := []string{"sin", "sqrt", "abs"}
+		return fmt.Sprintf("%s(%s)", functionNames[random.Intn(len(functionNames))], randomExpression(random, depth-1))
+	default:
+		operators := []string{"+", "-", "*", "/", "%", "^"}
+		return strings.Join([]string{
+			"(" + randomExpression(random, depth-1),
+			operators[random.Intn(len(operators))],
+			randomExpression(random, depth-1) + ")",
+		}, " ")
+	}
+}
+
+func randomOperand(random *rand.Rand) float64 {
+	operands := []float64{0, 1, -1, 0.5, 2, 10}
+	return operands[random.Intn(len(operands))]
+}
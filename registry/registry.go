@@ -0,0 +1,174 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rmaidveo/go-calculator/evaluator"
+)
+
+type Example struct {
+	Expression string
+	Result     float64
+}
+
+type Entry struct {
+	Name        string
+	Aliases     []string
+	Parameters  []string
+	Description string
+	Category    string
+	Examples    []Example
+	Deprecated  string
+	Function    evaluator.Function
+}
+
+type Options struct {
+	CaseInsensitive bool
+}
+
+type Registry struct {
+	options Options
+	entries map[string]Entry
+	names   map[string]string
+}
+
+func New(options Options) *Registry {
+	return &Registry{
+		options: options,
+		entries: map[string]Entry{},
+		names:   map[string]string{},
+	}
+}
+
+func (registry *Registry) Register(entry Entry) error {
+	if entry.Name == "" {
+		return errors.New("function name is empty")
+	}
+	if entry.Function.Handler == nil {
+		return fmt.Errorf("function %q has no handler", entry.Name)
+	}
+	if entry.Parameters != nil && len(entry.Parameters) != entry.Function.Arity {
+		return fmt.Errorf("function %q has %d parameter names, but the arity %d", entry.Name, len(entry.Parameters), entry.Function.Arity)
+	}
+
+	names := append([]string{entry.Name}, entry.Aliases...)
+	for nameIndex, name := range names {
+		if name == "" {
+			return fmt.Errorf("function %q has an empty alias", entry.Name)
+		}
+		if canonicalName, ok := registry.names[registry.key(name)]; ok {
+			return fmt.Errorf("function name %q is already registered for %q", name, canonicalName)
+		}
+
+		for _, previousName := range names[:nameIndex] {
+			if registry.key(previousName) == registry.key(name) {
+				return fmt.Errorf("function %q has a duplicate name %q", entry.Name, name)
+			}
+		}
+	}
+
+	entry.Aliases = append([]string(nil), entry.Aliases...)
+	entry.Parameters = append([]string(nil), entry.Parameters...)
+	entry.Examples = append([]Example(nil), entry.Examples...)
+	registry.entries[entry.Name] = entry
+	for _, name := range names {
+		registry.names[registry.key(name)] = entry.Name
+	}
+
+	return nil
+}
+
+func (registry *Registry) Merge(other *Registry) error {
+	for _, entry := range other.Entries() {
+		if err := registry.Register(entry); err != nil {
+			return fmt.Errorf("unable to merge the function %q: %w", entry.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (registry *Registry) Lookup(name string) (Entry, bool) {
+	canonicalName, ok := registry.names[registry.key(name)]
+	if !ok {
+		return Entry{}, false
+	}
+
+	return registry.entries[canonicalName], true
+}
+
+func (registry *Registry) Resolve(name string) (string, bool) {
+	canonicalName, ok := registry.names[registry.key(name)]
+	return canonicalName, ok
+}
+
+func (registry *Registry) Entries() []Entry {
+	entries := make([]Entry, 0, len(registry.entries))
+	for _, entry := range registry.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+
+	return entries
+}
+
+func (registry *Registry) Categories() []string {
+	isCategory := map[string]bool{}
+	var categories []string
+	for _, entry := range registry.entries {
+		if !isCategory[entry.Category] {
+			isCategory[entry.Category] = true
+			categories = append(categories, entry.Category)
+		}
+	}
+	sort.Strings(categories)
+
+	return categories
+}
+
+func (registry *Registry) Category(category string) []Entry {
+	var entries []Entry
+	for _, entry := range registry.Entries() {
+		if entry.Category == category {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
+func (registry *Registry) Functions() map[string]evaluator.Function {
+	functions := make(map[string]evaluator.Function, len(registry.names))
+	for _, entry := range registry.entries {
+		functions[entry.Name] = entry.Function
+		for _, alias := range entry.Aliases {
+			functions[alias] = entry.Function
+		}
+	}
+
+	return functions
+}
+
+func (registry *Registry) FunctionNames() map[string]struct{} {
+	functionNames := make(map[string]struct{}, len(registry.names))
+	for functionName := range registry.Functions() {
+		functionNames[functionName] = struct{}{}
+	}
+
+	return functionNames
+}
+
+func (entry Entry) Signature() string {
+	return fmt.Sprintf("%s(%s)", entry.Name, strings.Join(entry.Parameters, ", "))
+}
+
+func (registry *Registry) key(name string) string {
+	if registry.options.CaseInsensitive {
+		return strings.ToLower(name)
+	}
+
+	return name
+}
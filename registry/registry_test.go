@@ -0,0 +1,170 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Register(t *testing.T) {
+	handler := func(arguments []float64) (float64, error) { return 0, nil }
+
+	tests := []struct {
+		name    string
+		options Options
+		entries []Entry
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			entries: []Entry{
+				{Name: "f", Aliases: []string{"g"}, Parameters: []string{"x"}, Function: evaluator.Function{Arity: 1, Handler: handler}},
+				{Name: "F", Function: evaluator.Function{Arity: 0, Handler: handler}},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error/empty name",
+			entries: []Entry{{Function: evaluator.Function{Handler: handler}}},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/no handler",
+			entries: []Entry{{Name: "f"}},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/wrong number of parameters",
+			entries: []Entry{{Name: "f", Parameters: []string{"x", "y"}, Function: evaluator.Function{Arity: 1, Handler: handler}}},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/empty alias",
+			entries: []Entry{{Name: "f", Aliases: []string{""}, Function: evaluator.Function{Handler: handler}}},
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/duplicate name",
+			entries: []Entry{
+				{Name: "f", Function: evaluator.Function{Handler: handler}},
+				{Name: "g", Aliases: []string{"f"}, Function: evaluator.Function{Handler: handler}},
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/duplicate name in different cases",
+			options: Options{CaseInsensitive: true},
+			entries: []Entry{{Name: "f", Aliases: []string{"F"}, Function: evaluator.Function{Handler: handler}}},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := New(tt.options)
+
+			var err error
+			for _, entry := range tt.entries {
+				if err = registry.Register(entry); err != nil {
+					break
+				}
+			}
+
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestRegistry_Lookup(t *testing.T) {
+	handler := func(arguments []float64) (float64, error) { return 0, nil }
+	entry := Entry{
+		Name:        "ln",
+		Aliases:     []string{"log"},
+		Parameters:  []string{"x"},
+		Description: "Natural logarithm.",
+		Category:    "exponential",
+		Examples:    []Example{{Expression: "ln(1)", Result: 0}},
+		Deprecated:  "use log",
+		Function:    evaluator.Function{Arity: 1, Handler: handler},
+	}
+
+	tests := []struct {
+		name    string
+		options Options
+		lookup  string
+		wantOk  assert.BoolAssertionFunc
+	}{
+		{name: "name", lookup: "ln", wantOk: assert.True},
+		{name: "alias", lookup: "log", wantOk: assert.True},
+		{name: "other case", lookup: "LOG", wantOk: assert.False},
+		{name: "other case/case-insensitive", options: Options{CaseInsensitive: true}, lookup: "LOG", wantOk: assert.True},
+		{name: "unknown", lookup: "exp", wantOk: assert.False},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := New(tt.options)
+			assert.NoError(t, registry.Register(entry))
+
+			got, ok := registry.Lookup(tt.lookup)
+			gotName, resolved := registry.Resolve(tt.lookup)
+
+			tt.wantOk(t, ok)
+			tt.wantOk(t, resolved)
+			if ok {
+				assert.Equal(t, "ln", got.Name)
+				assert.Equal(t, "ln", gotName)
+				assert.Equal(t, 1, got.Function.Arity)
+				assert.Equal(t, "use log", got.Deprecated)
+			}
+		})
+	}
+}
+
+func TestRegistry_Merge(t *testing.T) {
+	handler := func(arguments []float64) (float64, error) { return 0, nil }
+	first := New(Options{})
+	assert.NoError(t, first.Register(Entry{Name: "f", Category: "a", Function: evaluator.Function{Handler: handler}}))
+	second := New(Options{})
+	assert.NoError(t, second.Register(Entry{Name: "g", Aliases: []string{"h"}, Category: "b", Function: evaluator.Function{Handler: handler}}))
+	assert.NoError(t, second.Register(Entry{Name: "e", Category: "b", Function: evaluator.Function{Handler: handler}}))
+
+	err := first.Merge(second)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"e", "f", "g"}, entryNames(first.Entries()))
+	assert.Equal(t, []string{"a", "b"}, first.Categories())
+	assert.Equal(t, []string{"e", "g"}, entryNames(first.Category("b")))
+	assert.Len(t, first.Functions(), 4)
+	assert.Equal(t, map[string]struct{}{"e": {}, "f": {}, "g": {}, "h": {}}, first.FunctionNames())
+
+	err = first.Merge(second)
+
+	assert.Error(t, err)
+}
+
+func TestEntry_Signature(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry Entry
+		want  string
+	}{
+		{name: "no parameters", entry: Entry{Name: "rand"}, want: "rand()"},
+		{name: "parameters", entry: Entry{Name: "atan2", Parameters: []string{"y", "x"}}, want: "atan2(y, x)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.entry.Signature()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func entryNames(entries []Entry) []string {
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name)
+	}
+
+	return names
+}
@@ -0,0 +1,113 @@
+package render
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/rmaidveo/go-calculator/ast"
+)
+
+var (
+	errStatementsNotSupported = errors.New("statements are not supported in Go expressions")
+)
+
+var goTarget = target{
+	number: func(value string) string {
+		switch value {
+		case "NaN":
+			return "math.NaN()"
+		case "+Inf":
+			return "math.Inf(1)"
+		case "-Inf":
+			return "math.Inf(-1)"
+		}
+		if !strings.Contains(value, ".") {
+			return value + ".0"
+		}
+
+		return value
+	},
+	variable: func(name string) string {
+		return name
+	},
+	call: goCall,
+	parenthesize: func(text string) string {
+		return "(" + text + ")"
+	},
+	isDelimited: func(node *ast.Node) bool {
+		return node.Kind == ast.LetNode ||
+			node.Kind == ast.CallNode && !isOperatorCall(node, "+", "-", "*", "/", "neg")
+	},
+	assignment: func(name string, value string) (string, error) {
+		return "", errStatementsNotSupported
+	},
+	let: func(name string, value string, body string) string {
+		return "func() float64 { " + name + " := " + value + "; return " + body + " }()"
+	},
+	sequence: func(statements []string) (string, error) {
+		return "", errStatementsNotSupported
+	},
+}
+
+func Go(node *ast.Node, rules map[string]Rule) (string, error) {
+	return Renderer{rules: rules, target: goTarget}.Render(node)
+}
+
+func GoRules() map[string]Rule {
+	return map[string]Rule{
+		"+": infix(func(x string, y string) string { return x + " + " + y }),
+		"-": infix(func(x string, y string) string { return x + " - " + y }),
+		"*": infix(func(x string, y string) string { return x + " * " + y }),
+		"/": infix(func(x string, y string) string { return x + " / " + y }),
+		"%": goFunction("math.Mod"),
+		"^": goFunction("math.Pow"),
+		"neg": func(renderer Renderer, node *ast.Node) (string, error) {
+			x, err := renderer.Operand(node, 0)
+			if err != nil {
+				return "", err
+			}
+			if strings.HasPrefix(x, "-") {
+				x = renderer.Parenthesize(x)
+			}
+
+			return "-" + x, nil
+		},
+		"!":       unary(func(x string) string { return goCall("math.Gamma", []string{x + " + 1.0"}) }),
+		"°":       postfix(func(x string) string { return "(" + x + " * math.Pi / 180.0)" }),
+		"percent": postfix(func(x string) string { return "(" + x + " / 100.0)" }),
+
+		"abs":   goFunction("math.Abs"),
+		"sqrt":  goFunction("math.Sqrt"),
+		"exp":   goFunction("math.Exp"),
+		"log":   goFunction("math.Log"),
+		"log10": goFunction("math.Log10"),
+		"sin":   goFunction("math.Sin"),
+		"cos":   goFunction("math.Cos"),
+		"tan":   goFunction("math.Tan"),
+		"asin":  goFunction("math.Asin"),
+		"acos":  goFunction("math.Acos"),
+		"atan":  goFunction("math.Atan"),
+		"floor": goFunction("math.Floor"),
+		"ceil":  goFunction("math.Ceil"),
+		"round": goFunction("math.Round"),
+		"atan2": goFunction("math.Atan2"),
+		"pow":   goFunction("math.Pow"),
+		"min":   goFunction("math.Min"),
+		"max":   goFunction("math.Max"),
+	}
+}
+
+func goFunction(name string) Rule {
+	return func(renderer Renderer, node *ast.Node) (string, error) {
+		arguments, err := renderer.renderArguments(node)
+		if err != nil {
+			return "", err
+		}
+
+		return goCall(name, arguments), nil
+	}
+}
+
+func goCall(name string, arguments []string) string {
+	return name + "(" + strings.Join(arguments, ", ") + ")"
+}
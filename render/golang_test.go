@@ -0,0 +1,100 @@
+package render
+
+import (
+	"go/parser"
+	"math"
+	"testing"
+
+	"github.com/rmaidveo/go-calculator/ast"
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGo(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success/arithmetic",
+			text:    "1 / 2 + x * (y - 3.5)",
+			want:    "1.0 / 2.0 + x * (y - 3.5)",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/math functions",
+			text:    "2 * x^2 % 3 + sqrt(abs(x)) - max(x, y)",
+			want:    "math.Mod(2.0 * math.Pow(x, 2.0), 3.0) + math.Sqrt(math.Abs(x)) - math.Max(x, y)",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/negation",
+			text:    "--x - -(x + y) * -(x^2)",
+			want:    "-(-x) - -(x + y) * -math.Pow(x, 2.0)",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/postfix operators",
+			text:    "1 / 90° + (x + 1)! - percent(x - y)",
+			want:    "1.0 / (90.0 * math.Pi / 180.0) + math.Gamma(x + 1.0 + 1.0) - ((x - y) / 100.0)",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/let binding",
+			text:    "2 * (let x = x + 1 in x * x)",
+			want:    "2.0 * func() float64 { x := x + 1.0; return x * x }()",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error/assignment",
+			text:    "x = 2",
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/statements",
+			text:    "x; y",
+			want:    "",
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			functions := builtin.Functions()
+			got, err := Go(parse(t, tt.text, functions), GoRules())
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+
+			if err == nil {
+				_, err := parser.ParseExpr(got)
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGo_nonFiniteNumbers(t *testing.T) {
+	tests := []struct {
+		name   string
+		number float64
+		want   string
+	}{
+		{name: "NaN", number: math.NaN(), want: "math.NaN()"},
+		{name: "positive infinity", number: math.Inf(1), want: "math.Inf(1)"},
+		{name: "negative infinity", number: math.Inf(-1), want: "math.Inf(-1)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Go(ast.Number(tt.number, 0), GoRules())
+
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, err)
+
+			_, err = parser.ParseExpr(got)
+			assert.NoError(t, err)
+		})
+	}
+}
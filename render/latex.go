@@ -0,0 +1,111 @@
+package render
+
+import (
+	"strings"
+
+	"github.com/rmaidveo/go-calculator/ast"
+)
+
+var latexTarget = target{
+	number: func(value string) string {
+		return value
+	},
+	variable: latexName,
+	call: func(name string, arguments []string) string {
+		return latexCall(`\operatorname{`+latexEscape(name)+`}`, arguments)
+	},
+	parenthesize: func(text string) string {
+		return `\left(` + text + `\right)`
+	},
+	isDelimited: func(node *ast.Node) bool {
+		return node.Kind == ast.CallNode && (!isOperatorCall(node) || isOperatorCall(node, "/"))
+	},
+	assignment: func(name string, value string) (string, error) {
+		return latexName(name) + " = " + value, nil
+	},
+	let: func(name string, value string, body string) string {
+		return `\text{let } ` + latexName(name) + " = " + value + ` \text{ in } ` + body
+	},
+	sequence: func(statements []string) (string, error) {
+		return strings.Join(statements, `;\quad `), nil
+	},
+}
+
+func LaTeX(node *ast.Node, rules map[string]Rule) (string, error) {
+	return Renderer{rules: rules, target: latexTarget}.Render(node)
+}
+
+func LaTeXRules() map[string]Rule {
+	return map[string]Rule{
+		"+": infix(func(x string, y string) string { return x + " + " + y }),
+		"-": infix(func(x string, y string) string { return x + " - " + y }),
+		"*": infix(func(x string, y string) string { return x + ` \cdot ` + y }),
+		"/": binary(func(x string, y string) string { return `\frac{` + x + `}{` + y + `}` }),
+		"%": infix(func(x string, y string) string { return x + ` \bmod ` + y }),
+		"^": latexPower,
+		"neg": prefix(func(x string) string {
+			return "-" + x
+		}),
+		"!":       postfix(func(x string) string { return x + "!" }),
+		"°":       postfix(func(x string) string { return x + `^\circ` }),
+		"percent": postfix(func(x string) string { return x + `\%` }),
+
+		"abs":   unary(func(x string) string { return `\left|` + x + `\right|` }),
+		"sqrt":  unary(func(x string) string { return `\sqrt{` + x + `}` }),
+		"exp":   unary(func(x string) string { return `e^{` + x + `}` }),
+		"log":   latexFunction(`\ln`),
+		"log10": latexFunction(`\log_{10}`),
+		"sin":   latexFunction(`\sin`),
+		"cos":   latexFunction(`\cos`),
+		"tan":   latexFunction(`\tan`),
+		"asin":  latexFunction(`\arcsin`),
+		"acos":  latexFunction(`\arccos`),
+		"atan":  latexFunction(`\arctan`),
+		"floor": unary(func(x string) string { return `\left\lfloor ` + x + ` \right\rfloor` }),
+		"ceil":  unary(func(x string) string { return `\left\lceil ` + x + ` \right\rceil` }),
+		"pow":   latexPower,
+		"min":   latexFunction(`\min`),
+		"max":   latexFunction(`\max`),
+	}
+}
+
+func latexPower(renderer Renderer, node *ast.Node) (string, error) {
+	base, err := powerBase(renderer, node, "exp")
+	if err != nil {
+		return "", err
+	}
+
+	exponent, err := renderer.Render(node.Arguments[1])
+	if err != nil {
+		return "", err
+	}
+
+	return base + "^{" + exponent + "}", nil
+}
+
+func latexFunction(command string) Rule {
+	return func(renderer Renderer, node *ast.Node) (string, error) {
+		arguments, err := renderer.renderArguments(node)
+		if err != nil {
+			return "", err
+		}
+
+		return latexCall(command, arguments), nil
+	}
+}
+
+func latexCall(command string, arguments []string) string {
+	return command + `\left(` + strings.Join(arguments, ", ") + `\right)`
+}
+
+func latexName(name string) string {
+	if len(name) == 1 {
+		return name
+	}
+
+	return `\mathit{` + latexEscape(name) + `}`
+}
+
+func latexEscape(text string) string {
+	return strings.ReplaceAll(text, "_", `\_`)
+}
@@ -0,0 +1,83 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLaTeX(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success/fraction",
+			text:    "(a + 1) / (b*c)",
+			want:    `\frac{a + 1}{b \cdot c}`,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/fraction in product",
+			text:    "2 * (a / b)",
+			want:    `2 \cdot \frac{a}{b}`,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/power",
+			text: "x^2 + (x + 1)^(n - 1) + (a / b)^2 + (x^2)^3 + (-x)^2 + exp(x)^2 + sin(x)^2",
+			want: `x^{2} + \left(x + 1\right)^{n - 1} + \left(\frac{a}{b}\right)^{2} + ` +
+				`\left(x^{2}\right)^{3} + \left(-x\right)^{2} + \left(e^{x}\right)^{2} + ` +
+				`\sin\left(x\right)^{2}`,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/roots and brackets",
+			text:    "sqrt(x^2 + 1) * abs(x) - floor(x) % ceil(y)",
+			want:    `\sqrt{x^{2} + 1} \cdot \left|x\right| - \left\lfloor x \right\rfloor \bmod \left\lceil y \right\rceil`,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/functions",
+			text:    "log(x) + log10(x) + asin(x) + max(x, y)",
+			want:    `\ln\left(x\right) + \log_{10}\left(x\right) + \arcsin\left(x\right) + \max\left(x, y\right)`,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/negation",
+			text:    "-(a - b) * -x",
+			want:    `-\left(a - b\right) \cdot -x`,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/postfix operators",
+			text:    "n! / (2*n)! + (a + b)° + percent(x - 1) + 3!! + 2^3!",
+			want:    `\frac{n!}{\left(2 \cdot n\right)!} + \left(a + b\right)^\circ + \left(x - 1\right)\% + 3!! + 2^{3!}`,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/names and numbers",
+			text:    "rate_1 * 2.50",
+			want:    `\mathit{rate\_1} \cdot 2.5`,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/statements",
+			text:    "y = 2 * (let x = 3 in x*x); y",
+			want:    `y = 2 \cdot \left(\text{let } x = 3 \text{ in } x \cdot x\right);\quad y`,
+			wantErr: assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			functions := builtin.Functions()
+			got, err := LaTeX(parse(t, tt.text, functions), LaTeXRules())
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package render
+
+import (
+	"strings"
+
+	"github.com/rmaidveo/go-calculator/ast"
+)
+
+const (
+	mathMLNamespace = "http://www.w3.org/1998/Math/MathML"
+)
+
+var mathMLTarget = target{
+	number: func(value string) string {
+		return "<mn>" + value + "</mn>"
+	},
+	variable: mathMLIdentifier,
+	call: func(name string, arguments []string) string {
+		return mathMLCall(mathMLIdentifier(name), arguments)
+	},
+	parenthesize: func(text string) string {
+		return mathMLRow(mathMLOperator("("), text, mathMLOperator(")"))
+	},
+	isDelimited: func(node *ast.Node) bool {
+		return node.Kind == ast.CallNode && (!isOperatorCall(node) || isOperatorCall(node, "/"))
+	},
+	assignment: func(name string, value string) (string, error) {
+		return mathMLRow(mathMLIdentifier(name), mathMLOperator("="), value), nil
+	},
+	let: func(name string, value string, body string) string {
+		return mathMLRow(
+			"<mtext>let</mtext>",
+			mathMLIdentifier(name),
+			mathMLOperator("="),
+			value,
+			"<mtext>in</mtext>",
+			body,
+		)
+	},
+	sequence: func(statements []string) (string, error) {
+		return mathMLRow(strings.Join(statements, mathMLOperator(";"))), nil
+	},
+}
+
+func MathML(node *ast.Node, rules map[string]Rule) (string, error) {
+	text, err := Renderer{rules: rules, target: mathMLTarget}.Render(node)
+	if err != nil {
+		return "", err
+	}
+
+	return `<math xmlns="` + mathMLNamespace + `">` + text + "</math>", nil
+}
+
+func MathMLRules() map[string]Rule {
+	return map[string]Rule{
+		"+": mathMLInfix("+"),
+		"-": mathMLInfix("&#x2212;"),
+		"*": mathMLInfix("&#x22C5;"),
+		"/": binary(func(x string, y string) string { return "<mfrac>" + x + y + "</mfrac>" }),
+		"%": mathMLInfix("mod"),
+		"^": mathMLPower,
+		"neg": prefix(func(x string) string {
+			return mathMLRow(mathMLOperator("&#x2212;"), x)
+		}),
+		"!": postfix(func(x string) string {
+			return mathMLRow(x, mathMLOperator("!"))
+		}),
+		"°": postfix(func(x string) string {
+			return mathMLRow(x, mathMLOperator("&#x00B0;"))
+		}),
+		"percent": postfix(func(x string) string {
+			return mathMLRow(x, mathMLOperator("%"))
+		}),
+
+		"abs": unary(func(x string) string {
+			return mathMLRow(mathMLOperator("|"), x, mathMLOperator("|"))
+		}),
+		"sqrt": unary(func(x string) string { return "<msqrt>" + x + "</msqrt>" }),
+		"exp": unary(func(x string) string {
+			return "<msup>" + mathMLIdentifier("e") + x + "</msup>"
+		}),
+		"log":   mathMLFunction(mathMLIdentifier("ln")),
+		"log10": mathMLFunction("<msub>" + mathMLIdentifier("log") + "<mn>10</mn></msub>"),
+		"asin":  mathMLFunction(mathMLIdentifier("arcsin")),
+		"acos":  mathMLFunction(mathMLIdentifier("arccos")),
+		"atan":  mathMLFunction(mathMLIdentifier("arctan")),
+		"floor": unary(func(x string) string {
+			return mathMLRow(mathMLOperator("&#x230A;"), x, mathMLOperator("&#x230B;"))
+		}),
+		"ceil": unary(func(x string) string {
+			return mathMLRow(mathMLOperator("&#x2308;"), x, mathMLOperator("&#x2309;"))
+		}),
+		"pow": mathMLPower,
+	}
+}
+
+func mathMLPower(renderer Renderer, node *ast.Node) (string, error) {
+	base, err := powerBase(renderer, node, "exp")
+	if err != nil {
+		return "", err
+	}
+
+	exponent, err := renderer.Render(node.Arguments[1])
+	if err != nil {
+		return "", err
+	}
+
+	return "<msup>" + base + exponent + "</msup>", nil
+}
+
+func mathMLInfix(operator string) Rule {
+	return infix(func(x string, y string) string {
+		return mathMLRow(x, mathMLOperator(operator), y)
+	})
+}
+
+func mathMLFunction(name string) Rule {
+	return func(renderer Renderer, node *ast.Node) (string, error) {
+		arguments, err := renderer.renderArguments(node)
+		if err != nil {
+			return "", err
+		}
+
+		return mathMLCall(name, arguments), nil
+	}
+}
+
+func mathMLCall(name string, arguments []string) string {
+	return mathMLRow(
+		name,
+		mathMLOperator("&#x2061;"),
+		mathMLRow(mathMLOperator("("), strings.Join(arguments, mathMLOperator(",")), mathMLOperator(")")),
+	)
+}
+
+func mathMLIdentifier(name string) string {
+	return "<mi>" + name + "</mi>"
+}
+
+func mathMLOperator(operator string) string {
+	return "<mo>" + operator + "</mo>"
+}
+
+func mathMLRow(elements ...string) string {
+	return "<mrow>" + strings.Join(elements, "") + "</mrow>"
+}
@@ -0,0 +1,74 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMathML(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success/fraction and power",
+			text: "(a + 1) / b^2",
+			want: `<math xmlns="http://www.w3.org/1998/Math/MathML">` +
+				`<mfrac><mrow><mi>a</mi><mo>+</mo><mn>1</mn></mrow><msup><mi>b</mi><mn>2</mn></msup></mfrac>` +
+				`</math>`,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/parentheses",
+			text: "2 * (x - y)^2",
+			want: `<math xmlns="http://www.w3.org/1998/Math/MathML">` +
+				`<mrow><mn>2</mn><mo>&#x22C5;</mo><msup>` +
+				`<mrow><mo>(</mo><mrow><mi>x</mi><mo>&#x2212;</mo><mi>y</mi></mrow><mo>)</mo></mrow>` +
+				`<mn>2</mn></msup></mrow>` +
+				`</math>`,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/functions",
+			text: "sqrt(abs(-x)) + sin(x)",
+			want: `<math xmlns="http://www.w3.org/1998/Math/MathML">` +
+				`<mrow><msqrt><mrow><mo>|</mo><mrow><mo>&#x2212;</mo><mi>x</mi></mrow><mo>|</mo></mrow></msqrt>` +
+				`<mo>+</mo>` +
+				`<mrow><mi>sin</mi><mo>&#x2061;</mo><mrow><mo>(</mo><mi>x</mi><mo>)</mo></mrow></mrow></mrow>` +
+				`</math>`,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/postfix operators",
+			text: "(n + 1)! * 90°",
+			want: `<math xmlns="http://www.w3.org/1998/Math/MathML">` +
+				`<mrow><mrow><mrow><mo>(</mo><mrow><mi>n</mi><mo>+</mo><mn>1</mn></mrow><mo>)</mo></mrow><mo>!</mo></mrow>` +
+				`<mo>&#x22C5;</mo><mrow><mn>90</mn><mo>&#x00B0;</mo></mrow></mrow>` +
+				`</math>`,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/statements",
+			text: "y = let x = 2 in x; y",
+			want: `<math xmlns="http://www.w3.org/1998/Math/MathML">` +
+				`<mrow><mrow><mi>y</mi><mo>=</mo>` +
+				`<mrow><mtext>let</mtext><mi>x</mi><mo>=</mo><mn>2</mn><mtext>in</mtext><mi>x</mi></mrow></mrow>` +
+				`<mo>;</mo><mi>y</mi></mrow>` +
+				`</math>`,
+			wantErr: assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			functions := builtin.Functions()
+			got, err := MathML(parse(t, tt.text, functions), MathMLRules())
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package render
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/rmaidveo/go-calculator/ast"
+)
+
+var (
+	errUnsupportedNode = errors.New("node is not supported")
+)
+
+type Rule func(renderer Renderer, node *ast.Node) (string, error)
+
+type target struct {
+	number       func(value string) string
+	variable     func(name string) string
+	call         func(name string, arguments []string) string
+	parenthesize func(text string) string
+	isDelimited  func(node *ast.Node) bool
+	assignment   func(name string, value string) (string, error)
+	let          func(name string, value string, body string) string
+	sequence     func(statements []string) (string, error)
+}
+
+type Renderer struct {
+	rules  map[string]Rule
+	target target
+}
+
+func (renderer Renderer) Render(node *ast.Node) (string, error) {
+	switch node.Kind {
+	case ast.NumberNode:
+		number, ok := node.Number()
+		if !ok {
+			return "", fmt.Errorf("invalid number %q at position %d", node.Value, node.Position)
+		}
+
+		return renderer.target.number(strconv.FormatFloat(number, 'f', -1, 64)), nil
+	case ast.VariableNode:
+		return renderer.target.variable(node.Value), nil
+	case ast.CallNode:
+		if rule, ok := renderer.rules[node.Value]; ok {
+			text, err := rule(renderer, node)
+			if err != nil {
+				return "", fmt.Errorf("unable to render the function %q at position %d: %w", node.Value, node.Position, err)
+			}
+
+			return text, nil
+		}
+
+		arguments, err := renderer.renderArguments(node)
+		if err != nil {
+			return "", err
+		}
+
+		return renderer.target.call(node.Value, arguments), nil
+	case ast.AssignmentNode:
+		value, err := renderer.Render(node.Arguments[0])
+		if err != nil {
+			return "", err
+		}
+
+		text, err := renderer.target.assignment(node.Value, value)
+		if err != nil {
+			return "", fmt.Errorf("unable to render the assignment to %q at position %d: %w", node.Value, node.Position, err)
+		}
+
+		return text, nil
+	case ast.LetNode:
+		value, err := renderer.Render(node.Arguments[0])
+		if err != nil {
+			return "", err
+		}
+
+		body, err := renderer.Render(node.Arguments[1])
+		if err != nil {
+			return "", err
+		}
+
+		return renderer.target.let(node.Value, value, body), nil
+	case ast.SequenceNode:
+		statements, err := renderer.renderArguments(node)
+		if err != nil {
+			return "", err
+		}
+
+		text, err := renderer.target.sequence(statements)
+		if err != nil {
+			return "", fmt.Errorf("unable to render the statements at position %d: %w", node.Position, err)
+		}
+
+		return text, nil
+	default:
+		return "", fmt.Errorf("%w: kind %d at position %d", errUnsupportedNode, node.Kind, node.Position)
+	}
+}
+
+func (renderer Renderer) Operand(node *ast.Node, argumentIndex int) (string, error) {
+	argument := node.Arguments[argumentIndex]
+	text, err := renderer.Render(argument)
+	if err != nil {
+		return "", err
+	}
+
+	if argument.NeedsParentheses(node, argumentIndex) && !renderer.target.isDelimited(argument) {
+		return renderer.Parenthesize(text), nil
+	}
+
+	return text, nil
+}
+
+func (renderer Renderer) Parenthesize(text string) string {
+	return renderer.target.parenthesize(text)
+}
+
+func (renderer Renderer) renderArguments(node *ast.Node) ([]string, error) {
+	arguments := make([]string, 0, len(node.Arguments))
+	for _, argument := range node.Arguments {
+		text, err := renderer.Render(argument)
+		if err != nil {
+			return nil, err
+		}
+
+		arguments = append(arguments, text)
+	}
+
+	return arguments, nil
+}
+
+func isOperatorCall(node *ast.Node, operators ...string) bool {
+	if _, ok := node.Operator(); !ok {
+		return false
+	}
+	if len(operators) == 0 {
+		return true
+	}
+
+	for _, operator := range operators {
+		if node.Value == operator {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isAtom(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.NumberNode:
+		number, ok := node.Number()
+		return ok && number >= 0
+	case ast.VariableNode:
+		return true
+	default:
+		return false
+	}
+}
+
+func unary(template func(x string) string) Rule {
+	return func(renderer Renderer, node *ast.Node) (string, error) {
+		x, err := renderer.Render(node.Arguments[0])
+		if err != nil {
+			return "", err
+		}
+
+		return template(x), nil
+	}
+}
+
+func prefix(template func(x string) string) Rule {
+	return func(renderer Renderer, node *ast.Node) (string, error) {
+		x, err := renderer.Operand(node, 0)
+		if err != nil {
+			return "", err
+		}
+
+		return template(x), nil
+	}
+}
+
+func infix(template func(x string, y string) string) Rule {
+	return func(renderer Renderer, node *ast.Node) (string, error) {
+		x, err := renderer.Operand(node, 0)
+		if err != nil {
+			return "", err
+		}
+
+		y, err := renderer.Operand(node, 1)
+		if err != nil {
+			return "", err
+		}
+
+		return template(x, y), nil
+	}
+}
+
+func binary(template func(x string, y string) string) Rule {
+	return func(renderer Renderer, node *ast.Node) (string, error) {
+		arguments, err := renderer.renderArguments(node)
+		if err != nil {
+			return "", err
+		}
+
+		return template(arguments[0], arguments[1]), nil
+	}
+}
+
+func powerBase(renderer Renderer, node *ast.Node, superscriptFunctions ...string) (string, error) {
+	base := node.Arguments[0]
+	text, err := renderer.Render(base)
+	if err != nil {
+		return "", err
+	}
+
+	isPlainCall := base.Kind == ast.CallNode && !isOperatorCall(base) && base.Value != "pow"
+	for _, superscriptFunction := range superscriptFunctions {
+		isPlainCall = isPlainCall && base.Value != superscriptFunction
+	}
+	if isAtom(base) || isPlainCall {
+		return text, nil
+	}
+
+	return renderer.Parenthesize(text), nil
+}
+
+func postfix(template func(x string) string) Rule {
+	return func(renderer Renderer, node *ast.Node) (string, error) {
+		argument := node.Arguments[0]
+		x, err := renderer.Render(argument)
+		if err != nil {
+			return "", err
+		}
+
+		isPlainCall := argument.Kind == ast.CallNode &&
+			(!isOperatorCall(argument) || isOperatorCall(argument, "!", "°"))
+		if !isAtom(argument) && !isPlainCall {
+			x = renderer.Parenthesize(x)
+		}
+
+		return template(x), nil
+	}
+}
@@ -0,0 +1,92 @@
+package render
+
+import (
+	"errors"
+	"testing"
+
+	calculator "github.com/rmaidveo/go-calculator"
+	"github.com/rmaidveo/go-calculator/ast"
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderer(t *testing.T) {
+	functions := builtin.Functions()
+	functions["hypot"] = evaluator.Function{Arity: 2}
+	functions["fail"] = evaluator.Function{Arity: 1}
+
+	rules := LaTeXRules()
+	rules["hypot"] = func(renderer Renderer, node *ast.Node) (string, error) {
+		x, err := renderer.Operand(node, 0)
+		if err != nil {
+			return "", err
+		}
+
+		y, err := renderer.Operand(node, 1)
+		if err != nil {
+			return "", err
+		}
+
+		return renderer.Parenthesize(x+", "+y) + "_{2}", nil
+	}
+	rules["fail"] = func(renderer Renderer, node *ast.Node) (string, error) {
+		return "", errors.New("failure")
+	}
+
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success/user rule",
+			text:    "2 * hypot(x, y + 1)",
+			want:    `2 \cdot \left(x, y + 1\right)_{2}`,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/function without rule",
+			text:    "round(x) + atan2(y, x)",
+			want:    `\operatorname{round}\left(x\right) + \operatorname{atan2}\left(y, x\right)`,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error/rule failure",
+			text:    "1 + sin(fail(x))",
+			want:    "",
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LaTeX(parse(t, tt.text, functions), rules)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestRenderer_Render(t *testing.T) {
+	renderer := Renderer{rules: GoRules(), target: goTarget}
+
+	got, err := renderer.Render(&ast.Node{Kind: ast.NumberNode, Value: "x", Position: 42})
+	assert.Equal(t, "", got)
+	assert.Error(t, err)
+
+	got, err = renderer.Render(&ast.Node{Kind: ast.NodeKind(100), Position: 42})
+	assert.Equal(t, "", got)
+	assert.ErrorIs(t, err, errUnsupportedNode)
+}
+
+func parse(t *testing.T, text string, functions map[string]evaluator.Function) *ast.Node {
+	commands, err := calculator.Compile(text, functions)
+	assert.NoError(t, err)
+
+	node, err := ast.Build(commands, functions)
+	assert.NoError(t, err)
+
+	return node
+}
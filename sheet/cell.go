@@ -0,0 +1,116 @@
+package sheet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	MaxColumn = 16384
+	MaxRow    = 1048576
+)
+
+type Cell struct {
+	Column int
+	Row    int
+}
+
+type Range struct {
+	From Cell
+	To   Cell
+}
+
+func ParseCell(text string) (Cell, error) {
+	letters, digits, ok := splitCellReference(text)
+	if !ok {
+		return Cell{}, fmt.Errorf("invalid cell reference %q", text)
+	}
+
+	column := 0
+	for _, letter := range letters {
+		column = column*26 + int(letter-'A') + 1
+		if column > MaxColumn {
+			return Cell{}, fmt.Errorf("column of the cell reference %q is out of range", text)
+		}
+	}
+
+	row, err := strconv.Atoi(digits)
+	if err != nil || row < 1 || row > MaxRow {
+		return Cell{}, fmt.Errorf("row of the cell reference %q is out of range", text)
+	}
+
+	return Cell{Column: column, Row: row}, nil
+}
+
+func ParseRange(text string) (Range, error) {
+	fromText, toText, ok := strings.Cut(text, ":")
+	if !ok {
+		return Range{}, fmt.Errorf("invalid range %q", text)
+	}
+
+	from, err := ParseCell(fromText)
+	if err != nil {
+		return Range{}, fmt.Errorf("unable to parse the range start: %w", err)
+	}
+
+	to, err := ParseCell(toText)
+	if err != nil {
+		return Range{}, fmt.Errorf("unable to parse the range end: %w", err)
+	}
+
+	return Range{From: from, To: to}, nil
+}
+
+func (cell Cell) String() string {
+	var letters []byte
+	for column := cell.Column; column > 0; column = (column - 1) / 26 {
+		letters = append([]byte{byte('A' + (column-1)%26)}, letters...)
+	}
+
+	return string(letters) + strconv.Itoa(cell.Row)
+}
+
+func (cellRange Range) Cells() []Cell {
+	fromColumn, toColumn := cellRange.From.Column, cellRange.To.Column
+	if fromColumn > toColumn {
+		fromColumn, toColumn = toColumn, fromColumn
+	}
+
+	fromRow, toRow := cellRange.From.Row, cellRange.To.Row
+	if fromRow > toRow {
+		fromRow, toRow = toRow, fromRow
+	}
+
+	cells := make([]Cell, 0, (toColumn-fromColumn+1)*(toRow-fromRow+1))
+	for row := fromRow; row <= toRow; row++ {
+		for column := fromColumn; column <= toColumn; column++ {
+			cells = append(cells, Cell{Column: column, Row: row})
+		}
+	}
+
+	return cells
+}
+
+func (cellRange Range) String() string {
+	return cellRange.From.String() + ":" + cellRange.To.String()
+}
+
+func splitCellReference(text string) (string, string, bool) {
+	text = strings.ReplaceAll(text, "$", "")
+	letterCount := 0
+	for letterCount < len(text) && text[letterCount] >= 'A' && text[letterCount] <= 'Z' {
+		letterCount++
+	}
+	if letterCount == 0 || letterCount == len(text) {
+		return "", "", false
+	}
+
+	for _, digit := range text[letterCount:] {
+		if digit < '0' || digit > '9' {
+			return "", "", false
+		}
+	}
+
+	return text[:letterCount], text[letterCount:], true
+}
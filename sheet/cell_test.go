@@ -0,0 +1,113 @@
+package sheet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCell(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    Cell
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "success/simple", text: "A1", want: Cell{Column: 1, Row: 1}, wantErr: assert.NoError},
+		{name: "success/absolute", text: "$B$2", want: Cell{Column: 2, Row: 2}, wantErr: assert.NoError},
+		{name: "success/several letters", text: "AA10", want: Cell{Column: 27, Row: 10}, wantErr: assert.NoError},
+		{name: "success/last cell", text: "XFD1048576", want: Cell{Column: MaxColumn, Row: MaxRow}, wantErr: assert.NoError},
+		{name: "error/zero row", text: "A0", want: Cell{}, wantErr: assert.Error},
+		{name: "error/column out of range", text: "XFE1", want: Cell{}, wantErr: assert.Error},
+		{name: "error/row out of range", text: "A1048577", want: Cell{}, wantErr: assert.Error},
+		{name: "error/no row", text: "AB", want: Cell{}, wantErr: assert.Error},
+		{name: "error/lowercase", text: "a1", want: Cell{}, wantErr: assert.Error},
+		{name: "error/name", text: "rate", want: Cell{}, wantErr: assert.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCell(tt.text)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    Range
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success",
+			text:    "A1:$C$10",
+			want:    Range{From: Cell{Column: 1, Row: 1}, To: Cell{Column: 3, Row: 10}},
+			wantErr: assert.NoError,
+		},
+		{name: "error/no separator", text: "A1", want: Range{}, wantErr: assert.Error},
+		{name: "error/invalid start", text: "A0:B1", want: Range{}, wantErr: assert.Error},
+		{name: "error/invalid end", text: "A1:B", want: Range{}, wantErr: assert.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRange(tt.text)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestCell_String(t *testing.T) {
+	tests := []struct {
+		name string
+		cell Cell
+		want string
+	}{
+		{name: "first", cell: Cell{Column: 1, Row: 1}, want: "A1"},
+		{name: "last letter", cell: Cell{Column: 26, Row: 3}, want: "Z3"},
+		{name: "two letters", cell: Cell{Column: 27, Row: 3}, want: "AA3"},
+		{name: "last column", cell: Cell{Column: MaxColumn, Row: 7}, want: "XFD7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.cell.String()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRange_Cells(t *testing.T) {
+	tests := []struct {
+		name      string
+		cellRange Range
+		want      []Cell
+	}{
+		{
+			name:      "single cell",
+			cellRange: Range{From: Cell{Column: 2, Row: 2}, To: Cell{Column: 2, Row: 2}},
+			want:      []Cell{{Column: 2, Row: 2}},
+		},
+		{
+			name:      "rectangle",
+			cellRange: Range{From: Cell{Column: 1, Row: 1}, To: Cell{Column: 2, Row: 2}},
+			want:      []Cell{{Column: 1, Row: 1}, {Column: 2, Row: 1}, {Column: 1, Row: 2}, {Column: 2, Row: 2}},
+		},
+		{
+			name:      "reversed",
+			cellRange: Range{From: Cell{Column: 1, Row: 3}, To: Cell{Column: 1, Row: 1}},
+			want:      []Cell{{Column: 1, Row: 1}, {Column: 1, Row: 2}, {Column: 1, Row: 3}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.cellRange.Cells()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
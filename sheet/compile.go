@@ -0,0 +1,292 @@
+package sheet
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/rmaidveo/go-calculator/tokenizer"
+	"github.com/rmaidveo/go-calculator/translator"
+)
+
+const (
+	maxRangeSize = 1 << 16
+)
+
+var aggregates = map[string]struct{}{
+	"sum":     {},
+	"average": {},
+	"count":   {},
+	"min":     {},
+	"max":     {},
+}
+
+func Compile(
+	text string,
+	functions map[string]evaluator.Function,
+) ([]translator.Command, map[string]evaluator.Function, error) {
+	formulaFunctions := make(map[string]evaluator.Function, len(functions))
+	for name, function := range functions {
+		formulaFunctions[name] = function
+	}
+
+	commands, err := compile(text, formulaFunctions, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return commands, formulaFunctions, nil
+}
+
+// the aggregate functions of the formula are added to the functions;
+// they skip the cells of ranges that are empty at evaluation time
+func compile(text string, functions map[string]evaluator.Function, isEmpty func(cell Cell) bool) ([]translator.Command, error) {
+	tokens, err := tokenizer.TokenizeWithOptions(text, tokenizer.Options{CellReferences: true})
+	if err != nil {
+		return nil, fmt.Errorf("unable to tokenize: %w", err)
+	}
+
+	for tokenIndex := range tokens {
+		if tokens[tokenIndex].Kind == tokenizer.IdentifierToken {
+			tokens[tokenIndex].Value = strings.ReplaceAll(tokens[tokenIndex].Value, "$", "")
+		}
+	}
+
+	tokens, err = expandRanges(tokens, functions, isEmpty)
+	if err != nil {
+		return nil, fmt.Errorf("unable to expand ranges: %w", err)
+	}
+
+	functionNames := make(map[string]struct{}, len(functions))
+	for functionName := range functions {
+		functionNames[functionName] = struct{}{}
+	}
+
+	commands, err := translator.Translate(tokens, functionNames)
+	if err != nil {
+		return nil, fmt.Errorf("unable to translate: %w", err)
+	}
+
+	return commands, nil
+}
+
+func expandRanges(
+	tokens []tokenizer.Token,
+	functions map[string]evaluator.Function,
+	isEmpty func(cell Cell) bool,
+) ([]tokenizer.Token, error) {
+	var expandedTokens []tokenizer.Token
+	for tokenIndex := 0; tokenIndex < len(tokens); tokenIndex++ {
+		token := tokens[tokenIndex]
+		if token.Kind == tokenizer.ColonToken {
+			return nil, fmt.Errorf("unexpected range outside of an aggregate function at position %d", token.Position)
+		}
+
+		aggregateName := strings.ToLower(token.Value)
+		_, isAggregate := aggregates[aggregateName]
+		if token.Kind != tokenizer.IdentifierToken ||
+			!isAggregate ||
+			tokenIndex+1 >= len(tokens) ||
+			tokens[tokenIndex+1].Kind != tokenizer.LeftParenthesisToken {
+			expandedTokens = append(expandedTokens, token)
+			continue
+		}
+
+		closingIndex := findClosingParenthesis(tokens, tokenIndex+1)
+		if closingIndex == -1 {
+			return nil, fmt.Errorf("no right parenthesis is found for the function %q at position %d", token.Value, token.Position)
+		}
+
+		var operands [][]tokenizer.Token
+		var argumentRanges [][]Cell
+		var argumentNames []string
+		for _, argument := range splitArguments(tokens[tokenIndex+2 : closingIndex]) {
+			switch {
+			case len(argument) == 0:
+				return nil, fmt.Errorf("empty argument of the function %q at position %d", token.Value, token.Position)
+			case isRange(argument):
+				cellRange, rangeOperands, err := expandRange(argument)
+				if err != nil {
+					return nil, err
+				}
+
+				operands = append(operands, rangeOperands...)
+				argumentRanges = append(argumentRanges, cellRange.Cells())
+				argumentNames = append(argumentNames, cellRange.String())
+			default:
+				expandedArgument, err := expandRanges(argument, functions, isEmpty)
+				if err != nil {
+					return nil, err
+				}
+
+				operands = append(operands, expandedArgument)
+				argumentRanges = append(argumentRanges, nil)
+				argumentNames = append(argumentNames, "_")
+			}
+		}
+
+		functionName := fmt.Sprintf("%s(%s)", aggregateName, strings.Join(argumentNames, ","))
+		functions[functionName] = aggregateFunction(aggregateName, argumentRanges, isEmpty)
+		expandedTokens = append(expandedTokens, call(functionName, token.Position, operands)...)
+		tokenIndex = closingIndex
+	}
+
+	return expandedTokens, nil
+}
+
+func findClosingParenthesis(tokens []tokenizer.Token, openingIndex int) int {
+	depth := 0
+	for tokenIndex := openingIndex; tokenIndex < len(tokens); tokenIndex++ {
+		switch tokens[tokenIndex].Kind {
+		case tokenizer.LeftParenthesisToken:
+			depth++
+		case tokenizer.RightParenthesisToken:
+			depth--
+			if depth == 0 {
+				return tokenIndex
+			}
+		}
+	}
+
+	return -1
+}
+
+func splitArguments(tokens []tokenizer.Token) [][]tokenizer.Token {
+	var arguments [][]tokenizer.Token
+	argumentStart := 0
+	depth := 0
+	for tokenIndex, token := range tokens {
+		switch {
+		case token.Kind == tokenizer.LeftParenthesisToken:
+			depth++
+		case token.Kind == tokenizer.RightParenthesisToken:
+			depth--
+		case token.Kind == tokenizer.CommaToken && depth == 0:
+			arguments = append(arguments, tokens[argumentStart:tokenIndex])
+			argumentStart = tokenIndex + 1
+		}
+	}
+
+	return append(arguments, tokens[argumentStart:])
+}
+
+func isRange(tokens []tokenizer.Token) bool {
+	return len(tokens) == 3 &&
+		tokens[0].Kind == tokenizer.IdentifierToken &&
+		tokens[1].Kind == tokenizer.ColonToken &&
+		tokens[2].Kind == tokenizer.IdentifierToken
+}
+
+func expandRange(tokens []tokenizer.Token) (Range, [][]tokenizer.Token, error) {
+	cellRange, err := ParseRange(tokens[0].Value + ":" + tokens[2].Value)
+	if err != nil {
+		return Range{}, nil, fmt.Errorf("unable to parse the range at position %d: %w", tokens[0].Position, err)
+	}
+
+	cells := cellRange.Cells()
+	if len(cells) > maxRangeSize {
+		return Range{}, nil, fmt.Errorf("range %s at position %d has more than %d cells", cellRange, tokens[0].Position, maxRangeSize)
+	}
+
+	operands := make([][]tokenizer.Token, 0, len(cells))
+	for _, cell := range cells {
+		operands = append(operands, []tokenizer.Token{{
+			Kind:     tokenizer.IdentifierToken,
+			Value:    cell.String(),
+			Position: tokens[0].Position,
+		}})
+	}
+
+	return cellRange, operands, nil
+}
+
+func call(functionName string, position int, operands [][]tokenizer.Token) []tokenizer.Token {
+	tokens := []tokenizer.Token{
+		{Kind: tokenizer.IdentifierToken, Value: functionName, Position: position},
+		{Kind: tokenizer.LeftParenthesisToken, Position: position},
+	}
+	for operandIndex, operand := range operands {
+		if operandIndex > 0 {
+			tokens = append(tokens, tokenizer.Token{Kind: tokenizer.CommaToken, Position: position})
+		}
+
+		tokens = append(tokens, operand...)
+	}
+
+	return append(tokens, tokenizer.Token{Kind: tokenizer.RightParenthesisToken, Position: position})
+}
+
+// the ranges hold the cells of each argument, or nil for an argument that is not a range
+func aggregateFunction(aggregateName string, argumentRanges [][]Cell, isEmpty func(cell Cell) bool) evaluator.Function {
+	arity := 0
+	for _, cells := range argumentRanges {
+		if cells == nil {
+			arity++
+			continue
+		}
+
+		arity += len(cells)
+	}
+
+	return evaluator.Function{
+		Arity: arity,
+		Handler: func(arguments []float64) (float64, error) {
+			values := make([]float64, 0, len(arguments))
+			argumentIndex := 0
+			for _, cells := range argumentRanges {
+				if cells == nil {
+					values = append(values, arguments[argumentIndex])
+					argumentIndex++
+					continue
+				}
+
+				for _, cell := range cells {
+					if isEmpty == nil || !isEmpty(cell) {
+						values = append(values, arguments[argumentIndex])
+					}
+
+					argumentIndex++
+				}
+			}
+
+			return aggregate(aggregateName, values)
+		},
+	}
+}
+
+func aggregate(aggregateName string, values []float64) (float64, error) {
+	switch aggregateName {
+	case "count":
+		return float64(len(values)), nil
+	case "min", "max":
+		if len(values) == 0 {
+			return 0, nil
+		}
+
+		result := values[0]
+		for _, value := range values[1:] {
+			if aggregateName == "min" {
+				result = math.Min(result, value)
+			} else {
+				result = math.Max(result, value)
+			}
+		}
+
+		return result, nil
+	}
+
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	if aggregateName == "sum" {
+		return sum, nil
+	}
+	if len(values) == 0 {
+		return 0, builtin.ErrDivisionByZero
+	}
+
+	return sum / float64(len(values)), nil
+}
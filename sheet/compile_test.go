@@ -0,0 +1,50 @@
+package sheet
+
+import (
+	"testing"
+
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompile(t *testing.T) {
+	variables := map[string]float64{"A1": 1, "A2": 2, "A3": 3, "B1": 10, "B2": 20, "B3": 30}
+
+	tests := []struct {
+		name    string
+		text    string
+		want    float64
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "success/references", text: "$A$1 + B$2 * $A3", want: 61, wantErr: assert.NoError},
+		{name: "success/sum", text: "sum(A1:B2)", want: 33, wantErr: assert.NoError},
+		{name: "success/sum of several arguments", text: "sum(A1:A3, B1 * 2, 4)", want: 30, wantErr: assert.NoError},
+		{name: "success/average", text: "average(B1:B3)", want: 20, wantErr: assert.NoError},
+		{name: "success/count", text: "count(A1:B3, 7)", want: 7, wantErr: assert.NoError},
+		{name: "success/min", text: "min(B3:A1)", want: 1, wantErr: assert.NoError},
+		{name: "success/max", text: "max(A1:A3, -B1) + 1", want: 4, wantErr: assert.NoError},
+		{name: "success/single argument", text: "max(B2)", want: 20, wantErr: assert.NoError},
+		{name: "success/case-insensitive aggregates", text: "SUM(A1:A3) + Average(B1:B3)", want: 26, wantErr: assert.NoError},
+		{name: "success/nested aggregates", text: "sum(A1, max(B1:B3), count(A1:A3))", want: 34, wantErr: assert.NoError},
+		{name: "error/range outside of an aggregate", text: "A1:A3 + 1", want: 0, wantErr: assert.Error},
+		{name: "error/empty argument", text: "sum(A1, )", want: 0, wantErr: assert.Error},
+		{name: "error/no arguments", text: "sum()", want: 0, wantErr: assert.Error},
+		{name: "error/unclosed call", text: "sum(A1:A3", want: 0, wantErr: assert.Error},
+		{name: "error/invalid range", text: "sum(x:y)", want: 0, wantErr: assert.Error},
+		{name: "error/too large range", text: "sum(A1:Z100000)", want: 0, wantErr: assert.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands, functions, err := Compile(tt.text, builtin.Functions())
+
+			var got float64
+			if err == nil {
+				got, err = evaluator.Evaluate(commands, variables, functions)
+			}
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package sheet
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/rmaidveo/go-calculator/evaluator"
+)
+
+func ReadCSV(reader io.Reader, functions map[string]evaluator.Function) (*Sheet, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the CSV: %w", err)
+	}
+
+	sheet := New(functions)
+	for rowIndex, record := range records {
+		for columnIndex, content := range record {
+			cell := Cell{Column: columnIndex + 1, Row: rowIndex + 1}
+			if _, err := sheet.Set(cell, content); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return sheet, nil
+}
+
+func (sheet *Sheet) WriteCSV(writer io.Writer) error {
+	return sheet.writeCSV(writer, sheet.Content)
+}
+
+func (sheet *Sheet) WriteValuesCSV(writer io.Writer) error {
+	return sheet.writeCSV(writer, sheet.Text)
+}
+
+func (sheet *Sheet) writeCSV(writer io.Writer, field func(cell Cell) string) error {
+	columnCount, rowCount := 0, 0
+	for cell := range sheet.contents {
+		if cell.Column > columnCount {
+			columnCount = cell.Column
+		}
+		if cell.Row > rowCount {
+			rowCount = cell.Row
+		}
+	}
+
+	csvWriter := csv.NewWriter(writer)
+	for row := 1; row <= rowCount; row++ {
+		record := make([]string, columnCount)
+		for column := 1; column <= columnCount; column++ {
+			record[column-1] = field(Cell{Column: column, Row: row})
+		}
+
+		if err := csvWriter.Write(record); err != nil {
+			return fmt.Errorf("unable to write the row %d: %w", row, err)
+		}
+	}
+
+	csvWriter.Flush()
+	if err := csvWriter.Error(); err != nil {
+		return fmt.Errorf("unable to write the CSV: %w", err)
+	}
+
+	return nil
+}
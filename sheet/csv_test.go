@@ -0,0 +1,91 @@
+package sheet
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadCSV(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		wantCSV    string
+		wantValues string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			text: "12.5,4,=A1*B1\n" +
+				"3,10,=A2*B2\n" +
+				",,=sum(C1:C2)\n" +
+				",,\"=average(C1:C2) / (B3 - B3)\"\n",
+			wantCSV: "12.5,4,=A1*B1\n" +
+				"3,10,=A2*B2\n" +
+				",,=sum(C1:C2)\n" +
+				",,=average(C1:C2) / (B3 - B3)\n",
+			wantValues: "12.5,4,50\n" +
+				"3,10,30\n" +
+				",,80\n" +
+				",,#DIV/0!\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:       "success/formulas",
+			text:       "1,2,=A1+B1\n=C1*2,,=max(A1:C1)\n",
+			wantCSV:    "1,2,=A1+B1\n=C1*2,,=max(A1:C1)\n",
+			wantValues: "1,2,3\n6,,3\n",
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "success/range filled after the formula",
+			text:       "=count(C1:E1),=average(C1:E1),1,,2\n",
+			wantCSV:    "=count(C1:E1),=average(C1:E1),1,,2\n",
+			wantValues: "2,1.5,1,,2\n",
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "success/errors",
+			text:       "0,=1/A1\n=B1+1,=A0\n",
+			wantCSV:    "0,=1/A1\n=B1+1,=A0\n",
+			wantValues: "0,#DIV/0!\n#DIV/0!,#REF!\n",
+			wantErr:    assert.NoError,
+		},
+		{
+			name:    "error/cycle",
+			text:    "=B1,=A1\n",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/text",
+			text:    "price,qty\n",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/invalid CSV",
+			text:    "\"1,2\n",
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sheet, err := ReadCSV(strings.NewReader(tt.text), builtin.Functions())
+			tt.wantErr(t, err)
+			if err != nil {
+				assert.Nil(t, sheet)
+				return
+			}
+
+			var gotCSV bytes.Buffer
+			assert.NoError(t, sheet.WriteCSV(&gotCSV))
+			assert.Equal(t, tt.wantCSV, gotCSV.String())
+
+			var gotValues bytes.Buffer
+			assert.NoError(t, sheet.WriteValuesCSV(&gotValues))
+			assert.Equal(t, tt.wantValues, gotValues.String())
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package sheet
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/rmaidveo/go-calculator/graph"
+	"github.com/rmaidveo/go-calculator/translator"
+)
+
+const (
+	formulaPrefix = "="
+)
+
+type CellError string
+
+const (
+	ErrDivisionByZero CellError = "#DIV/0!"
+	ErrName           CellError = "#NAME?"
+	ErrNumber         CellError = "#NUM!"
+	ErrReference      CellError = "#REF!"
+	ErrValue          CellError = "#VALUE!"
+)
+
+func (err CellError) Error() string {
+	return string(err)
+}
+
+type Sheet struct {
+	functions       map[string]evaluator.Function
+	contents        map[Cell]string
+	constants       map[string]float64
+	formulas        map[string][]translator.Command
+	referenceErrs   map[string]CellError
+	errs            map[string]CellError
+	dependencyGraph *graph.Graph
+}
+
+func New(functions map[string]evaluator.Function) *Sheet {
+	sheetFunctions := make(map[string]evaluator.Function, len(functions))
+	for name, function := range functions {
+		sheetFunctions[name] = function
+	}
+
+	dependencyGraph, _ := graph.New(nil, sheetFunctions)
+	return &Sheet{
+		functions:       sheetFunctions,
+		contents:        map[Cell]string{},
+		constants:       map[string]float64{},
+		formulas:        map[string][]translator.Command{},
+		referenceErrs:   map[string]CellError{},
+		errs:            map[string]CellError{},
+		dependencyGraph: dependencyGraph,
+	}
+}
+
+func (sheet *Sheet) Set(cell Cell, content string) ([]Cell, error) {
+	content = strings.TrimSpace(content)
+	number, commands, err := sheet.parseContent(cell, content)
+	if err != nil {
+		return nil, err
+	}
+
+	// the content is stored before the recalculation, so that the ranges see whether the cell is empty
+	name := cell.String()
+	previousContent, previousNumber, previousCommands := sheet.contents[cell], sheet.constants[name], sheet.formulas[name]
+	sheet.setContent(cell, content, number, commands)
+
+	recalculatedNames, err := sheet.recalculate(cell)
+	if err != nil {
+		sheet.setContent(cell, previousContent, previousNumber, previousCommands)
+		return nil, err
+	}
+
+	sheet.updateErrs(recalculatedNames)
+	return cellsForNames(recalculatedNames), nil
+}
+
+func (sheet *Sheet) Content(cell Cell) string {
+	return sheet.contents[cell]
+}
+
+func (sheet *Sheet) Value(cell Cell) (float64, error) {
+	name := cell.String()
+	if _, ok := sheet.formulas[name]; !ok {
+		return sheet.constants[name], nil
+	}
+	if err, ok := sheet.errs[name]; ok {
+		return 0, err
+	}
+
+	return sheet.dependencyGraph.Value(name)
+}
+
+func (sheet *Sheet) Text(cell Cell) string {
+	if _, ok := sheet.contents[cell]; !ok {
+		return ""
+	}
+
+	value, err := sheet.Value(cell)
+	if err != nil {
+		return err.Error()
+	}
+
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func (sheet *Sheet) Cells() []Cell {
+	cells := make([]Cell, 0, len(sheet.contents))
+	for cell := range sheet.contents {
+		cells = append(cells, cell)
+	}
+
+	sort.Slice(cells, func(i, j int) bool {
+		if cells[i].Row != cells[j].Row {
+			return cells[i].Row < cells[j].Row
+		}
+
+		return cells[i].Column < cells[j].Column
+	})
+	return cells
+}
+
+func (sheet *Sheet) parseContent(cell Cell, content string) (float64, []translator.Command, error) {
+	switch {
+	case content == "":
+		return 0, nil, nil
+	case strings.HasPrefix(content, formulaPrefix):
+		commands, err := compile(strings.TrimPrefix(content, formulaPrefix), sheet.functions, sheet.isEmpty)
+		if err != nil {
+			return 0, nil, fmt.Errorf("unable to compile the formula of the cell %s: %w", cell, err)
+		}
+
+		return 0, commands, nil
+	default:
+		number, err := strconv.ParseFloat(content, 64)
+		if err != nil {
+			return 0, nil, fmt.Errorf("unable to parse the number of the cell %s: %w", cell, err)
+		}
+
+		return number, nil, nil
+	}
+}
+
+func (sheet *Sheet) setContent(cell Cell, content string, number float64, commands []translator.Command) {
+	name := cell.String()
+	delete(sheet.constants, name)
+	delete(sheet.formulas, name)
+	delete(sheet.referenceErrs, name)
+	switch {
+	case content == "":
+		delete(sheet.contents, cell)
+	case strings.HasPrefix(content, formulaPrefix):
+		sheet.contents[cell] = content
+		sheet.formulas[name] = commands
+		for _, dependency := range graph.Dependencies(commands) {
+			if _, err := ParseCell(dependency); err == nil {
+				continue
+			}
+
+			if _, _, ok := splitCellReference(dependency); ok {
+				sheet.referenceErrs[name] = ErrReference
+				break
+			}
+
+			sheet.referenceErrs[name] = ErrName
+		}
+	default:
+		sheet.contents[cell] = content
+		sheet.constants[name] = number
+	}
+}
+
+func (sheet *Sheet) recalculate(cell Cell) ([]string, error) {
+	name := cell.String()
+	commands, ok := sheet.formulas[name]
+	if !ok {
+		sheet.dependencyGraph.RemoveFormula(name)
+		delete(sheet.errs, name)
+
+		recalculatedNames, err := sheet.dependencyGraph.Set(name, sheet.constants[name])
+		if err != nil {
+			return nil, fmt.Errorf("unable to recalculate the cell %s: %w", cell, err)
+		}
+
+		return recalculatedNames, nil
+	}
+
+	// empty cells are not set in the dependency graph until a formula refers to them
+	for _, dependency := range graph.Dependencies(commands) {
+		dependencyCell, err := ParseCell(dependency)
+		if err != nil || dependency == name || !sheet.isEmpty(dependencyCell) {
+			continue
+		}
+		if _, err := sheet.dependencyGraph.Value(dependency); err == nil {
+			continue
+		}
+
+		if _, err := sheet.dependencyGraph.Set(dependency, 0); err != nil {
+			return nil, fmt.Errorf("unable to recalculate the cell %s: %w", dependencyCell, err)
+		}
+	}
+
+	recalculatedNames, err := sheet.dependencyGraph.SetFormula(name, commands)
+	if err != nil {
+		return nil, fmt.Errorf("unable to recalculate the cell %s: %w", cell, err)
+	}
+
+	return recalculatedNames, nil
+}
+
+func (sheet *Sheet) isEmpty(cell Cell) bool {
+	_, ok := sheet.contents[cell]
+	return !ok
+}
+
+func (sheet *Sheet) updateErrs(names []string) {
+	for _, name := range names {
+		delete(sheet.errs, name)
+		if err, ok := sheet.referenceErrs[name]; ok {
+			sheet.errs[name] = err
+			continue
+		}
+
+		if err, ok := sheet.dependencyErr(name); ok {
+			sheet.errs[name] = err
+			continue
+		}
+
+		value, err := sheet.dependencyGraph.Value(name)
+		switch {
+		case errors.Is(err, builtin.ErrDivisionByZero):
+			sheet.errs[name] = ErrDivisionByZero
+		case err != nil:
+			sheet.errs[name] = ErrValue
+		case math.IsNaN(value) || math.IsInf(value, 0):
+			sheet.errs[name] = ErrNumber
+		}
+	}
+}
+
+func (sheet *Sheet) dependencyErr(name string) (CellError, bool) {
+	for _, dependency := range sheet.dependencyGraph.Dependencies(name) {
+		if err, ok := sheet.errs[dependency]; ok {
+			return err, true
+		}
+	}
+
+	return "", false
+}
+
+func cellsForNames(names []string) []Cell {
+	cells := make([]Cell, 0, len(names))
+	for _, name := range names {
+		if cell, err := ParseCell(name); err == nil {
+			cells = append(cells, cell)
+		}
+	}
+
+	return cells
+}
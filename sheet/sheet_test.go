@@ -0,0 +1,169 @@
+package sheet
+
+import (
+	"testing"
+
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSheet_Set(t *testing.T) {
+	sheet := New(builtin.Functions())
+	setCells(t, sheet, map[string]string{
+		"A1": "10",
+		"A2": "20",
+		"A3": "=sum(A1:A2)",
+		"B1": "=A3 * 2",
+		"B2": "=B1 / A1",
+		"C1": "5",
+	})
+	assertTexts(t, sheet, map[string]string{"A3": "30", "B1": "60", "B2": "6", "C1": "5", "D1": ""})
+
+	recalculated, err := sheet.Set(mustParseCell(t, "A2"), "40")
+	assert.NoError(t, err)
+	assert.Equal(t, []Cell{mustParseCell(t, "A3"), mustParseCell(t, "B1"), mustParseCell(t, "B2")}, recalculated)
+	assertTexts(t, sheet, map[string]string{"A3": "50", "B1": "100", "B2": "10"})
+
+	recalculated, err = sheet.Set(mustParseCell(t, "C1"), "6")
+	assert.NoError(t, err)
+	assert.Equal(t, []Cell{}, recalculated)
+
+	_, err = sheet.Set(mustParseCell(t, "A1"), "0")
+	assert.NoError(t, err)
+	assertTexts(t, sheet, map[string]string{"A3": "40", "B1": "80", "B2": "#DIV/0!"})
+
+	value, err := sheet.Value(mustParseCell(t, "B2"))
+	assert.Equal(t, 0.0, value)
+	assert.ErrorIs(t, err, ErrDivisionByZero)
+
+	_, err = sheet.Set(mustParseCell(t, "A1"), "")
+	assert.NoError(t, err)
+	assert.Equal(t, "", sheet.Content(mustParseCell(t, "A1")))
+	assertTexts(t, sheet, map[string]string{"A3": "40", "B2": "#DIV/0!"})
+
+	_, err = sheet.Set(mustParseCell(t, "A1"), "=B1 + 1")
+	assert.Error(t, err)
+	assert.Equal(t, "", sheet.Content(mustParseCell(t, "A1")))
+	assertTexts(t, sheet, map[string]string{"A3": "40", "B1": "80"})
+
+	_, err = sheet.Set(mustParseCell(t, "A1"), "=(")
+	assert.Error(t, err)
+
+	_, err = sheet.Set(mustParseCell(t, "A1"), "ten")
+	assert.Error(t, err)
+
+	assert.Equal(t, []Cell{
+		mustParseCell(t, "B1"),
+		mustParseCell(t, "C1"),
+		mustParseCell(t, "A2"),
+		mustParseCell(t, "B2"),
+		mustParseCell(t, "A3"),
+	}, sheet.Cells())
+}
+
+func TestSheet_Set_formulas(t *testing.T) {
+	sheet := New(builtin.Functions())
+	setCells(t, sheet, map[string]string{
+		"A1": "1",
+		"A2": "2",
+		"B1": "=SUM(A1:A3)",
+		"B2": "=B1 * 10",
+		"C1": "=A1 + 1",
+	})
+	assertTexts(t, sheet, map[string]string{"B1": "3", "B2": "30", "C1": "2"})
+
+	recalculated, err := sheet.Set(mustParseCell(t, "A3"), "=A1 + A2")
+	assert.NoError(t, err)
+	assert.Equal(t, []Cell{mustParseCell(t, "A3"), mustParseCell(t, "B1"), mustParseCell(t, "B2")}, recalculated)
+	assertTexts(t, sheet, map[string]string{"A3": "3", "B1": "6", "B2": "60", "C1": "2"})
+
+	recalculated, err = sheet.Set(mustParseCell(t, "B1"), "=max(A1:A3)")
+	assert.NoError(t, err)
+	assert.Equal(t, []Cell{mustParseCell(t, "B1"), mustParseCell(t, "B2")}, recalculated)
+	assertTexts(t, sheet, map[string]string{"B1": "3", "B2": "30"})
+
+	recalculated, err = sheet.Set(mustParseCell(t, "A3"), "")
+	assert.NoError(t, err)
+	assert.Equal(t, []Cell{mustParseCell(t, "B1"), mustParseCell(t, "B2")}, recalculated)
+	assertTexts(t, sheet, map[string]string{"A3": "", "B1": "2", "B2": "20"})
+
+	_, err = sheet.Set(mustParseCell(t, "A2"), "=B2")
+	assert.Error(t, err)
+	assert.Equal(t, "2", sheet.Content(mustParseCell(t, "A2")))
+	assertTexts(t, sheet, map[string]string{"A2": "2", "B1": "2", "B2": "20"})
+}
+
+func TestSheet_errors(t *testing.T) {
+	sheet := New(builtin.Functions())
+	setCells(t, sheet, map[string]string{
+		"A1": "-4",
+		"A2": "=A0 + 1",
+		"A3": "=rate * 2",
+		"A4": "=sqrt(A1)",
+		"A5": "=A2 + A3",
+		"A6": "=A4 * 2",
+		"A7": "=let x = 2 in x * A1",
+	})
+
+	assertTexts(t, sheet, map[string]string{
+		"A2": "#REF!",
+		"A3": "#NAME?",
+		"A4": "#NUM!",
+		"A5": "#REF!",
+		"A6": "#NUM!",
+		"A7": "-8",
+	})
+
+	_, err := sheet.Set(mustParseCell(t, "A1"), "16")
+	assert.NoError(t, err)
+	assertTexts(t, sheet, map[string]string{"A4": "4", "A6": "8", "A7": "32"})
+}
+
+func TestSheet_partiallyFilledRanges(t *testing.T) {
+	sheet := New(builtin.Functions())
+	setCells(t, sheet, map[string]string{
+		"A1": "1",
+		"A2": "2",
+		"B1": "=count(A1:A5)",
+		"B2": "=average(A1:A5)",
+		"B3": "=min(A1:A3)",
+		"B4": "=max(A3:A5)",
+		"B5": "=sum(A1:A5)",
+		"B6": "=average(C1:C5)",
+	})
+	assertTexts(t, sheet, map[string]string{"B1": "2", "B2": "1.5", "B3": "1", "B4": "0", "B5": "3", "B6": "#DIV/0!"})
+
+	_, err := sheet.Set(mustParseCell(t, "A4"), "-1")
+	assert.NoError(t, err)
+	assertTexts(t, sheet, map[string]string{"B1": "3", "B2": "0.6666666666666666", "B3": "1", "B4": "-1", "B5": "2"})
+
+	_, err = sheet.Set(mustParseCell(t, "A1"), "")
+	assert.NoError(t, err)
+	assertTexts(t, sheet, map[string]string{"B1": "2", "B2": "0.5", "B3": "2", "B4": "-1", "B5": "1"})
+}
+
+func setCells(t *testing.T, sheet *Sheet, contents map[string]string) {
+	t.Helper()
+
+	for name, content := range contents {
+		_, err := sheet.Set(mustParseCell(t, name), content)
+		assert.NoError(t, err, name)
+	}
+}
+
+func assertTexts(t *testing.T, sheet *Sheet, want map[string]string) {
+	t.Helper()
+
+	for name, wantText := range want {
+		assert.Equal(t, wantText, sheet.Text(mustParseCell(t, name)), name)
+	}
+}
+
+func mustParseCell(t *testing.T, text string) Cell {
+	t.Helper()
+
+	cell, err := ParseCell(text)
+	assert.NoError(t, err)
+
+	return cell
+}
@@ -0,0 +1,85 @@
+package tokenizer
+
+import "fmt"
+
+const (
+	superscriptMinus = '⁻'
+)
+
+var (
+	operatorAliases = map[rune]TokenKind{
+		'×': AsteriskToken,
+		'·': AsteriskToken,
+		'⋅': AsteriskToken,
+		'÷': SlashToken,
+		'−': MinusToken,
+		'√': SquareRootToken,
+	}
+	identifierAliases = map[rune]string{
+		'π': "pi",
+	}
+	superscriptDigits = map[rune]rune{
+		'⁰': '0',
+		'¹': '1',
+		'²': '2',
+		'³': '3',
+		'⁴': '4',
+		'⁵': '5',
+		'⁶': '6',
+		'⁷': '7',
+		'⁸': '8',
+		'⁹': '9',
+	}
+	unsupportedAliases = map[rune]struct{}{
+		'≤': {},
+		'≥': {},
+		'≠': {},
+	}
+)
+
+func isUnicodeAlias(character rune) bool {
+	if _, ok := operatorAliases[character]; ok {
+		return true
+	}
+	if _, ok := identifierAliases[character]; ok {
+		return true
+	}
+	if _, ok := superscriptDigits[character]; ok {
+		return true
+	}
+	if _, ok := unsupportedAliases[character]; ok {
+		return true
+	}
+
+	return character == superscriptMinus
+}
+
+func appendUnicodeAlias(tokens []Token, character rune, index int, continuesSuperscript bool) ([]Token, error) {
+	if kind, ok := operatorAliases[character]; ok {
+		return append(tokens, Token{Kind: kind, Position: index}), nil
+	}
+	if name, ok := identifierAliases[character]; ok {
+		return append(tokens, Token{Kind: IdentifierToken, Value: name, Position: index}), nil
+	}
+	if _, ok := unsupportedAliases[character]; ok {
+		return nil, fmt.Errorf("unsupported comparison operator %q at position %d", character, index)
+	}
+
+	if character == superscriptMinus {
+		if continuesSuperscript {
+			return nil, fmt.Errorf("unexpected superscript minus at position %d", index)
+		}
+
+		return append(tokens, Token{Kind: ExponentiationToken, Position: index}, Token{Kind: MinusToken, Position: index}), nil
+	}
+
+	digit := string(superscriptDigits[character])
+	if !continuesSuperscript {
+		tokens = append(tokens, Token{Kind: ExponentiationToken, Position: index})
+	} else if lastToken := &tokens[len(tokens)-1]; lastToken.Kind == NumberToken {
+		lastToken.Value += digit
+		return tokens, nil
+	}
+
+	return append(tokens, Token{Kind: NumberToken, Value: digit, Position: index}), nil
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"unicode/utf8"
 )
 
 var (
@@ -11,21 +12,25 @@ var (
 )
 
 type stateContext struct {
-	state                 State
-	numberHasDecimalPoint bool
-	buffer                strings.Builder
+	state                      State
+	numberStart                int
+	numberHasDecimalPoint      bool
+	numberHasGroupingSeparator bool
+	buffer                     strings.Builder
 }
 
 func newStateContext() stateContext {
 	return stateContext{
-		state:                 DefaultState,
-		numberHasDecimalPoint: false,
+		state:                      DefaultState,
+		numberHasDecimalPoint:      false,
+		numberHasGroupingSeparator: false,
 	}
 }
 
 func (stateCtx *stateContext) addCharacterToNumber(index int, character rune) error {
 	if stateCtx.state == DefaultState {
 		stateCtx.state = NumberState
+		stateCtx.numberStart = index
 	}
 
 	if character == decimalPointCharacter && stateCtx.state == NumberState {
@@ -41,6 +46,15 @@ func (stateCtx *stateContext) addCharacterToNumber(index int, character rune) er
 	return nil
 }
 
+func (stateCtx *stateContext) addGroupingSeparator(index int) error {
+	if !stateCtx.numberHasGroupingSeparator && utf8.RuneCountInString(stateCtx.buffer.String()) > 3 {
+		return fmt.Errorf("expected at most three digits before the grouping separator at position %d", index)
+	}
+
+	stateCtx.numberHasGroupingSeparator = true
+	return nil
+}
+
 func (stateCtx *stateContext) addCharacterToIdentifier(character rune) {
 	if stateCtx.state == DefaultState {
 		stateCtx.state = IdentifierState
@@ -49,19 +63,52 @@ func (stateCtx *stateContext) addCharacterToIdentifier(character rune) {
 	stateCtx.buffer.WriteRune(character)
 }
 
+func (stateCtx *stateContext) startComment(isBlockComment bool) {
+	if isBlockComment {
+		stateCtx.state = BlockCommentState
+	} else {
+		stateCtx.state = LineCommentState
+	}
+}
+
+func (stateCtx *stateContext) addCharacterToComment(character rune) {
+	stateCtx.buffer.WriteRune(character)
+}
+
+func (stateCtx *stateContext) isBlockCommentFinished() bool {
+	value := stateCtx.buffer.String()
+	return stateCtx.state == BlockCommentState &&
+		len(value) >= len(blockCommentPrefix)+len(blockCommentSuffix) &&
+		strings.HasSuffix(value, blockCommentSuffix)
+}
+
+func (stateCtx *stateContext) createComment(index int) Comment {
+	value := stateCtx.buffer.String()
+
+	stateCtx.state = DefaultState
+	stateCtx.buffer.Reset()
+
+	comment := Comment{
+		Text:     value,
+		Position: index - len(value),
+	}
+	return comment
+}
+
 func (stateCtx *stateContext) createNumberToken(index int) (Token, error) {
 	if stateCtx.state != NumberState {
 		return Token{}, errNoToken
 	}
 
 	value := stateCtx.buffer.String()
-	position := index - len(value)
+	position := stateCtx.numberStart
 	if value == string(decimalPointCharacter) {
 		return Token{}, fmt.Errorf("the number has only a decimal point at position %d", position)
 	}
 
 	stateCtx.state = DefaultState
 	stateCtx.numberHasDecimalPoint = false
+	stateCtx.numberHasGroupingSeparator = false
 	stateCtx.buffer.Reset()
 
 	token := Token{
@@ -82,6 +129,14 @@ func (stateCtx *stateContext) createIdentifierToken(index int) (Token, error) {
 	stateCtx.state = DefaultState
 	stateCtx.buffer.Reset()
 
+	if kind, ok := keywords[value]; ok {
+		token := Token{
+			Kind:     kind,
+			Position: index - len(value),
+		}
+		return token, nil
+	}
+
 	token := Token{
 		Kind:     IdentifierToken,
 		Value:    value,
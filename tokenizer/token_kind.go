@@ -16,8 +16,53 @@ const (
 	LeftParenthesisToken
 	RightParenthesisToken
 	CommaToken
+	SemicolonToken
+	NewlineToken
+	EqualsToken
+	LetToken
+	InToken
+	NegationToken
+	ColonToken
+	ImplicitMultiplicationToken
+	FactorialToken
+	DegreeToken
+	LeftBracketToken
+	RightBracketToken
+	LeftBraceToken
+	RightBraceToken
+	VerticalBarToken
+	LeftFloorToken
+	RightFloorToken
+	LeftCeilingToken
+	RightCeilingToken
+	SquareRootToken
 )
 
+var keywords = map[string]TokenKind{
+	"let": LetToken,
+	"in":  InToken,
+}
+
+func ParseOperator(name string) (TokenKind, bool) {
+	for _, kind := range []TokenKind{
+		PlusToken,
+		MinusToken,
+		AsteriskToken,
+		SlashToken,
+		PercentToken,
+		ExponentiationToken,
+		NegationToken,
+		FactorialToken,
+		DegreeToken,
+	} {
+		if kind.String() == name {
+			return kind, true
+		}
+	}
+
+	return 0, false
+}
+
 func ParseTokenKind(character rune) (TokenKind, error) {
 	switch character {
 	case '+':
@@ -38,31 +83,136 @@ func ParseTokenKind(character rune) (TokenKind, error) {
 		return RightParenthesisToken, nil
 	case ',':
 		return CommaToken, nil
+	case ';':
+		return SemicolonToken, nil
+	case '\n':
+		return NewlineToken, nil
+	case '=':
+		return EqualsToken, nil
+	case ':':
+		return ColonToken, nil
+	case '!':
+		return FactorialToken, nil
+	case '°':
+		return DegreeToken, nil
+	case '[':
+		return LeftBracketToken, nil
+	case ']':
+		return RightBracketToken, nil
+	case '{':
+		return LeftBraceToken, nil
+	case '}':
+		return RightBraceToken, nil
+	case '|':
+		return VerticalBarToken, nil
+	case '⌊':
+		return LeftFloorToken, nil
+	case '⌋':
+		return RightFloorToken, nil
+	case '⌈':
+		return LeftCeilingToken, nil
+	case '⌉':
+		return RightCeilingToken, nil
 	default:
 		return 0, fmt.Errorf("unknown character %q", character)
 	}
 }
 
+func (kind TokenKind) IsStatementSeparator() bool {
+	return kind == SemicolonToken || kind == NewlineToken
+}
+
 func (kind TokenKind) Precedence() int {
 	switch kind {
 	case PlusToken, MinusToken:
 		return 1
 	case AsteriskToken, SlashToken, PercentToken:
 		return 2
-	case ExponentiationToken:
+	case ImplicitMultiplicationToken:
 		return 3
+	case ExponentiationToken, NegationToken, SquareRootToken:
+		return 4
+	case FactorialToken, DegreeToken:
+		return 5
 	default:
 		return 0
 	}
 }
 
+func (kind TokenKind) IsRightAssociative() bool {
+	return kind == ExponentiationToken || kind == NegationToken || kind == SquareRootToken
+}
+
 func (kind TokenKind) IsOperator() bool {
 	return kind == PlusToken ||
 		kind == MinusToken ||
 		kind == AsteriskToken ||
 		kind == SlashToken ||
 		kind == PercentToken ||
-		kind == ExponentiationToken
+		kind == ExponentiationToken ||
+		kind == NegationToken ||
+		kind == ImplicitMultiplicationToken ||
+		kind == SquareRootToken
+}
+
+func (kind TokenKind) IsPostfix() bool {
+	return kind == FactorialToken || kind == DegreeToken
+}
+
+func (kind TokenKind) IsOpeningBracket() bool {
+	return kind == LeftParenthesisToken ||
+		kind == LeftBracketToken ||
+		kind == LeftBraceToken ||
+		kind == VerticalBarToken ||
+		kind == LeftFloorToken ||
+		kind == LeftCeilingToken
+}
+
+func (kind TokenKind) IsClosingBracket() bool {
+	return kind == RightParenthesisToken ||
+		kind == RightBracketToken ||
+		kind == RightBraceToken ||
+		kind == VerticalBarToken ||
+		kind == RightFloorToken ||
+		kind == RightCeilingToken
+}
+
+func (kind TokenKind) ClosingBracket() (TokenKind, bool) {
+	switch kind {
+	case LeftParenthesisToken:
+		return RightParenthesisToken, true
+	case LeftBracketToken:
+		return RightBracketToken, true
+	case LeftBraceToken:
+		return RightBraceToken, true
+	case VerticalBarToken:
+		return VerticalBarToken, true
+	case LeftFloorToken:
+		return RightFloorToken, true
+	case LeftCeilingToken:
+		return RightCeilingToken, true
+	default:
+		return 0, false
+	}
+}
+
+func (kind TokenKind) OpeningBracket() (TokenKind, bool) {
+	switch kind {
+	case RightParenthesisToken:
+		return LeftParenthesisToken, true
+	case RightBracketToken:
+		return LeftBracketToken, true
+	case RightBraceToken:
+		return LeftBraceToken, true
+	case VerticalBarToken:
+		return VerticalBarToken, true
+	case RightFloorToken:
+		return LeftFloorToken, true
+	case RightCeilingToken:
+		return LeftCeilingToken, true
+	default:
+		return 0, false
+	}
 }
 
 func (kind TokenKind) String() string {
@@ -71,7 +221,7 @@ func (kind TokenKind) String() string {
 		return "+"
 	case MinusToken:
 		return "-"
-	case AsteriskToken:
+	case AsteriskToken, ImplicitMultiplicationToken:
 		return "*"
 	case SlashToken:
 		return "/"
@@ -85,6 +235,44 @@ func (kind TokenKind) String() string {
 		return ")"
 	case CommaToken:
 		return ","
+	case SemicolonToken:
+		return ";"
+	case NewlineToken:
+		return "\n"
+	case EqualsToken:
+		return "="
+	case LetToken:
+		return "let"
+	case InToken:
+		return "in"
+	case NegationToken:
+		return "neg"
+	case ColonToken:
+		return ":"
+	case FactorialToken:
+		return "!"
+	case DegreeToken:
+		return "°"
+	case LeftBracketToken:
+		return "["
+	case RightBracketToken:
+		return "]"
+	case LeftBraceToken:
+		return "{"
+	case RightBraceToken:
+		return "}"
+	case VerticalBarToken:
+		return "|"
+	case LeftFloorToken:
+		return "⌊"
+	case RightFloorToken:
+		return "⌋"
+	case LeftCeilingToken:
+		return "⌈"
+	case RightCeilingToken:
+		return "⌉"
+	case SquareRootToken:
+		return "sqrt"
 	default:
 		return ""
 	}
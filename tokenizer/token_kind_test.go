@@ -71,6 +71,96 @@ func TestParseTokenKind(t *testing.T) {
 			want:    CommaToken,
 			wantErr: assert.NoError,
 		},
+		{
+			name:    "success/;",
+			args:    args{character: ';'},
+			want:    SemicolonToken,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/newline",
+			args:    args{character: '\n'},
+			want:    NewlineToken,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/=",
+			args:    args{character: '='},
+			want:    EqualsToken,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/:",
+			args:    args{character: ':'},
+			want:    ColonToken,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/!",
+			args:    args{character: '!'},
+			want:    FactorialToken,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/°",
+			args:    args{character: '°'},
+			want:    DegreeToken,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/[",
+			args:    args{character: '['},
+			want:    LeftBracketToken,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/]",
+			args:    args{character: ']'},
+			want:    RightBracketToken,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/{",
+			args:    args{character: '{'},
+			want:    LeftBraceToken,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/}",
+			args:    args{character: '}'},
+			want:    RightBraceToken,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/|",
+			args:    args{character: '|'},
+			want:    VerticalBarToken,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/⌊",
+			args:    args{character: '⌊'},
+			want:    LeftFloorToken,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/⌋",
+			args:    args{character: '⌋'},
+			want:    RightFloorToken,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/⌈",
+			args:    args{character: '⌈'},
+			want:    LeftCeilingToken,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/⌉",
+			args:    args{character: '⌉'},
+			want:    RightCeilingToken,
+			wantErr: assert.NoError,
+		},
 		{
 			name:    "error/@",
 			args:    args{character: '@'},
@@ -88,6 +178,35 @@ func TestParseTokenKind(t *testing.T) {
 	}
 }
 
+func TestParseOperator(t *testing.T) {
+	type args struct {
+		name string
+	}
+
+	tests := []struct {
+		name   string
+		args   args
+		want   TokenKind
+		wantOk assert.BoolAssertionFunc
+	}{
+		{name: "success/+", args: args{name: "+"}, want: PlusToken, wantOk: assert.True},
+		{name: "success/^", args: args{name: "^"}, want: ExponentiationToken, wantOk: assert.True},
+		{name: "success/neg", args: args{name: "neg"}, want: NegationToken, wantOk: assert.True},
+		{name: "success/!", args: args{name: "!"}, want: FactorialToken, wantOk: assert.True},
+		{name: "success/°", args: args{name: "°"}, want: DegreeToken, wantOk: assert.True},
+		{name: "error/punctuation", args: args{name: "("}, want: 0, wantOk: assert.False},
+		{name: "error/function", args: args{name: "sin"}, want: 0, wantOk: assert.False},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotOk := ParseOperator(tt.args.name)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantOk(t, gotOk)
+		})
+	}
+}
+
 func TestTokenKind_Precedence(t *testing.T) {
 	tests := []struct {
 		name string
@@ -99,12 +218,23 @@ func TestTokenKind_Precedence(t *testing.T) {
 		{name: "*", kind: AsteriskToken, want: 2},
 		{name: "/", kind: SlashToken, want: 2},
 		{name: "%", kind: PercentToken, want: 2},
-		{name: "^", kind: ExponentiationToken, want: 3},
+		{name: "^", kind: ExponentiationToken, want: 4},
 		{name: "number", kind: NumberToken, want: 0},
 		{name: "identifier", kind: IdentifierToken, want: 0},
 		{name: "(", kind: LeftParenthesisToken, want: 0},
 		{name: ")", kind: RightParenthesisToken, want: 0},
 		{name: ",", kind: CommaToken, want: 0},
+		{name: ";", kind: SemicolonToken, want: 0},
+		{name: "newline", kind: NewlineToken, want: 0},
+		{name: "=", kind: EqualsToken, want: 0},
+		{name: "let", kind: LetToken, want: 0},
+		{name: "in", kind: InToken, want: 0},
+		{name: "neg", kind: NegationToken, want: 4},
+		{name: "sqrt", kind: SquareRootToken, want: 4},
+		{name: ":", kind: ColonToken, want: 0},
+		{name: "implicit *", kind: ImplicitMultiplicationToken, want: 3},
+		{name: "!", kind: FactorialToken, want: 5},
+		{name: "°", kind: DegreeToken, want: 5},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -115,6 +245,31 @@ func TestTokenKind_Precedence(t *testing.T) {
 	}
 }
 
+func TestTokenKind_IsRightAssociative(t *testing.T) {
+	tests := []struct {
+		name string
+		kind TokenKind
+		want assert.BoolAssertionFunc
+	}{
+		{name: "+", kind: PlusToken, want: assert.False},
+		{name: "-", kind: MinusToken, want: assert.False},
+		{name: "*", kind: AsteriskToken, want: assert.False},
+		{name: "/", kind: SlashToken, want: assert.False},
+		{name: "%", kind: PercentToken, want: assert.False},
+		{name: "^", kind: ExponentiationToken, want: assert.True},
+		{name: "neg", kind: NegationToken, want: assert.True},
+		{name: "sqrt", kind: SquareRootToken, want: assert.True},
+		{name: "implicit *", kind: ImplicitMultiplicationToken, want: assert.False},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.kind.IsRightAssociative()
+
+			tt.want(t, got)
+		})
+	}
+}
+
 func TestTokenKind_IsOperator(t *testing.T) {
 	tests := []struct {
 		name string
@@ -132,6 +287,17 @@ func TestTokenKind_IsOperator(t *testing.T) {
 		{name: "(", kind: LeftParenthesisToken, want: assert.False},
 		{name: ")", kind: RightParenthesisToken, want: assert.False},
 		{name: ",", kind: CommaToken, want: assert.False},
+		{name: ";", kind: SemicolonToken, want: assert.False},
+		{name: "newline", kind: NewlineToken, want: assert.False},
+		{name: "=", kind: EqualsToken, want: assert.False},
+		{name: "let", kind: LetToken, want: assert.False},
+		{name: "in", kind: InToken, want: assert.False},
+		{name: "neg", kind: NegationToken, want: assert.True},
+		{name: "sqrt", kind: SquareRootToken, want: assert.True},
+		{name: ":", kind: ColonToken, want: assert.False},
+		{name: "implicit *", kind: ImplicitMultiplicationToken, want: assert.True},
+		{name: "!", kind: FactorialToken, want: assert.False},
+		{name: "°", kind: DegreeToken, want: assert.False},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -142,6 +308,150 @@ func TestTokenKind_IsOperator(t *testing.T) {
 	}
 }
 
+func TestTokenKind_IsStatementSeparator(t *testing.T) {
+	tests := []struct {
+		name string
+		kind TokenKind
+		want assert.BoolAssertionFunc
+	}{
+		{name: ";", kind: SemicolonToken, want: assert.True},
+		{name: "newline", kind: NewlineToken, want: assert.True},
+		{name: ",", kind: CommaToken, want: assert.False},
+		{name: "=", kind: EqualsToken, want: assert.False},
+		{name: "identifier", kind: IdentifierToken, want: assert.False},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.kind.IsStatementSeparator()
+
+			tt.want(t, got)
+		})
+	}
+}
+
+func TestTokenKind_IsPostfix(t *testing.T) {
+	tests := []struct {
+		name string
+		kind TokenKind
+		want assert.BoolAssertionFunc
+	}{
+		{name: "!", kind: FactorialToken, want: assert.True},
+		{name: "°", kind: DegreeToken, want: assert.True},
+		{name: "%", kind: PercentToken, want: assert.False},
+		{name: "neg", kind: NegationToken, want: assert.False},
+		{name: "number", kind: NumberToken, want: assert.False},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.kind.IsPostfix()
+
+			tt.want(t, got)
+		})
+	}
+}
+
+func TestTokenKind_IsOpeningBracket(t *testing.T) {
+	tests := []struct {
+		name string
+		kind TokenKind
+		want assert.BoolAssertionFunc
+	}{
+		{name: "(", kind: LeftParenthesisToken, want: assert.True},
+		{name: "[", kind: LeftBracketToken, want: assert.True},
+		{name: "{", kind: LeftBraceToken, want: assert.True},
+		{name: "|", kind: VerticalBarToken, want: assert.True},
+		{name: "⌊", kind: LeftFloorToken, want: assert.True},
+		{name: "⌈", kind: LeftCeilingToken, want: assert.True},
+		{name: ")", kind: RightParenthesisToken, want: assert.False},
+		{name: "⌋", kind: RightFloorToken, want: assert.False},
+		{name: "number", kind: NumberToken, want: assert.False},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.kind.IsOpeningBracket()
+
+			tt.want(t, got)
+		})
+	}
+}
+
+func TestTokenKind_IsClosingBracket(t *testing.T) {
+	tests := []struct {
+		name string
+		kind TokenKind
+		want assert.BoolAssertionFunc
+	}{
+		{name: ")", kind: RightParenthesisToken, want: assert.True},
+		{name: "]", kind: RightBracketToken, want: assert.True},
+		{name: "}", kind: RightBraceToken, want: assert.True},
+		{name: "|", kind: VerticalBarToken, want: assert.True},
+		{name: "⌋", kind: RightFloorToken, want: assert.True},
+		{name: "⌉", kind: RightCeilingToken, want: assert.True},
+		{name: "(", kind: LeftParenthesisToken, want: assert.False},
+		{name: "⌈", kind: LeftCeilingToken, want: assert.False},
+		{name: "comma", kind: CommaToken, want: assert.False},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.kind.IsClosingBracket()
+
+			tt.want(t, got)
+		})
+	}
+}
+
+func TestTokenKind_ClosingBracket(t *testing.T) {
+	tests := []struct {
+		name   string
+		kind   TokenKind
+		want   TokenKind
+		wantOk assert.BoolAssertionFunc
+	}{
+		{name: "(", kind: LeftParenthesisToken, want: RightParenthesisToken, wantOk: assert.True},
+		{name: "[", kind: LeftBracketToken, want: RightBracketToken, wantOk: assert.True},
+		{name: "{", kind: LeftBraceToken, want: RightBraceToken, wantOk: assert.True},
+		{name: "|", kind: VerticalBarToken, want: VerticalBarToken, wantOk: assert.True},
+		{name: "⌊", kind: LeftFloorToken, want: RightFloorToken, wantOk: assert.True},
+		{name: "⌈", kind: LeftCeilingToken, want: RightCeilingToken, wantOk: assert.True},
+		{name: ")", kind: RightParenthesisToken, want: 0, wantOk: assert.False},
+		{name: "number", kind: NumberToken, want: 0, wantOk: assert.False},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.kind.ClosingBracket()
+
+			assert.Equal(t, tt.want, got)
+			tt.wantOk(t, ok)
+		})
+	}
+}
+
+func TestTokenKind_OpeningBracket(t *testing.T) {
+	tests := []struct {
+		name   string
+		kind   TokenKind
+		want   TokenKind
+		wantOk assert.BoolAssertionFunc
+	}{
+		{name: ")", kind: RightParenthesisToken, want: LeftParenthesisToken, wantOk: assert.True},
+		{name: "]", kind: RightBracketToken, want: LeftBracketToken, wantOk: assert.True},
+		{name: "}", kind: RightBraceToken, want: LeftBraceToken, wantOk: assert.True},
+		{name: "|", kind: VerticalBarToken, want: VerticalBarToken, wantOk: assert.True},
+		{name: "⌋", kind: RightFloorToken, want: LeftFloorToken, wantOk: assert.True},
+		{name: "⌉", kind: RightCeilingToken, want: LeftCeilingToken, wantOk: assert.True},
+		{name: "(", kind: LeftParenthesisToken, want: 0, wantOk: assert.False},
+		{name: "number", kind: NumberToken, want: 0, wantOk: assert.False},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.kind.OpeningBracket()
+
+			assert.Equal(t, tt.want, got)
+			tt.wantOk(t, ok)
+		})
+	}
+}
+
 func TestTokenKind_String(t *testing.T) {
 	tests := []struct {
 		name string
@@ -159,6 +469,26 @@ func TestTokenKind_String(t *testing.T) {
 		{name: "(", kind: LeftParenthesisToken, want: "("},
 		{name: ")", kind: RightParenthesisToken, want: ")"},
 		{name: ",", kind: CommaToken, want: ","},
+		{name: ";", kind: SemicolonToken, want: ";"},
+		{name: "newline", kind: NewlineToken, want: "\n"},
+		{name: "=", kind: EqualsToken, want: "="},
+		{name: "let", kind: LetToken, want: "let"},
+		{name: "in", kind: InToken, want: "in"},
+		{name: "neg", kind: NegationToken, want: "neg"},
+		{name: ":", kind: ColonToken, want: ":"},
+		{name: "implicit *", kind: ImplicitMultiplicationToken, want: "*"},
+		{name: "!", kind: FactorialToken, want: "!"},
+		{name: "°", kind: DegreeToken, want: "°"},
+		{name: "[", kind: LeftBracketToken, want: "["},
+		{name: "]", kind: RightBracketToken, want: "]"},
+		{name: "{", kind: LeftBraceToken, want: "{"},
+		{name: "}", kind: RightBraceToken, want: "}"},
+		{name: "|", kind: VerticalBarToken, want: "|"},
+		{name: "⌊", kind: LeftFloorToken, want: "⌊"},
+		{name: "⌋", kind: RightFloorToken, want: "⌋"},
+		{name: "⌈", kind: LeftCeilingToken, want: "⌈"},
+		{name: "⌉", kind: RightCeilingToken, want: "⌉"},
+		{name: "sqrt", kind: SquareRootToken, want: "sqrt"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
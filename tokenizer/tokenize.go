@@ -5,15 +5,32 @@ import (
 	"fmt"
 	"strings"
 	"unicode"
+	"unicode/utf8"
+
+	"github.com/rmaidveo/go-calculator/locale"
 )
 
 const (
 	decimalPointCharacter = '.'
+	absoluteReferenceSign = '$'
+	rangeSeparator        = ':'
+	newlineCharacter      = '\n'
+	lineCommentPrefix     = "#"
+	lineCommentAltPrefix  = "//"
+	blockCommentPrefix    = "/*"
+	blockCommentSuffix    = "*/"
 )
 
 type Token struct {
-	Kind     TokenKind
-	Value    string
+	Kind             TokenKind
+	Value            string
+	Position         int
+	LeadingComments  []Comment
+	TrailingComments []Comment
+}
+
+type Comment struct {
+	Text     string
 	Position int
 }
 
@@ -23,75 +40,157 @@ const (
 	DefaultState State = iota
 	NumberState
 	IdentifierState
+	LineCommentState
+	BlockCommentState
 )
 
+type Options struct {
+	CellReferences        bool
+	DisableUnicodeAliases bool
+	Locale                locale.Locale
+}
+
 func Tokenize(text string) ([]Token, error) {
+	return TokenizeWithOptions(text, Options{})
+}
+
+func TokenizeWithOptions(text string, options Options) ([]Token, error) {
+	tokens, _, err := tokenize(text, options)
+	return tokens, err
+}
+
+func TokenizeWithComments(text string, options Options) ([]Token, []Comment, error) {
+	tokens, comments, err := tokenize(text, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tokens, danglingComments := attachComments(tokens, comments)
+	return tokens, danglingComments, nil
+}
+
+func tokenize(text string, options Options) ([]Token, []Comment, error) {
+	numberLocale := options.Locale
+	if numberLocale == (locale.Locale{}) {
+		numberLocale = locale.English
+	}
+	if err := numberLocale.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("invalid locale: %w", err)
+	}
+
 	var tokens []Token
+	var comments []Comment
 	stateCtx := newStateContext()
+	superscriptEnd := -1
+	bracketDepth := 0
 	for index, character := range text {
+		if stateCtx.state == BlockCommentState ||
+			(stateCtx.state == LineCommentState && character != newlineCharacter) {
+			stateCtx.addCharacterToComment(character)
+			if stateCtx.isBlockCommentFinished() {
+				comments = append(comments, stateCtx.createComment(index+utf8.RuneLen(character)))
+			}
+
+			continue
+		}
+		if stateCtx.state == LineCommentState {
+			comments = append(comments, stateCtx.createComment(index))
+		}
+
 		switch {
-		case unicode.IsSpace(character):
-			token, err := stateCtx.createNumberToken(index)
-			if err != nil && !errors.Is(err, errNoToken) {
-				return nil, fmt.Errorf("unable to create a number token: %w", err)
+		case numberLocale.GroupingSeparator != 0 &&
+			character == numberLocale.GroupingSeparator &&
+			stateCtx.state == NumberState &&
+			!stateCtx.numberHasDecimalPoint &&
+			startsWithDigit(text[index+utf8.RuneLen(character):]):
+			if err := stateCtx.addGroupingSeparator(index); err != nil {
+				return nil, nil, err
 			}
-			if err == nil {
-				tokens = append(tokens, token)
+			if !startsWithDigitGroup(text[index+utf8.RuneLen(character):]) {
+				return nil, nil, fmt.Errorf("expected a group of three digits after the grouping separator at position %d", index)
 			}
 
-			token, err = stateCtx.createIdentifierToken(index)
-			if err != nil && !errors.Is(err, errNoToken) {
-				return nil, fmt.Errorf("unable to create a identifier token: %w", err)
-			}
-			if err == nil {
-				tokens = append(tokens, token)
+			continue
+		case unicode.IsSpace(character) && character != newlineCharacter:
+			var err error
+			tokens, err = appendPendingTokens(tokens, &stateCtx, index)
+			if err != nil {
+				return nil, nil, err
 			}
 
 			continue
-		case unicode.IsDigit(character) || character == decimalPointCharacter:
-			if character == decimalPointCharacter {
+		case strings.HasPrefix(text[index:], lineCommentPrefix) ||
+			strings.HasPrefix(text[index:], lineCommentAltPrefix) ||
+			strings.HasPrefix(text[index:], blockCommentPrefix):
+			var err error
+			tokens, err = appendPendingTokens(tokens, &stateCtx, index)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			stateCtx.startComment(strings.HasPrefix(text[index:], blockCommentPrefix))
+			stateCtx.addCharacterToComment(character)
+		case !options.DisableUnicodeAliases && isUnicodeAlias(character):
+			var err error
+			tokens, err = appendPendingTokens(tokens, &stateCtx, index)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			tokens, err = appendUnicodeAlias(tokens, character, index, index == superscriptEnd)
+			if err != nil {
+				return nil, nil, err
+			}
+			if _, ok := superscriptDigits[character]; ok || character == superscriptMinus {
+				superscriptEnd = index + utf8.RuneLen(character)
+			}
+		case unicode.IsDigit(character) || character == numberLocale.DecimalSeparator:
+			if character == numberLocale.DecimalSeparator {
 				token, err := stateCtx.createIdentifierToken(index)
 				if err != nil && !errors.Is(err, errNoToken) {
-					return nil, fmt.Errorf("unable to create a identifier token: %w", err)
+					return nil, nil, fmt.Errorf("unable to create a identifier token: %w", err)
 				}
 				if err == nil {
 					tokens = append(tokens, token)
 				}
+
+				character = decimalPointCharacter
 			}
 
 			if err := stateCtx.addCharacterToNumber(index, character); err != nil {
-				return nil, fmt.Errorf("unable to add a character to the number: %w", err)
+				return nil, nil, fmt.Errorf("unable to add a character to the number: %w", err)
 			}
-		case unicode.IsLetter(character) || character == '_':
+		case unicode.IsLetter(character) ||
+			character == '_' ||
+			(options.CellReferences && character == absoluteReferenceSign):
 			token, err := stateCtx.createNumberToken(index)
 			if err != nil && !errors.Is(err, errNoToken) {
-				return nil, fmt.Errorf("unable to create a number token: %w", err)
+				return nil, nil, fmt.Errorf("unable to create a number token: %w", err)
 			}
 			if err == nil {
 				tokens = append(tokens, token)
 			}
 
 			stateCtx.addCharacterToIdentifier(character)
-		case strings.ContainsRune("+-*/%^(),", character):
-			token, err := stateCtx.createNumberToken(index)
-			if err != nil && !errors.Is(err, errNoToken) {
-				return nil, fmt.Errorf("unable to create a number token: %w", err)
-			}
-			if err == nil {
-				tokens = append(tokens, token)
-			}
-
-			token, err = stateCtx.createIdentifierToken(index)
-			if err != nil && !errors.Is(err, errNoToken) {
-				return nil, fmt.Errorf("unable to create a identifier token: %w", err)
-			}
-			if err == nil {
-				tokens = append(tokens, token)
+		case strings.ContainsRune("+-*/%^(),;=\n!°[]{}|⌊⌋⌈⌉", character) ||
+			(options.CellReferences && character == rangeSeparator):
+			var err error
+			tokens, err = appendPendingTokens(tokens, &stateCtx, index)
+			if err != nil {
+				return nil, nil, err
 			}
 
 			kind, err := ParseTokenKind(character)
 			if err != nil {
-				return nil, fmt.Errorf("unable to parse a token kind at position %d: %w", index, err)
+				return nil, nil, fmt.Errorf("unable to parse a token kind at position %d: %w", index, err)
+			}
+			switch {
+			case kind.IsOpeningBracket() && kind != VerticalBarToken:
+				bracketDepth++
+			case kind.IsClosingBracket() && kind != VerticalBarToken:
+				bracketDepth--
+			case character == numberLocale.ArgumentSeparator && bracketDepth > 0:
+				kind = CommaToken
 			}
 
 			tokens = append(tokens, Token{
@@ -99,12 +198,46 @@ func Tokenize(text string) ([]Token, error) {
 				Position: index,
 			})
 		default:
-			return nil, fmt.Errorf("unknown character %q at position %d", character, index)
+			return nil, nil, fmt.Errorf("unknown character %q at position %d", character, index)
 		}
 	}
 
 	endOfTextIndex := len(text)
-	token, err := stateCtx.createNumberToken(endOfTextIndex)
+	if stateCtx.state == BlockCommentState {
+		return nil, nil, fmt.Errorf("unterminated comment at position %d", endOfTextIndex-stateCtx.buffer.Len())
+	}
+	if stateCtx.state == LineCommentState {
+		comments = append(comments, stateCtx.createComment(endOfTextIndex))
+	}
+
+	tokens, err := appendPendingTokens(tokens, &stateCtx, endOfTextIndex)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tokens, comments, nil
+}
+
+func startsWithDigit(text string) bool {
+	character, _ := utf8.DecodeRuneInString(text)
+	return unicode.IsDigit(character)
+}
+
+func startsWithDigitGroup(text string) bool {
+	for digitIndex := 0; digitIndex < 3; digitIndex++ {
+		character, size := utf8.DecodeRuneInString(text)
+		if !unicode.IsDigit(character) {
+			return false
+		}
+
+		text = text[size:]
+	}
+
+	return !startsWithDigit(text)
+}
+
+func appendPendingTokens(tokens []Token, stateCtx *stateContext, index int) ([]Token, error) {
+	token, err := stateCtx.createNumberToken(index)
 	if err != nil && !errors.Is(err, errNoToken) {
 		return nil, fmt.Errorf("unable to create a number token: %w", err)
 	}
@@ -112,7 +245,7 @@ func Tokenize(text string) ([]Token, error) {
 		tokens = append(tokens, token)
 	}
 
-	token, err = stateCtx.createIdentifierToken(endOfTextIndex)
+	token, err = stateCtx.createIdentifierToken(index)
 	if err != nil && !errors.Is(err, errNoToken) {
 		return nil, fmt.Errorf("unable to create a identifier token: %w", err)
 	}
@@ -122,3 +255,28 @@ func Tokenize(text string) ([]Token, error) {
 
 	return tokens, nil
 }
+
+func attachComments(tokens []Token, comments []Comment) ([]Token, []Comment) {
+	var danglingComments []Comment
+	tokenIndex := 0
+	for _, comment := range comments {
+		for tokenIndex < len(tokens) && tokens[tokenIndex].Position < comment.Position {
+			tokenIndex++
+		}
+
+		hasPreviousToken := tokenIndex > 0
+		hasNextToken := tokenIndex < len(tokens)
+		switch {
+		case hasPreviousToken && (tokens[tokenIndex-1].Kind != NewlineToken || !hasNextToken):
+			previousToken := &tokens[tokenIndex-1]
+			previousToken.TrailingComments = append(previousToken.TrailingComments, comment)
+		case hasNextToken:
+			nextToken := &tokens[tokenIndex]
+			nextToken.LeadingComments = append(nextToken.LeadingComments, comment)
+		default:
+			danglingComments = append(danglingComments, comment)
+		}
+	}
+
+	return tokens, danglingComments
+}
@@ -3,6 +3,7 @@ package tokenizer
 import (
 	"testing"
 
+	"github.com/rmaidveo/go-calculator/locale"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -240,6 +241,39 @@ func TestTokenize(t *testing.T) {
 			want:    []Token{{Kind: CommaToken, Position: 0}},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "success/postfix operators",
+			args: args{text: "5!+90°"},
+			want: []Token{
+				{Kind: NumberToken, Value: "5", Position: 0},
+				{Kind: FactorialToken, Position: 1},
+				{Kind: PlusToken, Position: 2},
+				{Kind: NumberToken, Value: "90", Position: 3},
+				{Kind: DegreeToken, Position: 5},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/brackets",
+			args: args{text: "[{|x|}]+⌊y⌋⌈z⌉"},
+			want: []Token{
+				{Kind: LeftBracketToken, Position: 0},
+				{Kind: LeftBraceToken, Position: 1},
+				{Kind: VerticalBarToken, Position: 2},
+				{Kind: IdentifierToken, Value: "x", Position: 3},
+				{Kind: VerticalBarToken, Position: 4},
+				{Kind: RightBraceToken, Position: 5},
+				{Kind: RightBracketToken, Position: 6},
+				{Kind: PlusToken, Position: 7},
+				{Kind: LeftFloorToken, Position: 8},
+				{Kind: IdentifierToken, Value: "y", Position: 11},
+				{Kind: RightFloorToken, Position: 12},
+				{Kind: LeftCeilingToken, Position: 15},
+				{Kind: IdentifierToken, Value: "z", Position: 18},
+				{Kind: RightCeilingToken, Position: 19},
+			},
+			wantErr: assert.NoError,
+		},
 		{
 			name: "success/all punctuation",
 			args: args{text: "+-*/%^(),"},
@@ -272,6 +306,93 @@ func TestTokenize(t *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "success/statements/separated by a semicolon",
+			args: args{text: "x = 2; x"},
+			want: []Token{
+				{Kind: IdentifierToken, Value: "x", Position: 0},
+				{Kind: EqualsToken, Position: 2},
+				{Kind: NumberToken, Value: "2", Position: 4},
+				{Kind: SemicolonToken, Position: 5},
+				{Kind: IdentifierToken, Value: "x", Position: 7},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/statements/separated by a newline",
+			args: args{text: "x = 2\n x"},
+			want: []Token{
+				{Kind: IdentifierToken, Value: "x", Position: 0},
+				{Kind: EqualsToken, Position: 2},
+				{Kind: NumberToken, Value: "2", Position: 4},
+				{Kind: NewlineToken, Position: 5},
+				{Kind: IdentifierToken, Value: "x", Position: 7},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/keywords",
+			args: args{text: "let x = 2 in x"},
+			want: []Token{
+				{Kind: LetToken, Position: 0},
+				{Kind: IdentifierToken, Value: "x", Position: 4},
+				{Kind: EqualsToken, Position: 6},
+				{Kind: NumberToken, Value: "2", Position: 8},
+				{Kind: InToken, Position: 10},
+				{Kind: IdentifierToken, Value: "x", Position: 13},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/keywords/as a part of an identifier",
+			args: args{text: "letter + index"},
+			want: []Token{
+				{Kind: IdentifierToken, Value: "letter", Position: 0},
+				{Kind: PlusToken, Position: 7},
+				{Kind: IdentifierToken, Value: "index", Position: 9},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/comments/line comment",
+			args: args{text: "12 + 34 # comment\n56"},
+			want: []Token{
+				{Kind: NumberToken, Value: "12", Position: 0},
+				{Kind: PlusToken, Position: 3},
+				{Kind: NumberToken, Value: "34", Position: 5},
+				{Kind: NewlineToken, Position: 17},
+				{Kind: NumberToken, Value: "56", Position: 18},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/comments/line comment with slashes",
+			args: args{text: "12// comment\n34/56"},
+			want: []Token{
+				{Kind: NumberToken, Value: "12", Position: 0},
+				{Kind: NewlineToken, Position: 12},
+				{Kind: NumberToken, Value: "34", Position: 13},
+				{Kind: SlashToken, Position: 15},
+				{Kind: NumberToken, Value: "56", Position: 16},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/comments/block comment",
+			args: args{text: "xy/* multi\nline */+ /*/ comment */34"},
+			want: []Token{
+				{Kind: IdentifierToken, Value: "xy", Position: 0},
+				{Kind: PlusToken, Position: 18},
+				{Kind: NumberToken, Value: "34", Position: 34},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/comments/only a comment",
+			args:    args{text: "# comment"},
+			want:    nil,
+			wantErr: assert.NoError,
+		},
 		{
 			name:    "error/number/duplicate decimal point",
 			args:    args{text: "2.3.5"},
@@ -290,6 +411,12 @@ func TestTokenize(t *testing.T) {
 			want:    nil,
 			wantErr: assert.Error,
 		},
+		{
+			name:    "error/comments/unterminated block comment",
+			args:    args{text: "12 /* comment"},
+			want:    nil,
+			wantErr: assert.Error,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -300,3 +427,291 @@ func TestTokenize(t *testing.T) {
 		})
 	}
 }
+
+func TestTokenizeWithOptions(t *testing.T) {
+	type args struct {
+		text    string
+		options Options
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    []Token
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success/without comments",
+			args: args{text: "# leading\n12 # trailing", options: Options{}},
+			want: []Token{
+				{Kind: NewlineToken, Position: 9},
+				{Kind: NumberToken, Value: "12", Position: 10},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/with cell references",
+			args: args{text: "sum($A$1:B2) + C$3", options: Options{CellReferences: true}},
+			want: []Token{
+				{Kind: IdentifierToken, Value: "sum", Position: 0},
+				{Kind: LeftParenthesisToken, Position: 3},
+				{Kind: IdentifierToken, Value: "$A$1", Position: 4},
+				{Kind: ColonToken, Position: 8},
+				{Kind: IdentifierToken, Value: "B2", Position: 9},
+				{Kind: RightParenthesisToken, Position: 11},
+				{Kind: PlusToken, Position: 13},
+				{Kind: IdentifierToken, Value: "C$3", Position: 15},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error/cell references without the option",
+			args:    args{text: "A1:B2", options: Options{}},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "success/unicode operator aliases",
+			args: args{text: "6×7÷2−√16", options: Options{}},
+			want: []Token{
+				{Kind: NumberToken, Value: "6", Position: 0},
+				{Kind: AsteriskToken, Position: 1},
+				{Kind: NumberToken, Value: "7", Position: 3},
+				{Kind: SlashToken, Position: 4},
+				{Kind: NumberToken, Value: "2", Position: 6},
+				{Kind: MinusToken, Position: 7},
+				{Kind: SquareRootToken, Position: 10},
+				{Kind: NumberToken, Value: "16", Position: 13},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/superscripts and pi",
+			args: args{text: "x²³+2⁻¹·π", options: Options{}},
+			want: []Token{
+				{Kind: IdentifierToken, Value: "x", Position: 0},
+				{Kind: ExponentiationToken, Position: 1},
+				{Kind: NumberToken, Value: "23", Position: 1},
+				{Kind: PlusToken, Position: 5},
+				{Kind: NumberToken, Value: "2", Position: 6},
+				{Kind: ExponentiationToken, Position: 7},
+				{Kind: MinusToken, Position: 7},
+				{Kind: NumberToken, Value: "1", Position: 10},
+				{Kind: AsteriskToken, Position: 12},
+				{Kind: IdentifierToken, Value: "pi", Position: 14},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/german locale",
+			args: args{text: "max(1.234,5;-,5); 2", options: Options{Locale: locale.German}},
+			want: []Token{
+				{Kind: IdentifierToken, Value: "max", Position: 0},
+				{Kind: LeftParenthesisToken, Position: 3},
+				{Kind: NumberToken, Value: "1234.5", Position: 4},
+				{Kind: CommaToken, Position: 11},
+				{Kind: MinusToken, Position: 12},
+				{Kind: NumberToken, Value: ".5", Position: 13},
+				{Kind: RightParenthesisToken, Position: 15},
+				{Kind: SemicolonToken, Position: 16},
+				{Kind: NumberToken, Value: "2", Position: 18},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/grouping separator is not followed by a digit",
+			args: args{text: "1\u202f+ 2", options: Options{Locale: locale.French}},
+			want: []Token{
+				{Kind: NumberToken, Value: "1", Position: 0},
+				{Kind: PlusToken, Position: 4},
+				{Kind: NumberToken, Value: "2", Position: 6},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/several grouping separators",
+			args: args{text: "1.234.567,5", options: Options{Locale: locale.German}},
+			want: []Token{
+				{Kind: NumberToken, Value: "1234567.5", Position: 0},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/three digits before the grouping separator",
+			args: args{text: "123.456", options: Options{Locale: locale.German}},
+			want: []Token{
+				{Kind: NumberToken, Value: "123456", Position: 0},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/french grouping separator",
+			args: args{text: "1\u202f234,5", options: Options{Locale: locale.French}},
+			want: []Token{
+				{Kind: NumberToken, Value: "1234.5", Position: 0},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/argument separator inside brackets",
+			args: args{text: "[1;2]", options: Options{Locale: locale.German}},
+			want: []Token{
+				{Kind: LeftBracketToken, Position: 0},
+				{Kind: NumberToken, Value: "1", Position: 1},
+				{Kind: CommaToken, Position: 2},
+				{Kind: NumberToken, Value: "2", Position: 3},
+				{Kind: RightBracketToken, Position: 4},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error/grouping separator followed by one digit",
+			args:    args{text: "1.5", options: Options{Locale: locale.German}},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/grouping separator followed by two digits",
+			args:    args{text: "1.23", options: Options{Locale: locale.German}},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/grouping separator followed by four digits",
+			args:    args{text: "1.2345", options: Options{Locale: locale.German}},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/several short digit groups",
+			args:    args{text: "1.2.3", options: Options{Locale: locale.German}},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/more than three digits before the grouping separator",
+			args:    args{text: "12345.678", options: Options{Locale: locale.German}},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/grouping separator after the decimal separator",
+			args:    args{text: "1,234.5", options: Options{Locale: locale.German}},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/invalid locale",
+			args:    args{text: "1", options: Options{Locale: locale.Locale{DecimalSeparator: ';', ArgumentSeparator: ','}}},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "success/unicode aliases are disabled",
+			args: args{text: "2π", options: Options{DisableUnicodeAliases: true}},
+			want: []Token{
+				{Kind: NumberToken, Value: "2", Position: 0},
+				{Kind: IdentifierToken, Value: "π", Position: 1},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error/unicode aliases are disabled",
+			args:    args{text: "2 × 3", options: Options{DisableUnicodeAliases: true}},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/unsupported comparison",
+			args:    args{text: "x ≤ 2", options: Options{}},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/repeated superscript minus",
+			args:    args{text: "x⁻⁻1", options: Options{}},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TokenizeWithOptions(tt.args.text, tt.args.options)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestTokenizeWithComments(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		want         []Token
+		wantDangling []Comment
+		wantErr      assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			text: "# leading\n12 /* inner */ + 34 // trailing\n/* last */",
+			want: []Token{
+				{
+					Kind:            NewlineToken,
+					Position:        9,
+					LeadingComments: []Comment{{Text: "# leading", Position: 0}},
+				},
+				{
+					Kind:             NumberToken,
+					Value:            "12",
+					Position:         10,
+					TrailingComments: []Comment{{Text: "/* inner */", Position: 13}},
+				},
+				{Kind: PlusToken, Position: 25},
+				{
+					Kind:             NumberToken,
+					Value:            "34",
+					Position:         27,
+					TrailingComments: []Comment{{Text: "// trailing", Position: 30}},
+				},
+				{
+					Kind:             NewlineToken,
+					Position:         41,
+					TrailingComments: []Comment{{Text: "/* last */", Position: 42}},
+				},
+			},
+			wantDangling: nil,
+			wantErr:      assert.NoError,
+		},
+		{
+			name:         "success/only a comment",
+			text:         "# x",
+			want:         nil,
+			wantDangling: []Comment{{Text: "# x", Position: 0}},
+			wantErr:      assert.NoError,
+		},
+		{
+			name:         "success/only comments",
+			text:         "/* a */ /* b */",
+			want:         nil,
+			wantDangling: []Comment{{Text: "/* a */", Position: 0}, {Text: "/* b */", Position: 8}},
+			wantErr:      assert.NoError,
+		},
+		{
+			name:         "error",
+			text:         "12 /* comment",
+			want:         nil,
+			wantDangling: nil,
+			wantErr:      assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotDangling, err := TokenizeWithComments(tt.text, Options{})
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantDangling, gotDangling)
+			tt.wantErr(t, err)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package translator
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/rmaidveo/go-calculator/containers"
 	"github.com/rmaidveo/go-calculator/tokenizer"
@@ -13,18 +14,119 @@ const (
 	PushNumberCommand CommandKind = iota
 	PushVariableCommand
 	CallFunctionCommand
+	AssignVariableCommand
+	BindVariableCommand
+	UnbindVariableCommand
+	PopCommand
 )
 
+const (
+	PercentFunction = "percent"
+)
+
+var bracketFunctions = map[tokenizer.TokenKind]string{
+	tokenizer.VerticalBarToken: "abs",
+	tokenizer.LeftFloorToken:   "floor",
+	tokenizer.LeftCeilingToken: "ceil",
+}
+
+var bracketNames = map[tokenizer.TokenKind]string{
+	tokenizer.LeftParenthesisToken:  "left parenthesis",
+	tokenizer.RightParenthesisToken: "right parenthesis",
+	tokenizer.LeftBracketToken:      "left bracket",
+	tokenizer.RightBracketToken:     "right bracket",
+	tokenizer.LeftBraceToken:        "left brace",
+	tokenizer.RightBraceToken:       "right brace",
+	tokenizer.VerticalBarToken:      "vertical bar",
+	tokenizer.LeftFloorToken:        "left floor bracket",
+	tokenizer.RightFloorToken:       "right floor bracket",
+	tokenizer.LeftCeilingToken:      "left ceiling bracket",
+	tokenizer.RightCeilingToken:     "right ceiling bracket",
+}
+
+type ImplicitMultiplication int
+
+const (
+	NoImplicitMultiplication ImplicitMultiplication = iota
+	ImplicitMultiplicationAsExplicit
+	ImplicitMultiplicationBindsTighter
+)
+
+type Options struct {
+	Constants              map[string]float64
+	ImplicitMultiplication ImplicitMultiplication
+	PostfixPercent         bool
+	ResolveFunction        func(name string) (string, bool)
+}
+
 type Command struct {
 	Kind     CommandKind
 	Operand  string
 	Position int
 }
 
+func (kind CommandKind) String() string {
+	switch kind {
+	case PushNumberCommand:
+		return "push"
+	case PushVariableCommand:
+		return "load"
+	case CallFunctionCommand:
+		return "call"
+	case AssignVariableCommand:
+		return "assign"
+	case BindVariableCommand:
+		return "bind"
+	case UnbindVariableCommand:
+		return "unbind"
+	case PopCommand:
+		return "pop"
+	default:
+		return ""
+	}
+}
+
+func (command Command) String() string {
+	if command.Operand == "" {
+		return command.Kind.String()
+	}
+
+	return command.Kind.String() + " " + command.Operand
+}
+
 func Translate(tokens []tokenizer.Token, functions map[string]struct{}) ([]Command, error) {
+	return TranslateWithOptions(tokens, functions, Options{})
+}
+
+func TranslateWithOptions(tokens []tokenizer.Token, functions map[string]struct{}, options Options) ([]Command, error) {
+	constants := options.Constants
 	var commands []Command
 	var tokenStack containers.Stack[tokenizer.Token]
-	for _, token := range tokens {
+	statementStart := 0
+	bracketDepth := 0
+	expectOperand := true
+	for index := 0; index < len(tokens); index++ {
+		token := tokens[index]
+		isClosingBracket := token.Kind.IsClosingBracket() &&
+			(token.Kind != tokenizer.VerticalBarToken || isClosingBar(tokenStack, expectOperand))
+		if !expectOperand && !isClosingBracket && startsOperand(token) {
+			if options.ImplicitMultiplication == NoImplicitMultiplication ||
+				token.Kind == tokenizer.NumberToken {
+				return nil, fmt.Errorf("unexpected %s after an operand at position %d", operandName(token), token.Position)
+			}
+
+			implicitMultiplicationKind := tokenizer.AsteriskToken
+			if options.ImplicitMultiplication == ImplicitMultiplicationBindsTighter {
+				implicitMultiplicationKind = tokenizer.ImplicitMultiplicationToken
+			}
+
+			commands = append(commands, pushOperator(&tokenStack, tokenizer.Token{
+				Kind:     implicitMultiplicationKind,
+				Position: token.Position,
+			})...)
+			expectOperand = true
+		}
+
 		switch {
 		case token.Kind == tokenizer.NumberToken:
 			commands = append(commands, Command{
@@ -32,72 +134,337 @@ func Translate(tokens []tokenizer.Token, functions map[string]struct{}) ([]Comma
 				Operand:  token.Value,
 				Position: token.Position,
 			})
+			expectOperand = false
 		case token.Kind == tokenizer.IdentifierToken:
-			if _, ok := functions[token.Value]; ok {
+			if functionName, ok := resolveFunction(token.Value, functions, options.ResolveFunction); ok {
+				if index+1 >= len(tokens) || tokens[index+1].Kind != tokenizer.LeftParenthesisToken {
+					return nil, fmt.Errorf("expected a left parenthesis after the function %q at position %d", token.Value, token.Position)
+				}
+
+				token.Value = functionName
 				tokenStack.Push(token)
 				continue
 			}
+			if constant, ok := constants[token.Value]; ok {
+				commands = append(commands, Command{
+					Kind:     PushNumberCommand,
+					Operand:  strconv.FormatFloat(constant, 'g', -1, 64),
+					Position: token.Position,
+				})
+				expectOperand = false
+				continue
+			}
 
 			commands = append(commands, Command{
 				Kind:     PushVariableCommand,
 				Operand:  token.Value,
 				Position: token.Position,
 			})
-		case token.Kind.IsOperator():
-			additionalCommands := unwindStack(&tokenStack, func(lastStackToken tokenizer.Token) bool {
-				return !lastStackToken.Kind.IsOperator() || lastStackToken.Kind.Precedence() <= token.Kind.Precedence()
+			expectOperand = false
+		case token.Kind == tokenizer.PlusToken && expectOperand:
+			continue
+		case token.Kind == tokenizer.MinusToken && expectOperand:
+			tokenStack.Push(tokenizer.Token{
+				Kind:     tokenizer.NegationToken,
+				Position: token.Position,
 			})
-			commands = append(commands, additionalCommands...)
+		case token.Kind.IsPostfix() || token.Kind == tokenizer.PercentToken && options.PostfixPercent:
+			if expectOperand {
+				return nil, fmt.Errorf("expected an operand before the postfix operator %q at position %d", token.Kind, token.Position)
+			}
 
+			functionName := token.Kind.String()
+			if token.Kind == tokenizer.PercentToken {
+				functionName = PercentFunction
+			}
+
+			commands = append(commands, Command{
+				Kind:     CallFunctionCommand,
+				Operand:  functionName,
+				Position: token.Position,
+			})
+		case token.Kind == tokenizer.SquareRootToken:
 			tokenStack.Push(token)
-		case token.Kind == tokenizer.LeftParenthesisToken:
-			tokenStack.Push(token)
-		case token.Kind == tokenizer.RightParenthesisToken:
-			additionalCommands := unwindStack(&tokenStack, func(lastStackToken tokenizer.Token) bool {
-				return lastStackToken.Kind == tokenizer.LeftParenthesisToken
+		case token.Kind.IsOperator():
+			commands = append(commands, pushOperator(&tokenStack, token)...)
+			expectOperand = true
+		case token.Kind == tokenizer.EqualsToken:
+			if isAssignmentTarget(tokens, index-1) {
+				if _, ok := constants[tokens[index-1].Value]; ok {
+					return nil, fmt.Errorf("unable to assign to the constant %q at position %d", tokens[index-1].Value, tokens[index-1].Position)
+				}
+			}
+			if !isAssignmentTarget(tokens, index-1) ||
+				len(commands) == 0 ||
+				commands[len(commands)-1].Kind != PushVariableCommand {
+				return nil, fmt.Errorf("unexpected assignment at position %d", token.Position)
+			}
+
+			target := commands[len(commands)-1]
+			commands = commands[:len(commands)-1]
+
+			tokenStack.Push(tokenizer.Token{
+				Kind:     tokenizer.EqualsToken,
+				Value:    target.Operand,
+				Position: target.Position,
+			})
+			expectOperand = true
+		case token.Kind == tokenizer.LetToken:
+			if index+2 >= len(tokens) ||
+				tokens[index+1].Kind != tokenizer.IdentifierToken ||
+				tokens[index+2].Kind != tokenizer.EqualsToken {
+				return nil, fmt.Errorf("expected a variable name and an equals sign after let at position %d", token.Position)
+			}
+			if _, ok := constants[tokens[index+1].Value]; ok {
+				return nil, fmt.Errorf("unable to bind the constant %q at position %d", tokens[index+1].Value, tokens[index+1].Position)
+			}
+
+			tokenStack.Push(tokenizer.Token{
+				Kind:     tokenizer.LetToken,
+				Value:    tokens[index+1].Value,
+				Position: tokens[index+1].Position,
 			})
+			index += 2
+			expectOperand = true
+		case token.Kind == tokenizer.InToken:
+			additionalCommands := unwindStack(&tokenStack, isGroupOpening)
 			commands = append(commands, additionalCommands...)
 
-			if _, ok := tokenStack.Pop(); !ok {
-				return nil, fmt.Errorf("no left parenthesis is found, but a right parenthesis at position %d", token.Position)
+			lastStackToken, ok := tokenStack.Pop()
+			if !ok || lastStackToken.Kind != tokenizer.LetToken {
+				return nil, fmt.Errorf("no let is found, but in at position %d", token.Position)
 			}
 
+			commands = append(commands, Command{
+				Kind:     BindVariableCommand,
+				Operand:  lastStackToken.Value,
+				Position: lastStackToken.Position,
+			})
+			tokenStack.Push(tokenizer.Token{
+				Kind:     tokenizer.InToken,
+				Value:    lastStackToken.Value,
+				Position: lastStackToken.Position,
+			})
+			expectOperand = true
+		case isClosingBracket:
+			additionalCommands := unwindStack(&tokenStack, isGroupOpening)
+			commands = append(commands, additionalCommands...)
+
 			lastStackToken, ok := tokenStack.Pop()
-			if ok {
-				if lastStackToken.Kind == tokenizer.IdentifierToken {
-					commands = append(commands, Command{
-						Kind:     CallFunctionCommand,
-						Operand:  lastStackToken.Value,
-						Position: lastStackToken.Position,
-					})
-				} else {
-					tokenStack.Push(lastStackToken)
+			if !ok {
+				openingKind, _ := token.Kind.OpeningBracket()
+				return nil, fmt.Errorf(
+					"no %s is found, but a %s at position %d",
+					bracketNames[openingKind],
+					bracketNames[token.Kind],
+					token.Position,
+				)
+			}
+			if lastStackToken.Kind == tokenizer.LetToken {
+				return nil, fmt.Errorf("no in is found for the let at position %d", lastStackToken.Position)
+			}
+			if closingKind, _ := lastStackToken.Kind.ClosingBracket(); closingKind != token.Kind {
+				return nil, fmt.Errorf(
+					"mismatched brackets: the %s at position %d is closed by the %s at position %d",
+					bracketNames[lastStackToken.Kind],
+					lastStackToken.Position,
+					bracketNames[token.Kind],
+					token.Position,
+				)
+			}
+
+			if functionName, ok := bracketFunctions[lastStackToken.Kind]; ok {
+				commands = append(commands, Command{
+					Kind:     CallFunctionCommand,
+					Operand:  functionName,
+					Position: lastStackToken.Position,
+				})
+			} else if lastStackToken.Kind == tokenizer.LeftParenthesisToken {
+				lastStackToken, ok = tokenStack.Pop()
+				if ok {
+					if lastStackToken.Kind == tokenizer.IdentifierToken {
+						commands = append(commands, Command{
+							Kind:     CallFunctionCommand,
+							Operand:  lastStackToken.Value,
+							Position: lastStackToken.Position,
+						})
+					} else {
+						tokenStack.Push(lastStackToken)
+					}
 				}
 			}
+
+			bracketDepth--
+			expectOperand = false
+		case token.Kind.IsOpeningBracket():
+			tokenStack.Push(token)
+			bracketDepth++
+			expectOperand = true
 		case token.Kind == tokenizer.CommaToken:
-			additionalCommands := unwindStack(&tokenStack, func(lastStackToken tokenizer.Token) bool {
-				return lastStackToken.Kind == tokenizer.LeftParenthesisToken
-			})
+			additionalCommands := unwindStack(&tokenStack, isGroupOpening)
 			commands = append(commands, additionalCommands...)
 
-			if tokenStack.IsEmpty() {
+			lastStackToken, ok := tokenStack.Pop()
+			if !ok {
 				return nil, fmt.Errorf("no left parenthesis is found, but a comma at position %d", token.Position)
 			}
+			if lastStackToken.Kind == tokenizer.LetToken {
+				return nil, fmt.Errorf("no in is found for the let at position %d", lastStackToken.Position)
+			}
+			if lastStackToken.Kind != tokenizer.LeftParenthesisToken {
+				return nil, fmt.Errorf(
+					"unexpected comma inside the %s at position %d",
+					bracketNames[lastStackToken.Kind],
+					token.Position,
+				)
+			}
+
+			tokenStack.Push(lastStackToken)
+			expectOperand = true
+		case token.Kind.IsStatementSeparator():
+			statementIsEmpty := len(commands) == statementStart && tokenStack.IsEmpty()
+			if statementIsEmpty {
+				continue
+			}
+			if token.Kind == tokenizer.NewlineToken &&
+				(bracketDepth > 0 || expectOperand || continuesLine(tokens, index)) {
+				continue
+			}
+
+			additionalCommands, err := finishStatement(&tokenStack)
+			if err != nil {
+				return nil, err
+			}
+			commands = append(commands, additionalCommands...)
+
+			commands = append(commands, Command{
+				Kind:     PopCommand,
+				Position: token.Position,
+			})
+			statementStart = len(commands)
+			expectOperand = true
 		}
 	}
 
-	additionalCommands := unwindStack(&tokenStack, func(lastStackToken tokenizer.Token) bool {
-		return lastStackToken.Kind == tokenizer.LeftParenthesisToken
-	})
+	additionalCommands, err := finishStatement(&tokenStack)
+	if err != nil {
+		return nil, err
+	}
 	commands = append(commands, additionalCommands...)
 
+	if len(commands) > 0 && commands[len(commands)-1].Kind == PopCommand {
+		commands = commands[:len(commands)-1]
+	}
+
+	return commands, nil
+}
+
+func resolveFunction(
+	name string,
+	functions map[string]struct{},
+	resolve func(name string) (string, bool),
+) (string, bool) {
+	if _, ok := functions[name]; ok {
+		return name, true
+	}
+	if resolve == nil {
+		return "", false
+	}
+
+	return resolve(name)
+}
+
+func continuesLine(tokens []tokenizer.Token, newlineIndex int) bool {
+	for _, token := range tokens[newlineIndex+1:] {
+		if token.Kind == tokenizer.NewlineToken {
+			continue
+		}
+
+		return token.Kind.IsOperator() && token.Kind != tokenizer.SquareRootToken || token.Kind.IsPostfix()
+	}
+
+	return false
+}
+
+func startsOperand(token tokenizer.Token) bool {
+	return token.Kind == tokenizer.NumberToken ||
+		token.Kind == tokenizer.IdentifierToken ||
+		token.Kind == tokenizer.SquareRootToken ||
+		token.Kind.IsOpeningBracket()
+}
+
+func operandName(token tokenizer.Token) string {
+	switch {
+	case token.Kind == tokenizer.NumberToken:
+		return "number"
+	case token.Kind == tokenizer.IdentifierToken:
+		return fmt.Sprintf("identifier %q", token.Value)
+	case token.Kind == tokenizer.SquareRootToken:
+		return "square root"
+	default:
+		return bracketNames[token.Kind]
+	}
+}
+
+func isClosingBar(tokenStack containers.Stack[tokenizer.Token], expectOperand bool) bool {
+	if expectOperand {
+		return false
+	}
+
+	elements := tokenStack.Elements()
+	for index := len(elements) - 1; index >= 0; index-- {
+		if elements[index].Kind.IsOpeningBracket() {
+			return elements[index].Kind == tokenizer.VerticalBarToken
+		}
+	}
+
+	return false
+}
+
+func pushOperator(tokenStack *containers.Stack[tokenizer.Token], token tokenizer.Token) []Command {
+	commands := unwindStack(tokenStack, func(lastStackToken tokenizer.Token) bool {
+		if !lastStackToken.Kind.IsOperator() {
+			return true
+		}
+		if token.Kind.IsRightAssociative() {
+			return lastStackToken.Kind.Precedence() <= token.Kind.Precedence()
+		}
+
+		return lastStackToken.Kind.Precedence() < token.Kind.Precedence()
+	})
+	tokenStack.Push(token)
+
+	return commands
+}
+
+func finishStatement(tokenStack *containers.Stack[tokenizer.Token]) ([]Command, error) {
+	commands := unwindStack(tokenStack, isGroupOpening)
+
 	if lastStackToken, ok := tokenStack.Pop(); ok {
-		return nil, fmt.Errorf("unexpected left parenthesis is found at position %d", lastStackToken.Position)
+		if lastStackToken.Kind == tokenizer.LetToken {
+			return nil, fmt.Errorf("no in is found for the let at position %d", lastStackToken.Position)
+		}
+
+		return nil, fmt.Errorf("unexpected %s is found at position %d", bracketNames[lastStackToken.Kind], lastStackToken.Position)
 	}
 
 	return commands, nil
 }
 
+func isGroupOpening(lastStackToken tokenizer.Token) bool {
+	return lastStackToken.Kind.IsOpeningBracket() || lastStackToken.Kind == tokenizer.LetToken
+}
+
+func isAssignmentTarget(tokens []tokenizer.Token, index int) bool {
+	if index < 0 || tokens[index].Kind != tokenizer.IdentifierToken {
+		return false
+	}
+
+	return index == 0 ||
+		tokens[index-1].Kind.IsStatementSeparator() ||
+		tokens[index-1].Kind == tokenizer.EqualsToken
+}
+
 func unwindStack(
 	tokenStack *containers.Stack[tokenizer.Token],
 	stopCondition func(lastStackToken tokenizer.Token) bool,
@@ -113,12 +480,31 @@ func unwindStack(
 			break
 		}
 
-		commands = append(commands, Command{
-			Kind:     CallFunctionCommand,
-			Operand:  lastStackToken.Kind.String(),
-			Position: lastStackToken.Position,
-		})
+		commands = append(commands, commandForStackToken(lastStackToken))
 	}
 
 	return commands
 }
+
+func commandForStackToken(token tokenizer.Token) Command {
+	switch token.Kind {
+	case tokenizer.EqualsToken:
+		return Command{
+			Kind:     AssignVariableCommand,
+			Operand:  token.Value,
+			Position: token.Position,
+		}
+	case tokenizer.InToken:
+		return Command{
+			Kind:     UnbindVariableCommand,
+			Operand:  token.Value,
+			Position: token.Position,
+		}
+	default:
+		return Command{
+			Kind:     CallFunctionCommand,
+			Operand:  token.Kind.String(),
+			Position: token.Position,
+		}
+	}
+}
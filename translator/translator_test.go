@@ -1,6 +1,8 @@
 package translator
 
 import (
+	"math"
+	"strings"
 	"testing"
 
 	"github.com/rmaidveo/go-calculator/tokenizer"
@@ -87,6 +89,46 @@ func TestTranslate(t *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "success/multiple operators (left-associative)",
+			args: args{
+				tokens: []tokenizer.Token{
+					{Kind: tokenizer.NumberToken, Value: "12", Position: 112},
+					{Kind: tokenizer.MinusToken, Position: 120},
+					{Kind: tokenizer.NumberToken, Value: "23", Position: 123},
+					{Kind: tokenizer.MinusToken, Position: 140},
+					{Kind: tokenizer.NumberToken, Value: "42", Position: 142},
+				},
+			},
+			want: []Command{
+				{Kind: PushNumberCommand, Operand: "12", Position: 112},
+				{Kind: PushNumberCommand, Operand: "23", Position: 123},
+				{Kind: CallFunctionCommand, Operand: "-", Position: 120},
+				{Kind: PushNumberCommand, Operand: "42", Position: 142},
+				{Kind: CallFunctionCommand, Operand: "-", Position: 140},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/multiple operators (right-associative)",
+			args: args{
+				tokens: []tokenizer.Token{
+					{Kind: tokenizer.NumberToken, Value: "12", Position: 112},
+					{Kind: tokenizer.ExponentiationToken, Position: 120},
+					{Kind: tokenizer.NumberToken, Value: "23", Position: 123},
+					{Kind: tokenizer.ExponentiationToken, Position: 140},
+					{Kind: tokenizer.NumberToken, Value: "42", Position: 142},
+				},
+			},
+			want: []Command{
+				{Kind: PushNumberCommand, Operand: "12", Position: 112},
+				{Kind: PushNumberCommand, Operand: "23", Position: 123},
+				{Kind: PushNumberCommand, Operand: "42", Position: 142},
+				{Kind: CallFunctionCommand, Operand: "^", Position: 140},
+				{Kind: CallFunctionCommand, Operand: "^", Position: 120},
+			},
+			wantErr: assert.NoError,
+		},
 		{
 			name: "success/multiple operators (with parentheses)",
 			args: args{
@@ -180,6 +222,190 @@ func TestTranslate(t *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "success/unary operators",
+			args: args{
+				tokens: []tokenizer.Token{
+					{Kind: tokenizer.MinusToken, Position: 100},
+					{Kind: tokenizer.NumberToken, Value: "12", Position: 101},
+					{Kind: tokenizer.ExponentiationToken, Position: 104},
+					{Kind: tokenizer.PlusToken, Position: 106},
+					{Kind: tokenizer.MinusToken, Position: 107},
+					{Kind: tokenizer.NumberToken, Value: "23", Position: 108},
+					{Kind: tokenizer.AsteriskToken, Position: 111},
+					{Kind: tokenizer.NumberToken, Value: "42", Position: 113},
+				},
+			},
+			want: []Command{
+				{Kind: PushNumberCommand, Operand: "12", Position: 101},
+				{Kind: PushNumberCommand, Operand: "23", Position: 108},
+				{Kind: CallFunctionCommand, Operand: "neg", Position: 107},
+				{Kind: CallFunctionCommand, Operand: "^", Position: 104},
+				{Kind: CallFunctionCommand, Operand: "neg", Position: 100},
+				{Kind: PushNumberCommand, Operand: "42", Position: 113},
+				{Kind: CallFunctionCommand, Operand: "*", Position: 111},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/unary operators (after a parenthesis and a comma)",
+			args: args{
+				tokens: []tokenizer.Token{
+					{Kind: tokenizer.IdentifierToken, Value: "max", Position: 100},
+					{Kind: tokenizer.LeftParenthesisToken, Position: 103},
+					{Kind: tokenizer.MinusToken, Position: 104},
+					{Kind: tokenizer.NumberToken, Value: "12", Position: 105},
+					{Kind: tokenizer.CommaToken, Position: 107},
+					{Kind: tokenizer.MinusToken, Position: 109},
+					{Kind: tokenizer.IdentifierToken, Value: "x", Position: 110},
+					{Kind: tokenizer.RightParenthesisToken, Position: 111},
+				},
+				functions: map[string]struct{}{"max": {}},
+			},
+			want: []Command{
+				{Kind: PushNumberCommand, Operand: "12", Position: 105},
+				{Kind: CallFunctionCommand, Operand: "neg", Position: 104},
+				{Kind: PushVariableCommand, Operand: "x", Position: 110},
+				{Kind: CallFunctionCommand, Operand: "neg", Position: 109},
+				{Kind: CallFunctionCommand, Operand: "max", Position: 100},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/assignment",
+			args: args{
+				tokens: []tokenizer.Token{
+					{Kind: tokenizer.IdentifierToken, Value: "x", Position: 100},
+					{Kind: tokenizer.EqualsToken, Position: 102},
+					{Kind: tokenizer.NumberToken, Value: "12", Position: 104},
+					{Kind: tokenizer.PlusToken, Position: 107},
+					{Kind: tokenizer.NumberToken, Value: "23", Position: 109},
+				},
+			},
+			want: []Command{
+				{Kind: PushNumberCommand, Operand: "12", Position: 104},
+				{Kind: PushNumberCommand, Operand: "23", Position: 109},
+				{Kind: CallFunctionCommand, Operand: "+", Position: 107},
+				{Kind: AssignVariableCommand, Operand: "x", Position: 100},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/assignment (chained)",
+			args: args{
+				tokens: []tokenizer.Token{
+					{Kind: tokenizer.IdentifierToken, Value: "x", Position: 100},
+					{Kind: tokenizer.EqualsToken, Position: 102},
+					{Kind: tokenizer.IdentifierToken, Value: "y", Position: 104},
+					{Kind: tokenizer.EqualsToken, Position: 106},
+					{Kind: tokenizer.NumberToken, Value: "12", Position: 108},
+				},
+			},
+			want: []Command{
+				{Kind: PushNumberCommand, Operand: "12", Position: 108},
+				{Kind: AssignVariableCommand, Operand: "y", Position: 104},
+				{Kind: AssignVariableCommand, Operand: "x", Position: 100},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/let binding",
+			args: args{
+				tokens: []tokenizer.Token{
+					{Kind: tokenizer.NumberToken, Value: "2", Position: 100},
+					{Kind: tokenizer.AsteriskToken, Position: 102},
+					{Kind: tokenizer.LeftParenthesisToken, Position: 104},
+					{Kind: tokenizer.LetToken, Position: 105},
+					{Kind: tokenizer.IdentifierToken, Value: "x", Position: 109},
+					{Kind: tokenizer.EqualsToken, Position: 111},
+					{Kind: tokenizer.NumberToken, Value: "12", Position: 113},
+					{Kind: tokenizer.InToken, Position: 116},
+					{Kind: tokenizer.IdentifierToken, Value: "x", Position: 119},
+					{Kind: tokenizer.PlusToken, Position: 121},
+					{Kind: tokenizer.NumberToken, Value: "23", Position: 123},
+					{Kind: tokenizer.RightParenthesisToken, Position: 125},
+				},
+			},
+			want: []Command{
+				{Kind: PushNumberCommand, Operand: "2", Position: 100},
+				{Kind: PushNumberCommand, Operand: "12", Position: 113},
+				{Kind: BindVariableCommand, Operand: "x", Position: 109},
+				{Kind: PushVariableCommand, Operand: "x", Position: 119},
+				{Kind: PushNumberCommand, Operand: "23", Position: 123},
+				{Kind: CallFunctionCommand, Operand: "+", Position: 121},
+				{Kind: UnbindVariableCommand, Operand: "x", Position: 109},
+				{Kind: CallFunctionCommand, Operand: "*", Position: 102},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/several statements",
+			args: args{
+				tokens: []tokenizer.Token{
+					{Kind: tokenizer.IdentifierToken, Value: "x", Position: 100},
+					{Kind: tokenizer.EqualsToken, Position: 102},
+					{Kind: tokenizer.NumberToken, Value: "12", Position: 104},
+					{Kind: tokenizer.SemicolonToken, Position: 106},
+					{Kind: tokenizer.NewlineToken, Position: 107},
+					{Kind: tokenizer.IdentifierToken, Value: "x", Position: 108},
+					{Kind: tokenizer.AsteriskToken, Position: 110},
+					{Kind: tokenizer.NumberToken, Value: "23", Position: 112},
+					{Kind: tokenizer.NewlineToken, Position: 114},
+				},
+			},
+			want: []Command{
+				{Kind: PushNumberCommand, Operand: "12", Position: 104},
+				{Kind: AssignVariableCommand, Operand: "x", Position: 100},
+				{Kind: PopCommand, Position: 106},
+				{Kind: PushVariableCommand, Operand: "x", Position: 108},
+				{Kind: PushNumberCommand, Operand: "23", Position: 112},
+				{Kind: CallFunctionCommand, Operand: "*", Position: 110},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/several statements (with a line starting with an operator)",
+			args: args{
+				tokens: []tokenizer.Token{
+					{Kind: tokenizer.IdentifierToken, Value: "x", Position: 100},
+					{Kind: tokenizer.NewlineToken, Position: 101},
+					{Kind: tokenizer.NewlineToken, Position: 102},
+					{Kind: tokenizer.PlusToken, Position: 103},
+					{Kind: tokenizer.NumberToken, Value: "1", Position: 104},
+					{Kind: tokenizer.NewlineToken, Position: 105},
+					{Kind: tokenizer.AsteriskToken, Position: 106},
+					{Kind: tokenizer.NumberToken, Value: "2", Position: 107},
+				},
+			},
+			want: []Command{
+				{Kind: PushVariableCommand, Operand: "x", Position: 100},
+				{Kind: PushNumberCommand, Operand: "1", Position: 104},
+				{Kind: PushNumberCommand, Operand: "2", Position: 107},
+				{Kind: CallFunctionCommand, Operand: "*", Position: 106},
+				{Kind: CallFunctionCommand, Operand: "+", Position: 103},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/several statements (with a continued line)",
+			args: args{
+				tokens: []tokenizer.Token{
+					{Kind: tokenizer.NumberToken, Value: "12", Position: 100},
+					{Kind: tokenizer.PlusToken, Position: 103},
+					{Kind: tokenizer.NewlineToken, Position: 104},
+					{Kind: tokenizer.LeftParenthesisToken, Position: 105},
+					{Kind: tokenizer.NumberToken, Value: "23", Position: 106},
+					{Kind: tokenizer.NewlineToken, Position: 108},
+					{Kind: tokenizer.RightParenthesisToken, Position: 109},
+				},
+			},
+			want: []Command{
+				{Kind: PushNumberCommand, Operand: "12", Position: 100},
+				{Kind: PushNumberCommand, Operand: "23", Position: 106},
+				{Kind: CallFunctionCommand, Operand: "+", Position: 103},
+			},
+			wantErr: assert.NoError,
+		},
 		{
 			name: "error/no left parenthesis is found",
 			args: args{
@@ -229,6 +455,98 @@ func TestTranslate(t *testing.T) {
 			want:    nil,
 			wantErr: assert.Error,
 		},
+		{
+			name: "error/function call/function name inside parentheses",
+			args: args{
+				tokens: []tokenizer.Token{
+					{Kind: tokenizer.LeftParenthesisToken, Value: "(", Position: 100},
+					{Kind: tokenizer.IdentifierToken, Value: "sin", Position: 101},
+					{Kind: tokenizer.RightParenthesisToken, Value: ")", Position: 105},
+					{Kind: tokenizer.NumberToken, Value: "0", Position: 106},
+				},
+				functions: map[string]struct{}{"sin": {}},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/assignment/not to a variable",
+			args: args{
+				tokens: []tokenizer.Token{
+					{Kind: tokenizer.NumberToken, Value: "12", Position: 100},
+					{Kind: tokenizer.EqualsToken, Position: 103},
+					{Kind: tokenizer.NumberToken, Value: "23", Position: 105},
+				},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/assignment/not at the beginning of a statement",
+			args: args{
+				tokens: []tokenizer.Token{
+					{Kind: tokenizer.NumberToken, Value: "12", Position: 100},
+					{Kind: tokenizer.PlusToken, Position: 103},
+					{Kind: tokenizer.IdentifierToken, Value: "x", Position: 105},
+					{Kind: tokenizer.EqualsToken, Position: 107},
+					{Kind: tokenizer.NumberToken, Value: "23", Position: 109},
+				},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/let binding/no variable name",
+			args: args{
+				tokens: []tokenizer.Token{
+					{Kind: tokenizer.LetToken, Position: 100},
+					{Kind: tokenizer.NumberToken, Value: "12", Position: 104},
+					{Kind: tokenizer.InToken, Position: 107},
+					{Kind: tokenizer.NumberToken, Value: "23", Position: 110},
+				},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/let binding/no in",
+			args: args{
+				tokens: []tokenizer.Token{
+					{Kind: tokenizer.LetToken, Position: 100},
+					{Kind: tokenizer.IdentifierToken, Value: "x", Position: 104},
+					{Kind: tokenizer.EqualsToken, Position: 106},
+					{Kind: tokenizer.NumberToken, Value: "12", Position: 108},
+				},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/let binding/no let",
+			args: args{
+				tokens: []tokenizer.Token{
+					{Kind: tokenizer.NumberToken, Value: "12", Position: 100},
+					{Kind: tokenizer.InToken, Position: 103},
+					{Kind: tokenizer.NumberToken, Value: "23", Position: 106},
+				},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/several statements/unexpected left parenthesis",
+			args: args{
+				tokens: []tokenizer.Token{
+					{Kind: tokenizer.LeftParenthesisToken, Position: 100},
+					{Kind: tokenizer.NumberToken, Value: "12", Position: 101},
+					{Kind: tokenizer.SemicolonToken, Position: 103},
+					{Kind: tokenizer.NumberToken, Value: "23", Position: 105},
+					{Kind: tokenizer.RightParenthesisToken, Position: 107},
+				},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -239,3 +557,569 @@ func TestTranslate(t *testing.T) {
 		})
 	}
 }
+
+func TestTranslateWithOptions_constants(t *testing.T) {
+	type args struct {
+		tokens    []tokenizer.Token
+		functions map[string]struct{}
+		constants map[string]float64
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    []Command
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success/constant",
+			args: args{
+				tokens: []tokenizer.Token{
+					{Kind: tokenizer.NumberToken, Value: "2", Position: 100},
+					{Kind: tokenizer.AsteriskToken, Value: "*", Position: 102},
+					{Kind: tokenizer.IdentifierToken, Value: "pi", Position: 104},
+				},
+				constants: map[string]float64{"pi": 3.5},
+			},
+			want: []Command{
+				{Kind: PushNumberCommand, Operand: "2", Position: 100},
+				{Kind: PushNumberCommand, Operand: "3.5", Position: 104},
+				{Kind: CallFunctionCommand, Operand: "*", Position: 102},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/special values",
+			args: args{
+				tokens: []tokenizer.Token{
+					{Kind: tokenizer.IdentifierToken, Value: "inf", Position: 100},
+					{Kind: tokenizer.PlusToken, Value: "+", Position: 104},
+					{Kind: tokenizer.IdentifierToken, Value: "nan", Position: 106},
+				},
+				constants: map[string]float64{"inf": math.Inf(+1), "nan": math.NaN()},
+			},
+			want: []Command{
+				{Kind: PushNumberCommand, Operand: "+Inf", Position: 100},
+				{Kind: PushNumberCommand, Operand: "NaN", Position: 106},
+				{Kind: CallFunctionCommand, Operand: "+", Position: 104},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/function with the same name",
+			args: args{
+				tokens: []tokenizer.Token{
+					{Kind: tokenizer.IdentifierToken, Value: "e", Position: 100},
+					{Kind: tokenizer.LeftParenthesisToken, Position: 101},
+					{Kind: tokenizer.RightParenthesisToken, Position: 102},
+				},
+				functions: map[string]struct{}{"e": {}},
+				constants: map[string]float64{"e": 2.5},
+			},
+			want:    []Command{{Kind: CallFunctionCommand, Operand: "e", Position: 100}},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/variable in an assignment",
+			args: args{
+				tokens: []tokenizer.Token{
+					{Kind: tokenizer.IdentifierToken, Value: "x", Position: 100},
+					{Kind: tokenizer.EqualsToken, Value: "=", Position: 102},
+					{Kind: tokenizer.IdentifierToken, Value: "pi", Position: 104},
+				},
+				constants: map[string]float64{"pi": 3.5},
+			},
+			want: []Command{
+				{Kind: PushNumberCommand, Operand: "3.5", Position: 104},
+				{Kind: AssignVariableCommand, Operand: "x", Position: 100},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error/assignment to a constant",
+			args: args{
+				tokens: []tokenizer.Token{
+					{Kind: tokenizer.IdentifierToken, Value: "pi", Position: 100},
+					{Kind: tokenizer.EqualsToken, Value: "=", Position: 103},
+					{Kind: tokenizer.NumberToken, Value: "3", Position: 105},
+				},
+				constants: map[string]float64{"pi": 3.5},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/chained assignment to a constant",
+			args: args{
+				tokens: []tokenizer.Token{
+					{Kind: tokenizer.IdentifierToken, Value: "x", Position: 100},
+					{Kind: tokenizer.EqualsToken, Value: "=", Position: 102},
+					{Kind: tokenizer.IdentifierToken, Value: "pi", Position: 104},
+					{Kind: tokenizer.EqualsToken, Value: "=", Position: 107},
+					{Kind: tokenizer.NumberToken, Value: "3", Position: 109},
+				},
+				constants: map[string]float64{"pi": 3.5},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/binding of a constant",
+			args: args{
+				tokens: []tokenizer.Token{
+					{Kind: tokenizer.LetToken, Position: 100},
+					{Kind: tokenizer.IdentifierToken, Value: "pi", Position: 104},
+					{Kind: tokenizer.EqualsToken, Value: "=", Position: 107},
+					{Kind: tokenizer.NumberToken, Value: "3", Position: 109},
+					{Kind: tokenizer.InToken, Position: 111},
+					{Kind: tokenizer.IdentifierToken, Value: "pi", Position: 114},
+				},
+				constants: map[string]float64{"pi": 3.5},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TranslateWithOptions(tt.args.tokens, tt.args.functions, Options{Constants: tt.args.constants})
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestTranslateWithOptions(t *testing.T) {
+	type args struct {
+		text      string
+		functions map[string]struct{}
+		options   Options
+	}
+
+	functions := map[string]struct{}{"sin": {}, "max": {}}
+	asExplicit := Options{ImplicitMultiplication: ImplicitMultiplicationAsExplicit}
+	bindsTighter := Options{ImplicitMultiplication: ImplicitMultiplicationBindsTighter}
+	withConstants := Options{Constants: map[string]float64{"pi": 3.5}, ImplicitMultiplication: ImplicitMultiplicationAsExplicit}
+	lowerCase := func(name string) (string, bool) {
+		name = strings.ToLower(name)
+		_, ok := functions[name]
+		return name, ok
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success/number and variable",
+			args:    args{text: "2x", functions: functions, options: asExplicit},
+			want:    "push 2; load x; call *",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/number and parentheses",
+			args:    args{text: "3(a + b)", functions: functions, options: asExplicit},
+			want:    "push 3; load a; load b; call +; call *",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/parentheses and parentheses",
+			args:    args{text: "(a + b)(a - b)", functions: functions, options: asExplicit},
+			want:    "load a; load b; call +; load a; load b; call -; call *",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/constant and variable",
+			args:    args{text: "2pi r", functions: functions, options: withConstants},
+			want:    "push 2; push 3.5; call *; load r; call *",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/variable and parentheses",
+			args:    args{text: "x(y)", functions: functions, options: asExplicit},
+			want:    "load x; load y; call *",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/function call",
+			args:    args{text: "2sin(x)max(x, 1)", functions: functions, options: asExplicit},
+			want:    "push 2; load x; call sin; call *; load x; push 1; call max; call *",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/square root",
+			args:    args{text: "√x² + 2√-y", functions: functions, options: asExplicit},
+			want:    "load x; push 2; call ^; call sqrt; push 2; load y; call neg; call sqrt; call *; call +",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error/square root after an operand",
+			args:    args{text: "2√x", functions: functions, options: Options{}},
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "success/division/as explicit",
+			args:    args{text: "1/2x", functions: functions, options: asExplicit},
+			want:    "push 1; push 2; call /; load x; call *",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/division/binds tighter",
+			args:    args{text: "1/2x", functions: functions, options: bindsTighter},
+			want:    "push 1; push 2; load x; call *; call /",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/exponentiation/binds tighter",
+			args:    args{text: "2x^2", functions: functions, options: bindsTighter},
+			want:    "push 2; load x; push 2; call ^; call *",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/negation/binds tighter",
+			args:    args{text: "-2x", functions: functions, options: bindsTighter},
+			want:    "push 2; call neg; load x; call *",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/assignment and statements",
+			args:    args{text: "y = 2x\n3y", functions: functions, options: asExplicit},
+			want:    "push 2; load x; call *; assign y; pop; push 3; load y; call *",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/disabled",
+			args:    args{text: "2 * x", functions: functions, options: Options{}},
+			want:    "push 2; load x; call *",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/postfix/factorial",
+			args:    args{text: "5! + 3!!", functions: functions, options: Options{}},
+			want:    "push 5; call !; push 3; call !; call !; call +",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/postfix/above exponentiation and negation",
+			args:    args{text: "-2^3!", functions: functions, options: Options{}},
+			want:    "push 2; push 3; call !; call ^; call neg",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/postfix/parentheses and function calls",
+			args:    args{text: "(a + b)! * sin(90°)", functions: functions, options: Options{}},
+			want:    "load a; load b; call +; call !; push 90; call °; call sin; call *",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/postfix/percent",
+			args:    args{text: "price * (1 - 15%)", functions: functions, options: Options{PostfixPercent: true}},
+			want:    "load price; push 1; push 15; call percent; call -; call *",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/postfix/modulo without the percent mode",
+			args:    args{text: "7 % 3", functions: functions, options: Options{}},
+			want:    "push 7; push 3; call %",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/postfix/implicit multiplication",
+			args:    args{text: "2x!y°", functions: functions, options: bindsTighter},
+			want:    "push 2; load x; call !; call *; load y; call °; call *",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error/postfix/no operand",
+			args:    args{text: "2 * !3", functions: functions, options: Options{}},
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/postfix/percent at the beginning",
+			args:    args{text: "% 3", functions: functions, options: Options{PostfixPercent: true}},
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/number after a number",
+			args:    args{text: "2 3", functions: functions, options: asExplicit},
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/number after a variable",
+			args:    args{text: "x 2", functions: functions, options: asExplicit},
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/number after parentheses",
+			args:    args{text: "(x)2", functions: functions, options: bindsTighter},
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/variable after a number without implicit multiplication",
+			args:    args{text: "2x", functions: functions, options: Options{}},
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/parentheses after a variable without implicit multiplication",
+			args:    args{text: "x (y)", functions: functions, options: Options{}},
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/absolute value between numbers without implicit multiplication",
+			args:    args{text: "2 |x| 3", functions: functions, options: Options{}},
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/postfix/number after percent",
+			args:    args{text: "10 % 3", functions: functions, options: Options{PostfixPercent: true}},
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/postfix/number after percent and before percent",
+			args:    args{text: "5 % 2%", functions: functions, options: Options{PostfixPercent: true}},
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "success/resolved function names",
+			args:    args{text: "SIN(x) + Max(x, 1)", functions: functions, options: Options{ResolveFunction: lowerCase}},
+			want:    "load x; call sin; load x; push 1; call max; call +",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error/unresolved function name",
+			args:    args{text: "SIN(x)", functions: functions, options: Options{}},
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/function without parentheses",
+			args:    args{text: "2sin x", functions: functions, options: asExplicit},
+			want:    "",
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := tokenizer.Tokenize(tt.args.text)
+			assert.NoError(t, err)
+
+			got, err := TranslateWithOptions(tokens, tt.args.functions, tt.args.options)
+
+			gotCommands := make([]string, 0, len(got))
+			for _, command := range got {
+				gotCommands = append(gotCommands, command.String())
+			}
+			assert.Equal(t, tt.want, strings.Join(gotCommands, "; "))
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestTranslate_associativity(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "subtraction is left-associative", text: "1 - 2 - 3", want: "push 1; push 2; call -; push 3; call -"},
+		{name: "division is left-associative", text: "8 / 2 / 2", want: "push 8; push 2; call /; push 2; call /"},
+		{name: "mixed precedence", text: "1 - 2 * 3 + 4", want: "push 1; push 2; push 3; call *; call -; push 4; call +"},
+		{name: "exponentiation is right-associative", text: "2 ^ 3 ^ 2", want: "push 2; push 3; push 2; call ^; call ^"},
+		{name: "negation is right-associative", text: "--2 ^ 2", want: "push 2; push 2; call ^; call neg; call neg"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := tokenizer.Tokenize(tt.text)
+			assert.NoError(t, err)
+
+			got, err := Translate(tokens, map[string]struct{}{})
+
+			gotCommands := make([]string, 0, len(got))
+			for _, command := range got {
+				gotCommands = append(gotCommands, command.String())
+			}
+			assert.Equal(t, tt.want, strings.Join(gotCommands, "; "))
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTranslate_brackets(t *testing.T) {
+	type args struct {
+		text      string
+		functions map[string]struct{}
+		options   Options
+	}
+
+	functions := map[string]struct{}{"max": {}}
+	asExplicit := Options{ImplicitMultiplication: ImplicitMultiplicationAsExplicit}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success/grouping brackets",
+			args:    args{text: "[a + b] * {c - (d)}", functions: functions},
+			want:    "load a; load b; call +; load c; load d; call -; call *",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/absolute value",
+			args:    args{text: "|x - y| + 1", functions: functions},
+			want:    "load x; load y; call -; call abs; push 1; call +",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/nested absolute values",
+			args:    args{text: "||x| - |y||", functions: functions},
+			want:    "load x; call abs; load y; call abs; call -; call abs",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/absolute value of a negation",
+			args:    args{text: "|-|x||", functions: functions},
+			want:    "load x; call abs; call neg; call abs",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/absolute value inside parentheses",
+			args:    args{text: "|(|x|)|", functions: functions},
+			want:    "load x; call abs; call abs",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/absolute value in a function call",
+			args:    args{text: "max(|x|, |y|)", functions: functions},
+			want:    "load x; call abs; load y; call abs; call max",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/floor and ceiling",
+			args:    args{text: "⌊x / 2⌋ - ⌈x / 2⌉", functions: functions},
+			want:    "load x; push 2; call /; call floor; load x; push 2; call /; call ceil; call -",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/implicit multiplication",
+			args:    args{text: "2|x|[y]⌊z⌋", functions: functions, options: asExplicit},
+			want:    "push 2; load x; call abs; call *; load y; call *; load z; call floor; call *",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success/newline inside brackets",
+			args:    args{text: "[1 +\n2\n]", functions: functions},
+			want:    "push 1; push 2; call +",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error/mismatched brackets",
+			args: args{text: "[a + b)", functions: functions},
+			want: "",
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(
+					t,
+					err,
+					"mismatched brackets: the left bracket at position 0 is closed by the right parenthesis at position 6",
+					msgAndArgs...,
+				)
+			},
+		},
+		{
+			name: "error/mismatched floor bracket",
+			args: args{text: "⌊x⌉", functions: functions},
+			want: "",
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(
+					t,
+					err,
+					"mismatched brackets: the left floor bracket at position 0 is closed by the right ceiling bracket at position 4",
+					msgAndArgs...,
+				)
+			},
+		},
+		{
+			name: "error/no opening bracket",
+			args: args{text: "x}", functions: functions},
+			want: "",
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(t, err, "no left brace is found, but a right brace at position 1", msgAndArgs...)
+			},
+		},
+		{
+			name: "error/unclosed absolute value",
+			args: args{text: "|x + 1", functions: functions},
+			want: "",
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(t, err, "unexpected vertical bar is found at position 0", msgAndArgs...)
+			},
+		},
+		{
+			name:    "error/comma inside brackets",
+			args:    args{text: "max([1, 2])", functions: functions},
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error/function call with brackets",
+			args:    args{text: "max[1]", functions: functions},
+			want:    "",
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := tokenizer.Tokenize(tt.args.text)
+			assert.NoError(t, err)
+
+			got, err := TranslateWithOptions(tokens, tt.args.functions, tt.args.options)
+
+			gotCommands := make([]string, 0, len(got))
+			for _, command := range got {
+				gotCommands = append(gotCommands, command.String())
+			}
+			assert.Equal(t, tt.want, strings.Join(gotCommands, "; "))
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestCommand_String(t *testing.T) {
+	tests := []struct {
+		name    string
+		command Command
+		want    string
+	}{
+		{name: "push", command: Command{Kind: PushNumberCommand, Operand: "23"}, want: "push 23"},
+		{name: "load", command: Command{Kind: PushVariableCommand, Operand: "x"}, want: "load x"},
+		{name: "call", command: Command{Kind: CallFunctionCommand, Operand: "+"}, want: "call +"},
+		{name: "assign", command: Command{Kind: AssignVariableCommand, Operand: "x"}, want: "assign x"},
+		{name: "bind", command: Command{Kind: BindVariableCommand, Operand: "x"}, want: "bind x"},
+		{name: "unbind", command: Command{Kind: UnbindVariableCommand, Operand: "x"}, want: "unbind x"},
+		{name: "pop", command: Command{Kind: PopCommand}, want: "pop"},
+		{name: "unknown", command: Command{Kind: CommandKind(100)}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.command.String()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
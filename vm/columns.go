@@ -0,0 +1,284 @@
+package vm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	DefaultChunkSize = 1024
+)
+
+type ColumnOptions struct {
+	ChunkSize int
+	Workers   int
+}
+
+type RowError struct {
+	Row int
+	Err error
+}
+
+func (err RowError) Error() string {
+	return fmt.Sprintf("row %d: %s", err.Row, err.Err)
+}
+
+func (err RowError) Unwrap() error {
+	return err.Err
+}
+
+type RowErrors []RowError
+
+func (errs RowErrors) Error() string {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+func (errs RowErrors) Unwrap() []error {
+	unwrappedErrs := make([]error, 0, len(errs))
+	for _, err := range errs {
+		unwrappedErrs = append(unwrappedErrs, err)
+	}
+
+	return unwrappedErrs
+}
+
+type columnChunk struct {
+	start int
+	end   int
+}
+
+type columnMachine struct {
+	program   *Program
+	inputs    [][]float64
+	stack     [][]float64
+	slots     [][]float64
+	bindings  [][]float64
+	arguments []float64
+	failures  []error
+}
+
+func EvaluateColumns(program *Program, columns map[string][]float64, options ColumnOptions) ([]float64, error) {
+	rowCount, err := columnRowCount(columns)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make([][]float64, len(program.slots))
+	for slot, name := range program.slots {
+		inputs[slot] = columns[name]
+	}
+	for _, name := range program.inputs {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("unknown variable %q", name)
+		}
+	}
+
+	chunkSize := options.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	if chunkSize > rowCount {
+		chunkSize = rowCount
+	}
+
+	workers := options.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	chunks := make(chan columnChunk)
+	go func() {
+		defer close(chunks)
+
+		for start := 0; start < rowCount; start += chunkSize {
+			end := start + chunkSize
+			if end > rowCount {
+				end = rowCount
+			}
+
+			chunks <- columnChunk{start: start, end: end}
+		}
+	}()
+
+	results := make([]float64, rowCount)
+	workerErrs := make([]RowErrors, workers)
+	var waitGroup sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		waitGroup.Add(1)
+		go func(worker int) {
+			defer waitGroup.Done()
+
+			machine := newColumnMachine(program, inputs, chunkSize)
+			for chunk := range chunks {
+				workerErrs[worker] = machine.run(chunk, results, workerErrs[worker])
+			}
+		}(worker)
+	}
+	waitGroup.Wait()
+
+	var rowErrs RowErrors
+	for _, errs := range workerErrs {
+		rowErrs = append(rowErrs, errs...)
+	}
+	if len(rowErrs) != 0 {
+		sort.Slice(rowErrs, func(i, j int) bool { return rowErrs[i].Row < rowErrs[j].Row })
+		return results, rowErrs
+	}
+
+	return results, nil
+}
+
+func columnRowCount(columns map[string][]float64) (int, error) {
+	if len(columns) == 0 {
+		return 0, errors.New("no columns are given")
+	}
+
+	names := make([]string, 0, len(columns))
+	for name := range columns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rowCount := len(columns[names[0]])
+	for _, name := range names[1:] {
+		if len(columns[name]) != rowCount {
+			return 0, fmt.Errorf(
+				"column %q has %d rows, but column %q has %d rows",
+				name,
+				len(columns[name]),
+				names[0],
+				rowCount,
+			)
+		}
+	}
+
+	return rowCount, nil
+}
+
+func newColumnMachine(program *Program, inputs [][]float64, chunkSize int) *columnMachine {
+	maximalArity := 0
+	for _, function := range program.functions {
+		if function.arity > maximalArity {
+			maximalArity = function.arity
+		}
+	}
+
+	return &columnMachine{
+		program:   program,
+		inputs:    inputs,
+		stack:     makeColumns(program.stackSize, chunkSize),
+		slots:     makeColumns(len(program.slots), chunkSize),
+		bindings:  makeColumns(program.bindingDepth, chunkSize),
+		arguments: make([]float64, maximalArity),
+		failures:  make([]error, chunkSize),
+	}
+}
+
+func (machine *columnMachine) run(chunk columnChunk, results []float64, errs RowErrors) RowErrors {
+	program := machine.program
+	rowCount := chunk.end - chunk.start
+	failures := machine.failures[:rowCount]
+	for row := range failures {
+		failures[row] = nil
+	}
+
+	for slot, input := range machine.inputs {
+		if input == nil {
+			for row := range machine.slots[slot][:rowCount] {
+				machine.slots[slot][row] = 0
+			}
+
+			continue
+		}
+
+		copy(machine.slots[slot], input[chunk.start:chunk.end])
+	}
+
+	stackDepth, bindingDepth := 0, 0
+	for _, instruction := range program.instructions {
+		switch instruction.opcode {
+		case pushNumberOpcode:
+			column := machine.stack[stackDepth][:rowCount]
+			for row := range column {
+				column[row] = program.constants[instruction.operand]
+			}
+
+			stackDepth++
+		case pushVariableOpcode:
+			copy(machine.stack[stackDepth], machine.slots[instruction.operand][:rowCount])
+			stackDepth++
+		case callFunctionOpcode:
+			function := &program.functions[instruction.operand]
+			argumentsStart := stackDepth - function.arity
+			arguments := machine.arguments[:function.arity:function.arity]
+			for row := 0; row < rowCount; row++ {
+				if failures[row] != nil {
+					continue
+				}
+
+				for argumentIndex := range arguments {
+					arguments[argumentIndex] = machine.stack[argumentsStart+argumentIndex][row]
+				}
+
+				number, err := function.handler(arguments)
+				if err != nil {
+					failures[row] = fmt.Errorf(
+						"unable to call the function %q at position %d: %w",
+						function.name,
+						instruction.position,
+						err,
+					)
+					continue
+				}
+
+				machine.stack[argumentsStart][row] = number
+			}
+
+			stackDepth = argumentsStart + 1
+		case assignVariableOpcode:
+			copy(machine.slots[instruction.operand], machine.stack[stackDepth-1][:rowCount])
+		case bindVariableOpcode:
+			copy(machine.bindings[bindingDepth], machine.slots[instruction.operand][:rowCount])
+			bindingDepth++
+
+			stackDepth--
+			copy(machine.slots[instruction.operand], machine.stack[stackDepth][:rowCount])
+		case unbindVariableOpcode:
+			bindingDepth--
+			copy(machine.slots[instruction.operand], machine.bindings[bindingDepth][:rowCount])
+		case popOpcode:
+			stackDepth--
+		}
+	}
+
+	for row, number := range machine.stack[stackDepth-1][:rowCount] {
+		if failures[row] != nil {
+			results[chunk.start+row] = math.NaN()
+			errs = append(errs, RowError{Row: chunk.start + row, Err: failures[row]})
+			continue
+		}
+
+		results[chunk.start+row] = number
+	}
+
+	return errs
+}
+
+func makeColumns(count int, rowCount int) [][]float64 {
+	columns := make([][]float64, count)
+	for columnIndex := range columns {
+		columns[columnIndex] = make([]float64, rowCount)
+	}
+
+	return columns
+}
@@ -0,0 +1,221 @@
+package vm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateColumns(t *testing.T) {
+	type args struct {
+		text    string
+		columns map[string][]float64
+		options ColumnOptions
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    []float64
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success/expression",
+			args: args{
+				text:    "price * qty + 1",
+				columns: map[string][]float64{"price": {1, 2, 3, 4, 5}, "qty": {10, 20, 30, 40, 50}},
+				options: ColumnOptions{ChunkSize: 2},
+			},
+			want:    []float64{11, 41, 91, 161, 251},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/assignments and let bindings",
+			args: args{
+				text:    "total = x * 2; let x = total + 1 in x * x",
+				columns: map[string][]float64{"x": {1, 2, 3}},
+				options: ColumnOptions{ChunkSize: 2, Workers: 2},
+			},
+			want:    []float64{9, 25, 49},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/constant expression",
+			args: args{
+				text:    "2 + 3",
+				columns: map[string][]float64{"unused": {0, 0}},
+			},
+			want:    []float64{5, 5},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success/empty columns",
+			args: args{
+				text:    "x + 1",
+				columns: map[string][]float64{"x": {}},
+			},
+			want:    []float64{},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error/row errors",
+			args: args{
+				text:    "1 / x + 1 / (x - 3)",
+				columns: map[string][]float64{"x": {0, 1, 2, 3, 4}},
+				options: ColumnOptions{ChunkSize: 2, Workers: 3},
+			},
+			want: []float64{math.NaN(), 0.5, -0.5, math.NaN(), 1.25},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				var rowErrs RowErrors
+				return assert.ErrorAs(t, err, &rowErrs, msgAndArgs...) &&
+					assert.Equal(t, []int{0, 3}, rowErrorRows(rowErrs), msgAndArgs...) &&
+					assert.EqualError(
+						t,
+						err,
+						"row 0: unable to call the function \"/\" at position 2: division by zero\n"+
+							"row 3: unable to call the function \"/\" at position 10: division by zero",
+						msgAndArgs...,
+					)
+			},
+		},
+		{
+			name: "error/missing column",
+			args: args{
+				text:    "x + y",
+				columns: map[string][]float64{"x": {1}},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/columns of different lengths",
+			args: args{
+				text:    "x + y",
+				columns: map[string][]float64{"x": {1, 2}, "y": {1}},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error/no columns",
+			args: args{
+				text:    "2 + 3",
+				columns: map[string][]float64{},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program, err := Link(compile(t, tt.args.text), builtin.Functions())
+			assert.NoError(t, err)
+
+			got, err := EvaluateColumns(program, tt.args.columns, tt.args.options)
+
+			assertNumbers(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestEvaluateColumns_matchesMachine(t *testing.T) {
+	functions := builtin.Functions()
+	random := rand.New(rand.NewSource(1))
+	for iteration := 0; iteration < 500; iteration++ {
+		text := randomProgram(random)
+		columns := map[string][]float64{"x": make([]float64, 37), "y": make([]float64, 37)}
+		for row := range columns["x"] {
+			columns["x"][row] = randomOperand(random)
+			columns["y"][row] = randomOperand(random)
+		}
+
+		t.Run(text, func(t *testing.T) {
+			program, err := Link(compile(t, text), functions)
+			assert.NoError(t, err)
+
+			got, err := EvaluateColumns(program, columns, ColumnOptions{ChunkSize: 8, Workers: 4})
+
+			var rowErrs RowErrors
+			if err != nil {
+				assert.True(t, errors.As(err, &rowErrs))
+			}
+
+			machine := NewMachine(program)
+			wantRowErrs := RowErrors(nil)
+			for row := range got {
+				variables, err := program.Variables(map[string]float64{"x": columns["x"][row], "y": columns["y"][row]})
+				assert.NoError(t, err)
+
+				want, err := machine.Run(variables)
+				if err != nil {
+					wantRowErrs = append(wantRowErrs, RowError{Row: row, Err: err})
+					assert.True(t, math.IsNaN(got[row]), fmt.Sprintf("row %d", row))
+					continue
+				}
+
+				assertNumbers(t, []float64{want}, []float64{got[row]})
+			}
+
+			assert.Equal(t, wantRowErrs, rowErrs)
+		})
+	}
+}
+
+func BenchmarkEvaluateColumns(b *testing.B) {
+	program, err := Link(
+		compile(b, "price * qty * (1 + rate) - max(discount, 0) + sqrt(abs(x))"),
+		builtin.Functions(),
+	)
+	assert.NoError(b, err)
+
+	const rowCount = 1 << 16
+	columns := map[string][]float64{}
+	for _, name := range program.Inputs() {
+		columns[name] = make([]float64, rowCount)
+		for row := range columns[name] {
+			columns[name][row] = float64(row % 100)
+		}
+	}
+
+	for _, workers := range []int{1, 4} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			b.ReportAllocs()
+			for iteration := 0; iteration < b.N; iteration++ {
+				_, _ = EvaluateColumns(program, columns, ColumnOptions{Workers: workers})
+			}
+		})
+	}
+}
+
+func rowErrorRows(errs RowErrors) []int {
+	rows := make([]int, 0, len(errs))
+	for _, err := range errs {
+		rows = append(rows, err.Row)
+	}
+
+	return rows
+}
+
+func assertNumbers(t *testing.T, want []float64, got []float64) {
+	t.Helper()
+
+	if !assert.Equal(t, len(want), len(got)) || want == nil {
+		assert.Equal(t, want, got)
+		return
+	}
+
+	for index := range want {
+		if math.IsNaN(want[index]) {
+			assert.True(t, math.IsNaN(got[index]), fmt.Sprintf("index %d", index))
+			continue
+		}
+
+		assert.Equal(t, want[index], got[index], fmt.Sprintf("index %d", index))
+	}
+}
@@ -0,0 +1,74 @@
+package vm
+
+import (
+	"fmt"
+)
+
+type Machine struct {
+	program  *Program
+	stack    []float64
+	slots    []float64
+	bindings []float64
+}
+
+func NewMachine(program *Program) *Machine {
+	return &Machine{
+		program:  program,
+		stack:    make([]float64, program.stackSize),
+		slots:    make([]float64, len(program.slots)),
+		bindings: make([]float64, program.bindingDepth),
+	}
+}
+
+func (machine *Machine) Run(variables []float64) (float64, error) {
+	program := machine.program
+	if len(variables) < len(program.slots) {
+		return 0, fmt.Errorf("expected %d variables, got %d", len(program.slots), len(variables))
+	}
+
+	stack := machine.stack
+	bindings := machine.bindings
+	slots := machine.slots
+	copy(slots, variables)
+	stackDepth, bindingDepth := 0, 0
+	for _, instruction := range program.instructions {
+		switch instruction.opcode {
+		case pushNumberOpcode:
+			stack[stackDepth] = program.constants[instruction.operand]
+			stackDepth++
+		case pushVariableOpcode:
+			stack[stackDepth] = slots[instruction.operand]
+			stackDepth++
+		case callFunctionOpcode:
+			function := &program.functions[instruction.operand]
+			argumentsStart := stackDepth - function.arity
+
+			number, err := function.handler(stack[argumentsStart:stackDepth:stackDepth])
+			if err != nil {
+				return 0, fmt.Errorf("unable to call the function %q at position %d: %w", function.name, instruction.position, err)
+			}
+
+			stack[argumentsStart] = number
+			stackDepth = argumentsStart + 1
+		case assignVariableOpcode:
+			slots[instruction.operand] = stack[stackDepth-1]
+		case bindVariableOpcode:
+			bindings[bindingDepth] = slots[instruction.operand]
+			bindingDepth++
+
+			stackDepth--
+			slots[instruction.operand] = stack[stackDepth]
+		case unbindVariableOpcode:
+			bindingDepth--
+			slots[instruction.operand] = bindings[bindingDepth]
+		case popOpcode:
+			stackDepth--
+		}
+	}
+
+	return stack[stackDepth-1], nil
+}
+
+func (machine *Machine) Variables() []float64 {
+	return machine.slots
+}
@@ -0,0 +1,243 @@
+package vm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMachine_Run(t *testing.T) {
+	type args struct {
+		text      string
+		variables map[string]float64
+	}
+
+	tests := []struct {
+		name          string
+		args          args
+		want          float64
+		wantVariables map[string]float64
+		wantErr       assert.ErrorAssertionFunc
+	}{
+		{
+			name:          "success/expression",
+			args:          args{text: "price * qty + sqrt(16)", variables: map[string]float64{"price": 12.5, "qty": 33}},
+			want:          416.5,
+			wantVariables: map[string]float64{"price": 12.5, "qty": 33},
+			wantErr:       assert.NoError,
+		},
+		{
+			name:          "success/assignments",
+			args:          args{text: "rate = 0.5; years = 2\nprincipal * (1 + rate) * years", variables: map[string]float64{"principal": 100}},
+			want:          300,
+			wantVariables: map[string]float64{"principal": 100, "rate": 0.5, "years": 2},
+			wantErr:       assert.NoError,
+		},
+		{
+			name:          "success/let bindings",
+			args:          args{text: "total = 2 * (let x = 3 in (let x = x + 1 in x) * x) + x", variables: map[string]float64{"x": 10}},
+			want:          34,
+			wantVariables: map[string]float64{"x": 10, "total": 34},
+			wantErr:       assert.NoError,
+		},
+		{
+			name:          "error/function failure",
+			args:          args{text: "let x = 0 in 1 / x", variables: map[string]float64{"x": 10}},
+			want:          0,
+			wantVariables: nil,
+			wantErr:       assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program, err := Link(compile(t, tt.args.text), builtin.Functions())
+			assert.NoError(t, err)
+
+			variables, err := program.Variables(tt.args.variables)
+			assert.NoError(t, err)
+
+			machine := NewMachine(program)
+			got, err := machine.Run(variables)
+
+			var gotVariables map[string]float64
+			if err == nil {
+				gotVariables = map[string]float64{}
+				for slot, name := range program.Slots() {
+					gotVariables[name] = machine.Variables()[slot]
+				}
+			}
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantVariables, gotVariables)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestMachine_Run_notEnoughVariables(t *testing.T) {
+	program, err := Link(compile(t, "x + y"), builtin.Functions())
+	assert.NoError(t, err)
+
+	got, err := NewMachine(program).Run([]float64{1})
+
+	assert.Equal(t, 0.0, got)
+	assert.Error(t, err)
+}
+
+func TestMachine_Run_matchesEvaluator(t *testing.T) {
+	functions := builtin.Functions()
+	random := rand.New(rand.NewSource(1))
+	for iteration := 0; iteration < 2000; iteration++ {
+		text := randomProgram(random)
+		variables := map[string]float64{
+			"x": randomOperand(random),
+			"y": randomOperand(random),
+		}
+
+		t.Run(fmt.Sprintf("%s/%v", text, variables), func(t *testing.T) {
+			commands := compile(t, text)
+			want, wantVariables, wantErr := evaluator.EvaluateWithVariables(commands, variables, functions)
+
+			program, err := Link(commands, functions)
+			assert.NoError(t, err)
+
+			slotValues, err := program.Variables(variables)
+			assert.NoError(t, err)
+
+			machine := NewMachine(program)
+			for run := 0; run < 2; run++ {
+				got, gotErr := machine.Run(slotValues)
+
+				assert.Equal(t, wantErr != nil, gotErr != nil)
+				if errors.Unwrap(wantErr) != nil {
+					assert.Equal(t, wantErr.Error(), gotErr.Error())
+				}
+				if wantErr != nil {
+					continue
+				}
+
+				if math.IsNaN(want) {
+					assert.True(t, math.IsNaN(got))
+				} else {
+					assert.Equal(t, want, got)
+				}
+				for slot, name := range program.Slots() {
+					if wantValue, ok := wantVariables[name]; ok && !math.IsNaN(wantValue) {
+						assert.Equal(t, wantValue, machine.Variables()[slot])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestMachine_Run_reusesInputs(t *testing.T) {
+	program, err := Link(compile(t, "x = x + 1; x * 2"), builtin.Functions())
+	assert.NoError(t, err)
+
+	variables, err := program.Variables(map[string]float64{"x": 1})
+	assert.NoError(t, err)
+
+	machine := NewMachine(program)
+	for run := 0; run < 3; run++ {
+		got, err := machine.Run(variables)
+
+		assert.Equal(t, 4.0, got)
+		assert.Equal(t, []float64{1}, variables)
+		assert.Equal(t, []float64{2}, machine.Variables())
+		assert.NoError(t, err)
+	}
+}
+
+func TestMachine_Run_allocations(t *testing.T) {
+	program, err := Link(
+		compile(t, "total = price * qty; let tax = total * 0.2 in max(total + tax, sqrt(total)) - x^2"),
+		builtin.Functions(),
+	)
+	assert.NoError(t, err)
+
+	variables, err := program.Variables(map[string]float64{"price": 12.5, "qty": 33, "x": 2})
+	assert.NoError(t, err)
+
+	machine := NewMachine(program)
+	allocations := testing.AllocsPerRun(100, func() {
+		_, _ = machine.Run(variables)
+	})
+
+	assert.Equal(t, 0.0, allocations)
+}
+
+func BenchmarkMachine_Run(b *testing.B) {
+	commands := compile(b, "price * qty * (1 + rate) - max(discount, 0) + sqrt(abs(x))")
+	program, err := Link(commands, builtin.Functions())
+	assert.NoError(b, err)
+
+	variables, err := program.Variables(map[string]float64{"price": 12.5, "qty": 33, "rate": 0.2, "discount": 5, "x": -4})
+	assert.NoError(b, err)
+
+	machine := NewMachine(program)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for iteration := 0; iteration < b.N; iteration++ {
+		_, _ = machine.Run(variables)
+	}
+}
+
+func BenchmarkEvaluate(b *testing.B) {
+	commands := compile(b, "price * qty * (1 + rate) - max(discount, 0) + sqrt(abs(x))")
+	functions := builtin.Functions()
+	variables := map[string]float64{"price": 12.5, "qty": 33, "rate": 0.2, "discount": 5, "x": -4}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for iteration := 0; iteration < b.N; iteration++ {
+		_, _ = evaluator.Evaluate(commands, variables, functions)
+	}
+}
+
+func randomProgram(random *rand.Rand) string {
+	switch random.Intn(3) {
+	case 0:
+		return "x = " + randomExpression(random, 3) + "; " + randomExpression(random, 3)
+	case 1:
+		return "let y = " + randomExpression(random, 3) + " in " + randomExpression(random, 3)
+	default:
+		return randomExpression(random, 4)
+	}
+}
+
+func randomExpression(random *rand.Rand, depth int) string {
+	if depth == 0 || random.Intn(4) == 0 {
+		operands := []string{"0", "1", "2", "0.5", "3", "x", "y"}
+		return operands[random.Intn(len(operands))]
+	}
+
+	switch random.Intn(5) {
+	case 0:
+		return "-" + randomExpression(random, depth-1)
+	case 1:
+		functionNames := []string{"sin", "sqrt", "abs"}
+		return fmt.Sprintf("%s(%s)", functionNames[random.Intn(len(functionNames))], randomExpression(random, depth-1))
+	case 2:
+		return fmt.Sprintf("(let x = %s in %s)", randomExpression(random, depth-1), randomExpression(random, depth-1))
+	default:
+		operators := []string{"+", "-", "*", "/", "%", "^"}
+		return strings.Join([]string{
+			"(" + randomExpression(random, depth-1),
+			operators[random.Intn(len(operators))],
+			randomExpression(random, depth-1) + ")",
+		}, " ")
+	}
+}
+
+func randomOperand(random *rand.Rand) float64 {
+	operands := []float64{0, 1, -1, 0.5, 2, 10}
+	return operands[random.Intn(len(operands))]
+}
@@ -0,0 +1,204 @@
+package vm
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/rmaidveo/go-calculator/translator"
+)
+
+type opcode uint8
+
+const (
+	pushNumberOpcode opcode = iota
+	pushVariableOpcode
+	callFunctionOpcode
+	assignVariableOpcode
+	bindVariableOpcode
+	unbindVariableOpcode
+	popOpcode
+)
+
+type instruction struct {
+	opcode   opcode
+	operand  int
+	position int
+}
+
+type function struct {
+	name    string
+	arity   int
+	handler func(arguments []float64) (float64, error)
+}
+
+type Program struct {
+	instructions []instruction
+	constants    []float64
+	functions    []function
+	slots        []string
+	slotIndexes  map[string]int
+	inputs       []string
+	stackSize    int
+	bindingDepth int
+}
+
+func Link(commands []translator.Command, functions map[string]evaluator.Function) (*Program, error) {
+	program := &Program{slotIndexes: map[string]int{}}
+	functionIndexes := map[string]int{}
+	definedVariables := map[string]int{}
+	isInput := map[string]bool{}
+	var bindings []int
+	stackDepth := 0
+	for _, command := range commands {
+		linkedInstruction := instruction{position: command.Position}
+		switch command.Kind {
+		case translator.PushNumberCommand:
+			number, err := strconv.ParseFloat(command.Operand, 64)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse the number at position %d: %w", command.Position, err)
+			}
+
+			linkedInstruction.opcode = pushNumberOpcode
+			linkedInstruction.operand = len(program.constants)
+			program.constants = append(program.constants, number)
+			stackDepth++
+		case translator.PushVariableCommand:
+			if definedVariables[command.Operand] == 0 && !isInput[command.Operand] {
+				isInput[command.Operand] = true
+				program.inputs = append(program.inputs, command.Operand)
+			}
+
+			linkedInstruction.opcode = pushVariableOpcode
+			linkedInstruction.operand = program.slot(command.Operand)
+			stackDepth++
+		case translator.CallFunctionCommand:
+			functionIndex, ok := functionIndexes[command.Operand]
+			if !ok {
+				linkedFunction, ok := functions[command.Operand]
+				if !ok {
+					return nil, fmt.Errorf("unknown function %q at position %d", command.Operand, command.Position)
+				}
+				if linkedFunction.Handler == nil {
+					return nil, fmt.Errorf("function %q has no handler at position %d", command.Operand, command.Position)
+				}
+
+				functionIndex = len(program.functions)
+				functionIndexes[command.Operand] = functionIndex
+				program.functions = append(program.functions, function{
+					name:    command.Operand,
+					arity:   linkedFunction.Arity,
+					handler: linkedFunction.Handler,
+				})
+			}
+
+			arity := program.functions[functionIndex].arity
+			if stackDepth < arity {
+				return nil, fmt.Errorf("number stack is empty for argument #%d at position %d", stackDepth, command.Position)
+			}
+
+			linkedInstruction.opcode = callFunctionOpcode
+			linkedInstruction.operand = functionIndex
+			stackDepth -= arity - 1
+		case translator.AssignVariableCommand:
+			if stackDepth == 0 {
+				return nil, fmt.Errorf("number stack is empty for the assignment to %q at position %d", command.Operand, command.Position)
+			}
+
+			if definedVariables[command.Operand] == 0 {
+				definedVariables[command.Operand] = 1
+			}
+
+			linkedInstruction.opcode = assignVariableOpcode
+			linkedInstruction.operand = program.slot(command.Operand)
+		case translator.BindVariableCommand:
+			if stackDepth == 0 {
+				return nil, fmt.Errorf("number stack is empty for the binding of %q at position %d", command.Operand, command.Position)
+			}
+
+			definedVariables[command.Operand]++
+			linkedInstruction.opcode = bindVariableOpcode
+			linkedInstruction.operand = program.slot(command.Operand)
+			bindings = append(bindings, linkedInstruction.operand)
+			stackDepth--
+		case translator.UnbindVariableCommand:
+			slot, ok := program.slotIndexes[command.Operand]
+			if !ok || len(bindings) == 0 || bindings[len(bindings)-1] != slot {
+				return nil, fmt.Errorf("no binding of %q is found at position %d", command.Operand, command.Position)
+			}
+
+			definedVariables[command.Operand]--
+			linkedInstruction.opcode = unbindVariableOpcode
+			linkedInstruction.operand = slot
+			bindings = bindings[:len(bindings)-1]
+		case translator.PopCommand:
+			if stackDepth == 0 {
+				return nil, fmt.Errorf("number stack is empty for the statement ending at position %d", command.Position)
+			}
+			if stackDepth > 1 {
+				return nil, fmt.Errorf("number stack has extra values for the statement ending at position %d", command.Position)
+			}
+
+			linkedInstruction.opcode = popOpcode
+			stackDepth--
+		default:
+			return nil, fmt.Errorf("unknown command kind %d at position %d", command.Kind, command.Position)
+		}
+
+		program.instructions = append(program.instructions, linkedInstruction)
+		if stackDepth > program.stackSize {
+			program.stackSize = stackDepth
+		}
+		if len(bindings) > program.bindingDepth {
+			program.bindingDepth = len(bindings)
+		}
+	}
+	if stackDepth == 0 {
+		return nil, errors.New("number stack is empty")
+	}
+	if stackDepth > 1 {
+		return nil, errors.New("number stack has extra values")
+	}
+
+	return program, nil
+}
+
+func (program *Program) Slots() []string {
+	return append([]string(nil), program.slots...)
+}
+
+func (program *Program) Slot(name string) (int, bool) {
+	slot, ok := program.slotIndexes[name]
+	return slot, ok
+}
+
+func (program *Program) Inputs() []string {
+	return append([]string(nil), program.inputs...)
+}
+
+func (program *Program) Variables(values map[string]float64) ([]float64, error) {
+	for _, name := range program.inputs {
+		if _, ok := values[name]; !ok {
+			return nil, fmt.Errorf("unknown variable %q", name)
+		}
+	}
+
+	variables := make([]float64, len(program.slots))
+	for slot, name := range program.slots {
+		variables[slot] = values[name]
+	}
+
+	return variables, nil
+}
+
+func (program *Program) slot(name string) int {
+	slot, ok := program.slotIndexes[name]
+	if !ok {
+		slot = len(program.slots)
+		program.slotIndexes[name] = slot
+		program.slots = append(program.slots, name)
+	}
+
+	return slot
+}
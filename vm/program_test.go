@@ -0,0 +1,212 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/rmaidveo/go-calculator/builtin"
+	"github.com/rmaidveo/go-calculator/evaluator"
+	"github.com/rmaidveo/go-calculator/tokenizer"
+	"github.com/rmaidveo/go-calculator/translator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLink(t *testing.T) {
+	tests := []struct {
+		name       string
+		commands   []translator.Command
+		wantSlots  []string
+		wantInputs []string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "success/inputs",
+			commands:   compile(t, "price * qty + price"),
+			wantSlots:  []string{"price", "qty"},
+			wantInputs: []string{"price", "qty"},
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "success/assignments are not inputs",
+			commands:   compile(t, "total = price * 2; total + tax"),
+			wantSlots:  []string{"price", "total", "tax"},
+			wantInputs: []string{"price", "tax"},
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "success/bindings are inputs outside of let",
+			commands:   compile(t, "(let x = 2 in x * y) + x"),
+			wantSlots:  []string{"x", "y"},
+			wantInputs: []string{"y", "x"},
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "success/read before assignment",
+			commands:   compile(t, "x = x + 1"),
+			wantSlots:  []string{"x"},
+			wantInputs: []string{"x"},
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "error/empty program",
+			commands:   nil,
+			wantSlots:  nil,
+			wantInputs: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error/invalid number",
+			commands: []translator.Command{
+				{Kind: translator.PushNumberCommand, Operand: "2..5", Position: 0},
+			},
+			wantSlots:  nil,
+			wantInputs: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error/unknown function",
+			commands: []translator.Command{
+				{Kind: translator.PushNumberCommand, Operand: "2", Position: 4},
+				{Kind: translator.CallFunctionCommand, Operand: "unknown", Position: 0},
+			},
+			wantSlots:  nil,
+			wantInputs: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error/function without handler",
+			commands: []translator.Command{
+				{Kind: translator.CallFunctionCommand, Operand: "nothing", Position: 0},
+			},
+			wantSlots:  nil,
+			wantInputs: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error/not enough arguments",
+			commands: []translator.Command{
+				{Kind: translator.PushNumberCommand, Operand: "2", Position: 0},
+				{Kind: translator.CallFunctionCommand, Operand: "+", Position: 2},
+			},
+			wantSlots:  nil,
+			wantInputs: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error/nothing to assign",
+			commands: []translator.Command{
+				{Kind: translator.AssignVariableCommand, Operand: "x", Position: 0},
+			},
+			wantSlots:  nil,
+			wantInputs: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error/nothing to bind",
+			commands: []translator.Command{
+				{Kind: translator.BindVariableCommand, Operand: "x", Position: 0},
+			},
+			wantSlots:  nil,
+			wantInputs: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error/unbind without bind",
+			commands: []translator.Command{
+				{Kind: translator.PushNumberCommand, Operand: "2", Position: 0},
+				{Kind: translator.UnbindVariableCommand, Operand: "x", Position: 0},
+			},
+			wantSlots:  nil,
+			wantInputs: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error/nothing to pop",
+			commands: []translator.Command{
+				{Kind: translator.PopCommand, Position: 0},
+			},
+			wantSlots:  nil,
+			wantInputs: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error/extra values to pop",
+			commands: []translator.Command{
+				{Kind: translator.PushNumberCommand, Operand: "1", Position: 0},
+				{Kind: translator.PushNumberCommand, Operand: "2", Position: 2},
+				{Kind: translator.PopCommand, Position: 3},
+				{Kind: translator.PushNumberCommand, Operand: "3", Position: 5},
+			},
+			wantSlots:  nil,
+			wantInputs: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error/extra values",
+			commands: []translator.Command{
+				{Kind: translator.PushNumberCommand, Operand: "1", Position: 0},
+				{Kind: translator.PushNumberCommand, Operand: "2", Position: 2},
+			},
+			wantSlots:  nil,
+			wantInputs: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error/unknown command kind",
+			commands: []translator.Command{
+				{Kind: translator.CommandKind(100), Position: 0},
+			},
+			wantSlots:  nil,
+			wantInputs: nil,
+			wantErr:    assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			functions := builtin.Functions()
+			functions["nothing"] = evaluator.Function{}
+
+			got, err := Link(tt.commands, functions)
+			tt.wantErr(t, err)
+			if err != nil {
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.Equal(t, tt.wantSlots, got.Slots())
+			assert.Equal(t, tt.wantInputs, got.Inputs())
+			for slot, name := range tt.wantSlots {
+				gotSlot, ok := got.Slot(name)
+				assert.True(t, ok)
+				assert.Equal(t, slot, gotSlot)
+			}
+		})
+	}
+}
+
+func TestProgram_Variables(t *testing.T) {
+	program, err := Link(compile(t, "total = price * qty; total"), builtin.Functions())
+	assert.NoError(t, err)
+
+	got, err := program.Variables(map[string]float64{"price": 2, "qty": 3, "unused": 4})
+	assert.Equal(t, []float64{2, 3, 0}, got)
+	assert.NoError(t, err)
+
+	got, err = program.Variables(map[string]float64{"price": 2})
+	assert.Nil(t, got)
+	assert.Error(t, err)
+}
+
+func compile(t testing.TB, text string) []translator.Command {
+	functionNames := map[string]struct{}{}
+	for functionName := range builtin.Functions() {
+		functionNames[functionName] = struct{}{}
+	}
+
+	tokens, err := tokenizer.Tokenize(text)
+	assert.NoError(t, err)
+
+	commands, err := translator.Translate(tokens, functionNames)
+	assert.NoError(t, err)
+
+	return commands
+}